@@ -38,6 +38,7 @@ require (
 	github.com/fluxcd/flux2/v2 v2.9.1
 	github.com/fluxcd/helm-controller/api v1.6.2
 	github.com/fluxcd/image-automation-controller/api v1.2.2
+	github.com/fluxcd/image-reflector-controller/api v1.2.2
 	github.com/fluxcd/kustomize-controller/api v1.9.2
 	github.com/fluxcd/notification-controller/api v1.9.2
 	github.com/fluxcd/pkg/apis/acl v0.10.0
@@ -52,7 +53,9 @@ require (
 	k8s.io/api v0.36.2
 	k8s.io/apiextensions-apiserver v0.36.2
 	k8s.io/apimachinery v0.36.2
+	k8s.io/autoscaler/vertical-pod-autoscaler v1.4.1
 	k8s.io/cli-runtime v0.36.2
+	k8s.io/client-go v0.36.2
 	sigs.k8s.io/controller-runtime v0.24.1
 	sigs.k8s.io/gateway-api v1.6.0
 	sigs.k8s.io/kustomize/api v0.21.1
@@ -199,7 +202,6 @@ require (
 	google.golang.org/protobuf v1.36.12-0.20260120151049-f2248ac996af // indirect
 	gopkg.in/evanphx/json-patch.v4 v4.13.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
-	k8s.io/client-go v0.36.2 // indirect
 	k8s.io/klog/v2 v2.140.0 // indirect
 	k8s.io/kube-openapi v0.0.0-20260603220949-865597e52e25 // indirect
 	k8s.io/streaming v0.36.2 // indirect
@@ -70,6 +70,18 @@
 //
 //	err := errors.NewFileError("read", "/path/to/file", "permission denied", nil)
 //
+// # Warnings
+//
+// Non-fatal findings are collected in a Warnings value instead of being
+// returned as errors. A nil *Warnings discards everything reported to it, so
+// APIs can accept the collector as an optional argument:
+//
+//	warnings := &errors.Warnings{}
+//	resources, err := bundle.GenerateWithWarnings(warnings)
+//	for _, w := range warnings.Items() {
+//	    fmt.Println(w)
+//	}
+//
 // # Integration
 //
 // All error types implement the standard error interface and support
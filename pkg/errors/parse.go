@@ -4,13 +4,19 @@ import "strings"
 
 // ParseErrors aggregates multiple errors returned during YAML decoding.
 // It implements the error interface and unwraps to the underlying errors.
+//
+// Warnings holds findings that did not prevent an object from being
+// decoded, such as unknown fields reported in strict mode when no warnings
+// collector was supplied. A ParseErrors holding only warnings still
+// describes them through Error; use HasErrors to tell the two cases apart.
 type ParseErrors struct {
-	Errors []error
+	Errors   []error
+	Warnings []Warning
 }
 
 func (pe *ParseErrors) Error() string {
 	if len(pe.Errors) == 0 {
-		return ""
+		return pe.warningsString()
 	}
 	if len(pe.Errors) == 1 {
 		return pe.Errors[0].Error()
@@ -25,6 +31,23 @@ func (pe *ParseErrors) Error() string {
 	return strings.TrimSuffix(b.String(), ";")
 }
 
+// HasErrors reports whether any document failed to decode, as opposed to
+// decoding with warnings only.
+func (pe *ParseErrors) HasErrors() bool {
+	return len(pe.Errors) > 0
+}
+
 func (pe *ParseErrors) Unwrap() []error {
 	return pe.Errors
 }
+
+func (pe *ParseErrors) warningsString() string {
+	if len(pe.Warnings) == 0 {
+		return ""
+	}
+	parts := make([]string, len(pe.Warnings))
+	for i, w := range pe.Warnings {
+		parts[i] = w.String()
+	}
+	return "parse warnings: " + strings.Join(parts, "; ")
+}
@@ -67,3 +67,23 @@ func TestParseErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestParseErrorsWarnings(t *testing.T) {
+	pe := &kerrors.ParseErrors{Warnings: []kerrors.Warning{
+		{Component: "Deployment", Path: "web", Message: `unknown field "spec.foo"`},
+	}}
+	if pe.HasErrors() {
+		t.Error("expected warnings only")
+	}
+	if got := pe.Error(); got != `parse warnings: Deployment web: unknown field "spec.foo"` {
+		t.Errorf("unexpected message %q", got)
+	}
+
+	pe.Errors = []error{errors.New("bad document")}
+	if !pe.HasErrors() {
+		t.Error("expected errors")
+	}
+	if got := pe.Error(); got != "bad document" {
+		t.Errorf("expected errors to take precedence, got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package errors
+
+import "fmt"
+
+// Warning is a non-fatal finding reported by an operation that otherwise
+// succeeded, such as a skipped nil entry or an option that was overridden.
+type Warning struct {
+	// Component names the API that reported the warning (e.g. "Bundle").
+	Component string
+	// Path locates the finding within the input, such as a node path or an
+	// application name. It may be empty.
+	Path string
+	// Message describes the finding.
+	Message string
+}
+
+// String formats the warning as "component path: message".
+func (w Warning) String() string {
+	if w.Path == "" {
+		return fmt.Sprintf("%s: %s", w.Component, w.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", w.Component, w.Path, w.Message)
+}
+
+// Warnings collects Warning values so callers can inspect non-fatal findings
+// programmatically. APIs accept a *Warnings as an optional collector: a nil
+// collector discards everything reported to it. Warnings is not safe for
+// concurrent use.
+type Warnings struct {
+	items []Warning
+}
+
+// Add records a warning with a literal message. Calling Add on a nil
+// collector is a no-op.
+func (w *Warnings) Add(component, path, msg string) {
+	if w == nil {
+		return
+	}
+	w.items = append(w.items, Warning{Component: component, Path: path, Message: msg})
+}
+
+// Addf records a warning whose message is formatted with fmt.Sprintf.
+// Calling Addf on a nil collector is a no-op.
+func (w *Warnings) Addf(component, path, format string, args ...any) {
+	if w == nil {
+		return
+	}
+	w.Add(component, path, fmt.Sprintf(format, args...))
+}
+
+// Items returns a copy of the recorded warnings in the order they were added.
+func (w *Warnings) Items() []Warning {
+	if w == nil || len(w.items) == 0 {
+		return nil
+	}
+	out := make([]Warning, len(w.items))
+	copy(out, w.items)
+	return out
+}
+
+// Len returns the number of recorded warnings.
+func (w *Warnings) Len() int {
+	if w == nil {
+		return 0
+	}
+	return len(w.items)
+}
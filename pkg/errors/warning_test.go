@@ -0,0 +1,58 @@
+package errors_test
+
+import (
+	"testing"
+
+	kerrors "github.com/go-kure/kure/pkg/errors"
+)
+
+func TestWarnings(t *testing.T) {
+	t.Run("nil collector discards", func(t *testing.T) {
+		var w *kerrors.Warnings
+		w.Add("Bundle", "apps", "skipped nil application")
+		if w.Len() != 0 {
+			t.Errorf("Expected 0 warnings on nil collector, got %d", w.Len())
+		}
+		if w.Items() != nil {
+			t.Errorf("Expected nil items on nil collector, got %v", w.Items())
+		}
+	})
+
+	t.Run("records in order", func(t *testing.T) {
+		w := &kerrors.Warnings{}
+		w.Addf("Bundle", "apps", "skipped nil application at index %d", 2)
+		w.Add("layout", "", "FilePer overridden")
+
+		items := w.Items()
+		if len(items) != 2 {
+			t.Fatalf("Expected 2 warnings, got %d", len(items))
+		}
+		if items[0].Message != "skipped nil application at index 2" {
+			t.Errorf("Unexpected formatted message: %q", items[0].Message)
+		}
+		if got := items[0].String(); got != "Bundle apps: skipped nil application at index 2" {
+			t.Errorf("Unexpected String(): %q", got)
+		}
+		if got := items[1].String(); got != "layout: FilePer overridden" {
+			t.Errorf("Unexpected String() without path: %q", got)
+		}
+	})
+
+	t.Run("Add keeps the message literal", func(t *testing.T) {
+		w := &kerrors.Warnings{}
+		w.Add("io", "", "100% literal")
+		if got := w.Items()[0].Message; got != "100% literal" {
+			t.Errorf("Expected literal message, got %q", got)
+		}
+	})
+
+	t.Run("items are a copy", func(t *testing.T) {
+		w := &kerrors.Warnings{}
+		w.Add("io", "", "first")
+		items := w.Items()
+		items[0].Message = "changed"
+		if w.Items()[0].Message != "first" {
+			t.Error("Expected Items to return a copy")
+		}
+	})
+}
@@ -34,12 +34,14 @@ func (f ConversionFunc) Convert(from any) (any, error) {
 type ConversionRegistry struct {
 	mu          sync.RWMutex
 	conversions map[string]map[string]Converter // [fromGVK][toGVK] -> Converter
+	gvks        map[string]GVK                  // GVK.String() -> GVK for every registered endpoint
 }
 
 // NewConversionRegistry creates a new conversion registry
 func NewConversionRegistry() *ConversionRegistry {
 	return &ConversionRegistry{
 		conversions: make(map[string]map[string]Converter),
+		gvks:        make(map[string]GVK),
 	}
 }
 
@@ -55,6 +57,8 @@ func (r *ConversionRegistry) Register(from, to GVK, converter Converter) {
 		r.conversions[fromKey] = make(map[string]Converter)
 	}
 	r.conversions[fromKey][toKey] = converter
+	r.gvks[fromKey] = from
+	r.gvks[toKey] = to
 }
 
 // RegisterFunc registers a conversion function
@@ -62,30 +66,36 @@ func (r *ConversionRegistry) RegisterFunc(from, to GVK, converter ConversionFunc
 	r.Register(from, to, converter)
 }
 
-// Convert converts from one GVK to another.
+// Convert converts from one GVK to another. When no direct converter is
+// registered, the shortest chain of registered conversions is applied in
+// order (e.g. v1alpha1 -> v1alpha2 -> v1beta1).
 func (r *ConversionRegistry) Convert(from, to GVK, obj any) (any, error) {
 	if from == to {
 		return obj, nil // No conversion needed
 	}
 
 	r.mu.RLock()
-	fromKey := from.String()
-	toKey := to.String()
-
-	var converter Converter
-	if fromConverters, exists := r.conversions[fromKey]; exists {
-		converter = fromConverters[toKey]
-	}
+	path, converters := r.findPath(from, to)
 	r.mu.RUnlock()
 
-	if converter != nil {
-		return converter.Convert(obj)
+	if path == nil {
+		return nil, errors.Errorf("no conversion path from %s to %s", from, to)
 	}
 
-	return nil, errors.Errorf("no conversion path from %s to %s", from, to)
+	// Converters run without the lock held so they may use the registry.
+	current := obj
+	for i, converter := range converters {
+		next, err := converter.Convert(current)
+		if err != nil {
+			return nil, errors.Wrapf(err, "convert %s to %s", path[i], path[i+1])
+		}
+		current = next
+	}
+	return current, nil
 }
 
-// HasConversion checks if a conversion path exists.
+// HasConversion checks if a conversion path exists, either directly or
+// through a chain of registered conversions.
 func (r *ConversionRegistry) HasConversion(from, to GVK) bool {
 	if from == to {
 		return true
@@ -94,15 +104,108 @@ func (r *ConversionRegistry) HasConversion(from, to GVK) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	path, _ := r.findPath(from, to)
+	return path != nil
+}
+
+// FindPath returns the shortest chain of registered conversions from one GVK
+// to another, including both endpoints. A direct conversion yields a path of
+// length two. An error is returned when no chain exists.
+func (r *ConversionRegistry) FindPath(from, to GVK) ([]GVK, error) {
+	if from == to {
+		return []GVK{from}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	path, _ := r.findPath(from, to)
+	if path == nil {
+		return nil, errors.Errorf("no conversion path from %s to %s", from, to)
+	}
+	return path, nil
+}
+
+// ConvertToLatest converts obj to the newest version of the same group and
+// kind that is reachable through registered conversions. Versions are
+// ordered with VersionComparator. It returns the target GVK together with
+// the converted object; obj is returned unchanged when from is already the
+// newest reachable version.
+func (r *ConversionRegistry) ConvertToLatest(from GVK, obj any) (GVK, any, error) {
+	r.mu.RLock()
+	candidates := []GVK{from}
+	for key, target := range r.gvks {
+		if key == from.String() || target.Group != from.Group || target.Kind != from.Kind {
+			continue
+		}
+		if path, _ := r.findPath(from, target); path != nil {
+			candidates = append(candidates, target)
+		}
+	}
+	r.mu.RUnlock()
+
+	vc := &VersionComparator{}
+	latest, err := vc.GetLatestVersion(candidates)
+	if err != nil {
+		return GVK{}, nil, err
+	}
+	converted, err := r.Convert(from, latest, obj)
+	if err != nil {
+		return GVK{}, nil, err
+	}
+	return latest, converted, nil
+}
+
+// findPath performs a breadth-first search over registered conversions and
+// returns the visited GVKs and the converters to apply between them, or nil
+// when to is unreachable. Neighbours are visited in sorted order so the
+// chosen path is deterministic. Callers must hold r.mu.
+func (r *ConversionRegistry) findPath(from, to GVK) ([]GVK, []Converter) {
 	fromKey := from.String()
 	toKey := to.String()
 
-	if fromConverters, exists := r.conversions[fromKey]; exists {
-		_, exists := fromConverters[toKey]
-		return exists
+	prev := map[string]string{fromKey: ""}
+	queue := []string{fromKey}
+	for len(queue) > 0 && !hasKey(prev, toKey) {
+		current := queue[0]
+		queue = queue[1:]
+
+		next := make([]string, 0, len(r.conversions[current]))
+		for key := range r.conversions[current] {
+			next = append(next, key)
+		}
+		sort.Strings(next)
+		for _, key := range next {
+			if hasKey(prev, key) {
+				continue
+			}
+			prev[key] = current
+			queue = append(queue, key)
+		}
+	}
+	if !hasKey(prev, toKey) {
+		return nil, nil
 	}
 
-	return false
+	var keys []string
+	for key := toKey; key != ""; key = prev[key] {
+		keys = append(keys, key)
+	}
+	path := make([]GVK, len(keys))
+	converters := make([]Converter, len(keys)-1)
+	for i := range keys {
+		key := keys[len(keys)-1-i]
+		path[i] = r.gvks[key]
+		if i > 0 {
+			converters[i-1] = r.conversions[keys[len(keys)-i]][key]
+		}
+	}
+	return path, converters
+}
+
+func hasKey(m map[string]string, key string) bool {
+	_, ok := m[key]
+	return ok
 }
 
 // ListConversions returns all available conversion paths for a given GVK.
@@ -113,17 +216,8 @@ func (r *ConversionRegistry) ListConversions(from GVK) []GVK {
 	fromKey := from.String()
 	var targets []GVK
 
-	if fromConverters, exists := r.conversions[fromKey]; exists {
-		for toKey := range fromConverters {
-			// Parse the target GVK from the key
-			// This is a simplified parser - in practice you'd store GVKs directly
-			parts := strings.Split(toKey, ", Kind=")
-			if len(parts) == 2 {
-				apiVersion := parts[0]
-				kind := parts[1]
-				targets = append(targets, ParseAPIVersion(apiVersion, kind))
-			}
-		}
+	for toKey := range r.conversions[fromKey] {
+		targets = append(targets, r.gvks[toKey])
 	}
 
 	return targets
@@ -1,6 +1,7 @@
 package gvk
 
 import (
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -309,3 +310,92 @@ func TestConversionRegistryConcurrentAccess(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestConversionRegistryChainedConversion(t *testing.T) {
+	registry := NewConversionRegistry()
+
+	v1alpha1 := GVK{Group: "test", Version: "v1alpha1", Kind: "Foo"}
+	v1alpha2 := GVK{Group: "test", Version: "v1alpha2", Kind: "Foo"}
+	v1beta1 := GVK{Group: "test", Version: "v1beta1", Kind: "Foo"}
+
+	registry.RegisterFunc(v1alpha1, v1alpha2, func(from any) (any, error) {
+		return from.(string) + "+a2", nil
+	})
+	registry.RegisterFunc(v1alpha2, v1beta1, func(from any) (any, error) {
+		return from.(string) + "+b1", nil
+	})
+
+	t.Run("find path", func(t *testing.T) {
+		path, err := registry.FindPath(v1alpha1, v1beta1)
+		if err != nil {
+			t.Fatalf("FindPath() error = %v", err)
+		}
+		want := []GVK{v1alpha1, v1alpha2, v1beta1}
+		if len(path) != len(want) {
+			t.Fatalf("FindPath() = %v, want %v", path, want)
+		}
+		for i := range want {
+			if path[i] != want[i] {
+				t.Errorf("FindPath()[%d] = %v, want %v", i, path[i], want[i])
+			}
+		}
+	})
+
+	t.Run("convert through chain", func(t *testing.T) {
+		result, err := registry.Convert(v1alpha1, v1beta1, "cfg")
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if result != "cfg+a2+b1" {
+			t.Errorf("Convert() = %v, want %v", result, "cfg+a2+b1")
+		}
+		if !registry.HasConversion(v1alpha1, v1beta1) {
+			t.Error("HasConversion() should return true for chained conversion")
+		}
+	})
+
+	t.Run("no reverse path", func(t *testing.T) {
+		if _, err := registry.FindPath(v1beta1, v1alpha1); err == nil {
+			t.Error("expected error for missing reverse path")
+		}
+	})
+
+	t.Run("convert to latest", func(t *testing.T) {
+		target, result, err := registry.ConvertToLatest(v1alpha1, "cfg")
+		if err != nil {
+			t.Fatalf("ConvertToLatest() error = %v", err)
+		}
+		if target != v1beta1 {
+			t.Errorf("ConvertToLatest() target = %v, want %v", target, v1beta1)
+		}
+		if result != "cfg+a2+b1" {
+			t.Errorf("ConvertToLatest() = %v, want %v", result, "cfg+a2+b1")
+		}
+	})
+
+	t.Run("already latest", func(t *testing.T) {
+		target, result, err := registry.ConvertToLatest(v1beta1, "cfg")
+		if err != nil {
+			t.Fatalf("ConvertToLatest() error = %v", err)
+		}
+		if target != v1beta1 || result != "cfg" {
+			t.Errorf("ConvertToLatest() = %v, %v; want unchanged", target, result)
+		}
+	})
+}
+
+func TestConversionRegistryConverterError(t *testing.T) {
+	registry := NewConversionRegistry()
+
+	v1 := GVK{Group: "test", Version: "v1", Kind: "Foo"}
+	v2 := GVK{Group: "test", Version: "v2", Kind: "Foo"}
+	v3 := GVK{Group: "test", Version: "v3", Kind: "Foo"}
+	boom := errors.New("boom")
+
+	registry.RegisterFunc(v1, v2, func(from any) (any, error) { return nil, boom })
+	registry.RegisterFunc(v2, v3, func(from any) (any, error) { return from, nil })
+
+	if _, err := registry.Convert(v1, v3, "cfg"); !errors.Is(err, boom) {
+		t.Errorf("Convert() error = %v, want wrapped %v", err, boom)
+	}
+}
@@ -36,6 +36,7 @@
 // - Automatic YAML unmarshaling with type detection
 // - Version comparison and migration support
 // - Extensible conversion system for version upgrades
+// - Multi-step conversion paths resolved automatically (FindPath, ConvertToLatest)
 // - Thread-safe registry operations
 //
 // # Usage Patterns
@@ -45,11 +45,19 @@
 //	    }
 //	}
 //
+// ParseStream decodes documents incrementally from an io.Reader and passes
+// each object to a callback instead of returning a slice, keeping memory use
+// flat for very large manifests. ParseFile streams from disk the same way.
+//
+//	err := io.ParseStream(f, func(obj runtime.Object) error {
+//	    return process(obj)
+//	})
+//
 // # Unstructured fallback
 //
 // By default the parser rejects objects whose GroupVersionKind is not
-// registered in the kure scheme. [ParseYAMLWithOptions] and
-// [ParseFileWithOptions] accept a [ParseOptions] value. When
+// registered in the kure scheme. [ParseYAMLWithOptions],
+// [ParseFileWithOptions] and [ParseStreamWithOptions] accept a [ParseOptions] value. When
 // AllowUnstructured is true, unknown GVKs are decoded as
 // *unstructured.Unstructured instead of returning an error, making it
 // possible to process arbitrary Kubernetes YAML including CRDs that are
@@ -63,11 +71,38 @@
 //	    }
 //	}
 //
+// RegisterScheme adds further API types to the shared scheme so downstream
+// tools can parse their own custom resources as typed objects:
+//
+//	if err := io.RegisterScheme(istiov1.AddToScheme); err != nil {
+//	    return err
+//	}
+//
+// ParseOptions.Strict reports unknown and duplicate fields on typed objects.
+// The objects are still returned and the findings are added to the
+// ParseOptions.Warnings collector or, when none is set, to the Warnings of
+// the returned ParseErrors.
+//
+// # Round-trip editing
+//
+// ParseDocuments keeps every document of a file as a yaml.v3 node tree
+// together with its original text. EncodeDocuments writes unmodified
+// documents back unchanged, and Document.Update merges an edited object into
+// the tree while keeping key order, comments and anchors, so files that kure
+// reads and rewrites only show the lines that actually changed.
+//
+// # Hashing
+//
+// HashObject and HashObjects return stable SHA-256 digests of the canonical
+// encoding of objects. ApplyHashSuffixes uses them to give ConfigMaps and
+// Secrets content-addressed names and rewrites the references to them, so
+// configuration changes roll out as new immutable objects.
+//
 // # Resource printing
 //
 // The io package includes comprehensive resource printing capabilities compatible
 // with kubectl output formats. The ResourcePrinter provides unified formatting
-// for YAML, JSON, table, wide, and name output modes:
+// for YAML, JSON, table, wide, name, and list output modes:
 //
 //	printer := io.NewResourcePrinter(io.PrintOptions{
 //		OutputFormat: io.OutputFormatTable,
@@ -79,6 +114,20 @@
 // Table output includes resource-specific column formatting for different
 // Kubernetes kinds (Pod, Deployment, Service, ConfigMap) with appropriate
 // status indicators, age formatting, and wide-mode additional details.
+// KindSpecificColumns also covers the Flux HelmRelease, Kustomization and
+// GitRepository kinds and cert-manager Certificates, whose READY column
+// shows the Ready condition.
+//
+// PrintOptions.SortBy orders resources by kind, namespace, name, or priority
+// before any format is rendered. Priority sorting defaults to the kind install
+// order reported by KindPriority; set PrintOptions.SortPriority to supply a
+// custom weight. The list format wraps all resources in a single v1 List.
+//
+// OutputFormatCustomColumns and OutputFormatJSONPath mirror kubectl's
+// custom-columns and jsonpath output. Columns come from
+// PrintOptions.CustomColumns, which ParseCustomColumns builds from a
+// "HEADER:.field.path" specification, and the template from
+// PrintOptions.JSONPathTemplate.
 //
 // For simple table printing, use the SimpleTablePrinter which provides
 // kubectl-style table output without external dependencies:
@@ -100,6 +149,16 @@
 // apiVersion, kind, metadata, spec, data, stringData, then remaining fields
 // alphabetically, with status last.
 //
+// Indent, NoLineWrap and QuoteStyle adjust the output style: indentation
+// width, folding of long strings, and whether ambiguous strings are
+// single- or double-quoted (or every string is double-quoted with
+// [QuoteDouble]).
+//
+// SortBy orders the objects before encoding using [SortObjects].
+// LeadingSeparator and SeparatorBlankLines control the "---" lines between
+// documents, and StripEmptyFields removes nulls and empty status maps below
+// the well-known metadata locations as well.
+//
 // # Server-set field stripping
 //
 // Resources exported from a cluster via `kubectl get -o yaml` include
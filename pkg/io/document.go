@@ -0,0 +1,323 @@
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// Document is one document of a multi-document YAML file, kept as a yaml.v3
+// node tree together with its original text. Documents that are never
+// changed through Update are written back byte for byte by EncodeDocuments,
+// and changed documents keep the key order, comments and anchors of the
+// parts that were not modified.
+type Document struct {
+	separator string
+	raw       string
+	node      *yaml.Node
+	indent    int
+	modified  bool
+}
+
+// ParseDocuments splits data into its YAML documents and parses each one
+// into a node tree. Documents are separated by lines starting with "---".
+func ParseDocuments(data []byte) ([]*Document, error) {
+	var docs []*Document
+	current := &Document{}
+	var body strings.Builder
+	flush := func() error {
+		current.raw = body.String()
+		body.Reset()
+		if err := current.parse(); err != nil {
+			return errors.NewParseError("YAML document", "failed to parse document", 0, 0, err)
+		}
+		docs = append(docs, current)
+		return nil
+	}
+
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+		if isDocumentSeparator(line) {
+			if body.Len() > 0 || current.separator != "" {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				current = &Document{}
+			}
+			current.separator = line
+			continue
+		}
+		body.WriteString(line)
+	}
+	if body.Len() > 0 || current.separator != "" {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// EncodeDocuments writes docs back to YAML. Unmodified documents are
+// emitted exactly as they were parsed; modified documents are re-encoded
+// from their node tree using the indentation detected in the original text.
+func EncodeDocuments(docs []*Document) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, doc := range docs {
+		if doc == nil {
+			return nil, errors.Errorf("EncodeDocuments: document %d is nil", i)
+		}
+		separator := doc.separator
+		if separator == "" && i > 0 {
+			separator = "---\n"
+		}
+		if i > 0 && buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(separator)
+		if !doc.modified {
+			buf.WriteString(doc.raw)
+			continue
+		}
+		out, err := doc.encode()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
+
+// Modified reports whether Update changed the document.
+func (d *Document) Modified() bool {
+	return d.modified
+}
+
+// Node returns the root content node of the document, or nil when the
+// document holds only comments. Changes made directly to the node are only
+// written by EncodeDocuments after calling MarkModified.
+func (d *Document) Node() *yaml.Node {
+	if d.node == nil || len(d.node.Content) == 0 {
+		return nil
+	}
+	return d.node.Content[0]
+}
+
+// MarkModified forces the document to be re-encoded from its node tree.
+func (d *Document) MarkModified() {
+	d.modified = true
+}
+
+// Object decodes the document into a Kubernetes object using the kure
+// scheme. It returns nil without an error for documents that hold only
+// comments.
+func (d *Document) Object(opts ParseOptions) (client.Object, error) {
+	root := d.Node()
+	if root == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "encode document")
+	}
+	objs, err := parse(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) != 1 {
+		return nil, errors.Errorf("document decodes to %d objects, expected 1", len(objs))
+	}
+	return objs[0], nil
+}
+
+// Update merges obj into the document. Values that are equal to the
+// current ones are left untouched together with their comments, style and
+// anchors; changed values are replaced in place, fields missing from obj are
+// removed and new fields are appended. Empty values that are not present in
+// the document, such as the "strategy: {}" emitted for typed objects, are
+// not added. Server-managed fields are stripped from obj first, as for
+// EncodeObjectsToYAML.
+func (d *Document) Update(obj client.Object) error {
+	if obj == nil {
+		return errors.ErrNilObject
+	}
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrapf(err, "marshal object")
+	}
+	var m map[string]any
+	if err := json.Unmarshal(jsonBytes, &m); err != nil {
+		return errors.Wrapf(err, "unmarshal object")
+	}
+	cleanResourceMap(m, StripServerFieldsFull)
+
+	root := d.Node()
+	if root == nil {
+		d.node = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{mapToNode(m, true)}}
+		d.modified = true
+		return nil
+	}
+	if mergeNode(root, m) {
+		d.modified = true
+	}
+	return nil
+}
+
+func (d *Document) parse() error {
+	d.indent = detectIndent(d.raw)
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(d.raw), &node); err != nil {
+		return err
+	}
+	if node.Kind == yaml.DocumentNode {
+		d.node = &node
+	}
+	return nil
+}
+
+func (d *Document) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(d.indent)
+	if err := enc.Encode(d.node); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close YAML encoder: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// isDocumentSeparator reports whether line is a "---" document marker.
+func isDocumentSeparator(line string) bool {
+	if !strings.HasPrefix(line, "---") {
+		return false
+	}
+	rest := strings.TrimRight(line[3:], "\r\n")
+	return rest == "" || rest[0] == ' ' || rest[0] == '\t'
+}
+
+// detectIndent returns the indentation of the first nested mapping found in
+// raw, falling back to 2 when none is found or the width is unsupported.
+func detectIndent(raw string) int {
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n >= 2 && n <= 9 {
+			return n
+		}
+		break
+	}
+	return 2
+}
+
+// mergeNode updates n so that it represents v, keeping nodes whose value
+// already matches. Empty values for keys missing from a mapping are not
+// added. It reports whether the node tree changed.
+func mergeNode(n *yaml.Node, v any) bool {
+	if nodeEquals(n, v) {
+		return false
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		if n.Kind != yaml.MappingNode {
+			break
+		}
+		changed := false
+		content := make([]*yaml.Node, 0, len(n.Content))
+		seen := make(map[string]bool, len(val))
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			newVal, ok := val[key]
+			if !ok {
+				changed = true
+				continue
+			}
+			seen[key] = true
+			if mergeNode(n.Content[i+1], newVal) {
+				changed = true
+			}
+			content = append(content, n.Content[i], n.Content[i+1])
+		}
+		for _, key := range sortedKeys(val, false) {
+			if seen[key] || isEmptyValue(val[key]) {
+				continue
+			}
+			changed = true
+			content = append(content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key, Tag: "!!str"},
+				valueToNode(val[key]))
+		}
+		n.Content = content
+		return changed
+	case []any:
+		if n.Kind != yaml.SequenceNode {
+			break
+		}
+		changed := len(n.Content) != len(val)
+		content := make([]*yaml.Node, 0, len(val))
+		for i, item := range val {
+			if i >= len(n.Content) {
+				content = append(content, valueToNode(item))
+				continue
+			}
+			if mergeNode(n.Content[i], item) {
+				changed = true
+			}
+			content = append(content, n.Content[i])
+		}
+		n.Content = content
+		return changed
+	}
+
+	replacement := valueToNode(v)
+	replacement.Anchor = n.Anchor
+	replacement.HeadComment = n.HeadComment
+	replacement.LineComment = n.LineComment
+	replacement.FootComment = n.FootComment
+	if n.Kind == yaml.ScalarNode && replacement.Kind == yaml.ScalarNode && replacement.Tag == n.Tag {
+		replacement.Style = n.Style
+	}
+	*n = *replacement
+	return true
+}
+
+// nodeEquals reports whether n decodes to the same JSON value as v.
+func nodeEquals(n *yaml.Node, v any) bool {
+	var decoded any
+	if err := n.Decode(&decoded); err != nil {
+		return false
+	}
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return false
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalized, v)
+}
+
+// isEmptyValue reports whether v is nil, an empty list or a deep-empty map.
+func isEmptyValue(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(val) == 0
+	case map[string]any:
+		return isDeepEmpty(val)
+	}
+	return false
+}
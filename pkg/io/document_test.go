@@ -0,0 +1,164 @@
+package io
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const documentInput = `# cluster configuration
+apiVersion: v1
+kind: ConfigMap
+metadata:
+    name: settings # keep me
+data:
+    zeta: "1"
+    alpha: &shared value
+    beta: *shared
+--- # second
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: web
+spec:
+    replicas: 2 # scaled by hand
+    selector:
+        matchLabels:
+            app: web
+    template:
+        metadata:
+            labels:
+                app: web
+        spec:
+            containers:
+                - name: web
+                  image: nginx:1.25
+`
+
+func TestDocumentsRoundTripUnmodified(t *testing.T) {
+	docs, err := ParseDocuments([]byte(documentInput))
+	if err != nil {
+		t.Fatalf("ParseDocuments: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+	out, err := EncodeDocuments(docs)
+	if err != nil {
+		t.Fatalf("EncodeDocuments: %v", err)
+	}
+	if string(out) != documentInput {
+		t.Errorf("expected byte-identical output, got:\n%s", out)
+	}
+}
+
+func TestDocumentUpdate(t *testing.T) {
+	docs, err := ParseDocuments([]byte(documentInput))
+	if err != nil {
+		t.Fatalf("ParseDocuments: %v", err)
+	}
+
+	obj, err := docs[1].Object(ParseOptions{})
+	if err != nil {
+		t.Fatalf("Object: %v", err)
+	}
+	dep, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		t.Fatalf("expected Deployment, got %T", obj)
+	}
+
+	// Updating with the unchanged typed object must not touch the document,
+	// even though it carries defaults such as strategy: {}.
+	if err := docs[1].Update(dep); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if docs[1].Modified() {
+		t.Fatal("expected unchanged object to leave the document unmodified")
+	}
+
+	dep.Spec.Template.Spec.Containers[0].Image = "nginx:1.27"
+	if err := docs[1].Update(dep); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !docs[1].Modified() {
+		t.Fatal("expected document to be modified")
+	}
+
+	out, err := EncodeDocuments(docs)
+	if err != nil {
+		t.Fatalf("EncodeDocuments: %v", err)
+	}
+	s := string(out)
+	if !strings.HasPrefix(s, documentInput[:strings.Index(documentInput, "--- # second")]) {
+		t.Errorf("expected first document to be untouched, got:\n%s", s)
+	}
+	for _, want := range []string{"--- # second\n", "replicas: 2 # scaled by hand", "image: nginx:1.27", "    name: web\n"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, s)
+		}
+	}
+	if strings.Contains(s, "strategy") || strings.Contains(s, "resources") {
+		t.Errorf("expected typed defaults not to be added, got:\n%s", s)
+	}
+	if strings.Index(s, "kind: Deployment") > strings.Index(s, "metadata:\n    name: web") {
+		t.Errorf("expected key order to be preserved, got:\n%s", s)
+	}
+}
+
+func TestDocumentUpdatePreservesAnchors(t *testing.T) {
+	docs, err := ParseDocuments([]byte(documentInput))
+	if err != nil {
+		t.Fatalf("ParseDocuments: %v", err)
+	}
+	obj, err := docs[0].Object(ParseOptions{})
+	if err != nil {
+		t.Fatalf("Object: %v", err)
+	}
+	cm := obj.(*corev1.ConfigMap)
+	cm.Data["zeta"] = "2"
+	if err := docs[0].Update(cm); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	out, err := EncodeDocuments(docs[:1])
+	if err != nil {
+		t.Fatalf("EncodeDocuments: %v", err)
+	}
+	s := string(out)
+	for _, want := range []string{"# cluster configuration", "name: settings # keep me", `zeta: "2"`, "alpha: &shared value", "beta: *shared"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, s)
+		}
+	}
+	if strings.Index(s, "zeta") > strings.Index(s, "alpha") {
+		t.Errorf("expected original key order, got:\n%s", s)
+	}
+}
+
+func TestParseDocumentsEdgeCases(t *testing.T) {
+	docs, err := ParseDocuments([]byte("---\n# only a comment\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: ns"))
+	if err != nil {
+		t.Fatalf("ParseDocuments: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+	if obj, err := docs[0].Object(ParseOptions{}); obj != nil || err != nil {
+		t.Errorf("expected comment-only document to decode to nil, got %v, %v", obj, err)
+	}
+	out, err := EncodeDocuments(docs)
+	if err != nil {
+		t.Fatalf("EncodeDocuments: %v", err)
+	}
+	if string(out) != "---\n# only a comment\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: ns" {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if _, err := ParseDocuments([]byte("a: [b\n")); err == nil {
+		t.Error("expected error for malformed YAML")
+	}
+	if err := docs[1].Update(nil); err == nil {
+		t.Error("expected error for nil object")
+	}
+}
@@ -0,0 +1,214 @@
+package io
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// hashSuffixLength is the number of hex characters appended by
+// ApplyHashSuffixes.
+const hashSuffixLength = 10
+
+// HashObject returns the hex-encoded SHA-256 of the canonical encoding of
+// obj: its JSON form with object keys sorted, server-managed fields stripped
+// as in EncodeObjectsToYAML and null values removed. Typed and unstructured
+// representations of the same object hash identically.
+func HashObject(obj client.Object) (string, error) {
+	if obj == nil {
+		return "", errors.ErrNilObject
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", errors.Wrapf(err, "marshal %s", obj.GetName())
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", errors.Wrapf(err, "unmarshal %s", obj.GetName())
+	}
+	cleanResourceMap(m, StripServerFieldsFull)
+	stripEmptyFields(m)
+
+	// encoding/json writes map keys in sorted order, which makes the
+	// encoding canonical.
+	canonical, err := json.Marshal(m)
+	if err != nil {
+		return "", errors.Wrapf(err, "encode %s", obj.GetName())
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashObjects returns a SHA-256 over the hashes of all objects. The result
+// does not depend on the order of objects.
+func HashObjects(objects []*client.Object) (string, error) {
+	hashes := make([]string, 0, len(objects))
+	for i, obj := range objects {
+		if obj == nil || *obj == nil {
+			return "", errors.Errorf("HashObjects: object %d is nil", i)
+		}
+		h, err := HashObject(*obj)
+		if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, h)
+	}
+	slices.Sort(hashes)
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "\n")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ApplyHashSuffixes appends "-<hash>" to the name of every ConfigMap and
+// Secret in objects, where hash is the first ten characters of its
+// HashObject, and rewrites references to them in the other objects of the
+// same namespace. A change to the data therefore yields a new name and
+// rolls out the workloads that use it, which allows the objects to be
+// marked immutable.
+//
+// References are rewritten wherever they appear: volumes and projected
+// sources, envFrom, env valueFrom, imagePullSecrets, Ingress TLS secrets,
+// any "secretRef" such as those of Flux sources, and the HelmRelease
+// valuesFrom and Kustomization postBuild.substituteFrom lists.
+// ApplyHashSuffixes is not idempotent; call it once on the final set of
+// objects.
+func ApplyHashSuffixes(objects []*client.Object) error {
+	renames := make(map[string]string)
+	for i, ref := range objects {
+		if ref == nil || *ref == nil {
+			return errors.Errorf("ApplyHashSuffixes: object %d is nil", i)
+		}
+		obj := *ref
+		kind := hashedKind(obj)
+		if kind == "" {
+			continue
+		}
+		h, err := HashObject(obj)
+		if err != nil {
+			return err
+		}
+		name := obj.GetName()
+		hashed := name + "-" + h[:hashSuffixLength]
+		renames[referenceKey(kind, obj.GetNamespace(), name)] = hashed
+		obj.SetName(hashed)
+	}
+	if len(renames) == 0 {
+		return nil
+	}
+
+	for _, ref := range objects {
+		obj := *ref
+		if hashedKind(obj) != "" {
+			continue
+		}
+		content, err := objectContent(obj)
+		if err != nil {
+			return err
+		}
+		if !rewriteReferences(content, obj.GetNamespace(), renames) {
+			continue
+		}
+		if _, ok := obj.(runtime.Unstructured); ok {
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj); err != nil {
+			return errors.Wrapf(err, "update references in %s", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// hashedKind returns "ConfigMap" or "Secret" for objects that receive a
+// hash suffix and "" otherwise.
+func hashedKind(obj client.Object) string {
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		return "ConfigMap"
+	case *corev1.Secret:
+		return "Secret"
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Group == "" && (gvk.Kind == "ConfigMap" || gvk.Kind == "Secret") {
+		return gvk.Kind
+	}
+	return ""
+}
+
+func referenceKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// referenceFields maps the keys under which ConfigMaps and Secrets are
+// referenced to the kind they reference and the fields that may hold the
+// name: a "secret" volume uses secretName, a projected secret source uses
+// name. An empty kind means each reference names its own kind, as in the
+// Flux valuesFrom and substituteFrom lists.
+var referenceFields = map[string]struct {
+	kind       string
+	nameFields []string
+}{
+	"configMap":        {"ConfigMap", []string{"name"}},
+	"configMapRef":     {"ConfigMap", []string{"name"}},
+	"configMapKeyRef":  {"ConfigMap", []string{"name"}},
+	"secret":           {"Secret", []string{"secretName", "name"}},
+	"secretRef":        {"Secret", []string{"name"}},
+	"secretKeyRef":     {"Secret", []string{"name"}},
+	"imagePullSecrets": {"Secret", []string{"name"}},
+	"tls":              {"Secret", []string{"secretName"}},
+	"valuesFrom":       {"", []string{"name"}},
+	"substituteFrom":   {"", []string{"name"}},
+}
+
+// rewriteReferences renames the ConfigMap and Secret references found
+// anywhere in node and reports whether any were changed.
+func rewriteReferences(node any, namespace string, renames map[string]string) bool {
+	changed := false
+	switch val := node.(type) {
+	case map[string]any:
+		for key, child := range val {
+			if field, ok := referenceFields[key]; ok {
+				targets := []any{child}
+				if list, ok := child.([]any); ok {
+					targets = list
+				}
+				for _, target := range targets {
+					ref, ok := target.(map[string]any)
+					if !ok {
+						continue
+					}
+					kind := field.kind
+					if kind == "" {
+						kind, _ = ref["kind"].(string)
+					}
+					for _, nameField := range field.nameFields {
+						name, ok := ref[nameField].(string)
+						if !ok {
+							continue
+						}
+						if hashed, ok := renames[referenceKey(kind, namespace, name)]; ok {
+							ref[nameField] = hashed
+							changed = true
+						}
+					}
+				}
+			}
+			if rewriteReferences(child, namespace, renames) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if rewriteReferences(item, namespace, renames) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
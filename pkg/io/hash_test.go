@@ -0,0 +1,234 @@
+package io
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func hashTestConfigMap(name, value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+		Data:       map[string]string{"key": value},
+	}
+}
+
+func TestHashObject(t *testing.T) {
+	cm := hashTestConfigMap("settings", "a")
+	h1, err := HashObject(cm)
+	if err != nil {
+		t.Fatalf("HashObject: %v", err)
+	}
+	if len(h1) != 64 {
+		t.Fatalf("expected a hex SHA-256, got %q", h1)
+	}
+
+	// Server-set fields do not change the hash.
+	withServerFields := cm.DeepCopy()
+	withServerFields.ResourceVersion = "42"
+	withServerFields.UID = "1234"
+	if h, _ := HashObject(withServerFields); h != h1 {
+		t.Error("expected server-set fields to be ignored")
+	}
+
+	// The unstructured form hashes identically.
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "settings", "namespace": "apps"},
+		"data":       map[string]any{"key": "a"},
+	}}
+	if h, _ := HashObject(u); h != h1 {
+		t.Error("expected typed and unstructured objects to hash identically")
+	}
+
+	if h, _ := HashObject(hashTestConfigMap("settings", "b")); h == h1 {
+		t.Error("expected different data to change the hash")
+	}
+	if _, err := HashObject(nil); err == nil {
+		t.Error("expected error for nil object")
+	}
+}
+
+func TestHashObjects(t *testing.T) {
+	var a client.Object = hashTestConfigMap("a", "1")
+	var b client.Object = hashTestConfigMap("b", "2")
+
+	h1, err := HashObjects([]*client.Object{&a, &b})
+	if err != nil {
+		t.Fatalf("HashObjects: %v", err)
+	}
+	h2, err := HashObjects([]*client.Object{&b, &a})
+	if err != nil {
+		t.Fatalf("HashObjects: %v", err)
+	}
+	if h1 != h2 {
+		t.Error("expected the hash not to depend on order")
+	}
+	if _, err := HashObjects([]*client.Object{&a, nil}); err == nil {
+		t.Error("expected error for nil entry")
+	}
+}
+
+func TestApplyHashSuffixes(t *testing.T) {
+	cm := hashTestConfigMap("settings", "a")
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "apps"},
+		StringData: map[string]string{"token": "x"},
+	}
+	dep := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "web",
+				EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}},
+				Env: []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}, Key: "token"},
+				}}},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}},
+				{Name: "other", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "unrelated"}}}},
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "creds"}},
+		}}},
+	}
+	other := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "elsewhere", "namespace": "other"},
+		"spec": map[string]any{"volumes": []any{
+			map[string]any{"name": "config", "configMap": map[string]any{"name": "settings"}},
+		}},
+	}}
+
+	objs := []client.Object{cm, secret, dep, other}
+	refs := make([]*client.Object, len(objs))
+	for i := range objs {
+		refs[i] = &objs[i]
+	}
+	if err := ApplyHashSuffixes(refs); err != nil {
+		t.Fatalf("ApplyHashSuffixes: %v", err)
+	}
+
+	if !strings.HasPrefix(cm.Name, "settings-") || len(cm.Name) != len("settings-")+10 {
+		t.Fatalf("unexpected ConfigMap name %q", cm.Name)
+	}
+	if !strings.HasPrefix(secret.Name, "creds-") {
+		t.Fatalf("unexpected Secret name %q", secret.Name)
+	}
+	pod := dep.Spec.Template.Spec
+	if got := pod.Containers[0].EnvFrom[0].ConfigMapRef.Name; got != cm.Name {
+		t.Errorf("envFrom references %q, want %q", got, cm.Name)
+	}
+	if got := pod.Containers[0].Env[0].ValueFrom.SecretKeyRef.Name; got != secret.Name {
+		t.Errorf("secretKeyRef references %q, want %q", got, secret.Name)
+	}
+	if got := pod.Volumes[0].ConfigMap.Name; got != cm.Name {
+		t.Errorf("volume references %q, want %q", got, cm.Name)
+	}
+	if got := pod.Volumes[1].ConfigMap.Name; got != "unrelated" {
+		t.Errorf("unrelated volume was renamed to %q", got)
+	}
+	if got := pod.ImagePullSecrets[0].Name; got != secret.Name {
+		t.Errorf("imagePullSecrets references %q, want %q", got, secret.Name)
+	}
+	volumes, _, _ := unstructured.NestedSlice(other.Object, "spec", "volumes")
+	if got := volumes[0].(map[string]any)["configMap"].(map[string]any)["name"]; got != "settings" {
+		t.Errorf("reference in another namespace was renamed to %v", got)
+	}
+}
+
+func TestApplyHashSuffixesReferenceShapes(t *testing.T) {
+	cm := hashTestConfigMap("values", "a")
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "apps"},
+		StringData: map[string]string{"token": "x"},
+	}
+	projected := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{Name: "all", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}},
+				}},
+			}}}},
+		}}},
+	}
+	ingress := &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "creds"}},
+		},
+	}
+	release := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata":   map[string]any{"name": "web", "namespace": "apps"},
+		"spec": map[string]any{"valuesFrom": []any{
+			map[string]any{"kind": "ConfigMap", "name": "values"},
+			map[string]any{"kind": "Secret", "name": "creds", "valuesKey": "token"},
+			map[string]any{"kind": "Secret", "name": "values"},
+		}},
+	}}
+	kustomization := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]any{"name": "apps", "namespace": "apps"},
+		"spec": map[string]any{"postBuild": map[string]any{"substituteFrom": []any{
+			map[string]any{"kind": "ConfigMap", "name": "values"},
+			map[string]any{"kind": "Secret", "name": "creds", "optional": true},
+		}}},
+	}}
+
+	objs := []client.Object{cm, secret, projected, ingress, release, kustomization}
+	refs := make([]*client.Object, len(objs))
+	for i := range objs {
+		refs[i] = &objs[i]
+	}
+	if err := ApplyHashSuffixes(refs); err != nil {
+		t.Fatalf("ApplyHashSuffixes: %v", err)
+	}
+
+	t.Run("projected secret", func(t *testing.T) {
+		src := projected.Spec.Template.Spec.Volumes[0].Projected.Sources[0].Secret
+		if src.Name != secret.Name {
+			t.Errorf("projected secret references %q, want %q", src.Name, secret.Name)
+		}
+	})
+	t.Run("ingress tls", func(t *testing.T) {
+		if got := ingress.Spec.TLS[0].SecretName; got != secret.Name {
+			t.Errorf("tls secretName references %q, want %q", got, secret.Name)
+		}
+	})
+	t.Run("helmrelease valuesFrom", func(t *testing.T) {
+		valuesFrom, _, _ := unstructured.NestedSlice(release.Object, "spec", "valuesFrom")
+		want := []string{cm.Name, secret.Name, "values"}
+		for i, v := range valuesFrom {
+			if got := v.(map[string]any)["name"]; got != want[i] {
+				t.Errorf("valuesFrom[%d] references %v, want %q", i, got, want[i])
+			}
+		}
+	})
+	t.Run("kustomization substituteFrom", func(t *testing.T) {
+		substituteFrom, _, _ := unstructured.NestedSlice(kustomization.Object, "spec", "postBuild", "substituteFrom")
+		want := []string{cm.Name, secret.Name}
+		for i, v := range substituteFrom {
+			if got := v.(map[string]any)["name"]; got != want[i] {
+				t.Errorf("substituteFrom[%d] references %v, want %q", i, got, want[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package io
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// CustomColumn is one column of the custom-columns output format.
+type CustomColumn struct {
+	// Header is printed in the header row.
+	Header string
+	// FieldSpec is a JSONPath expression such as ".metadata.name" or
+	// "{.spec.replicas}". The surrounding braces and the leading dot are
+	// optional, as with kubectl.
+	FieldSpec string
+}
+
+// ParseCustomColumns parses a kubectl custom-columns specification of the
+// form "HEADER:FIELD,HEADER:FIELD", for example
+// "NAME:.metadata.name,REPLICAS:.spec.replicas".
+func ParseCustomColumns(spec string) ([]CustomColumn, error) {
+	if spec == "" {
+		return nil, errors.New("custom-columns format specified but no custom columns given")
+	}
+	parts := strings.Split(spec, ",")
+	columns := make([]CustomColumn, 0, len(parts))
+	for _, part := range parts {
+		header, field, ok := strings.Cut(part, ":")
+		if !ok || header == "" {
+			return nil, errors.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", part)
+		}
+		columns = append(columns, CustomColumn{Header: header, FieldSpec: field})
+	}
+	return columns, nil
+}
+
+var relaxedJSONPath = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
+
+// relaxedJSONPathExpression turns "name1.name2", ".name1.name2",
+// "{name1.name2}" and "{.name1.name2}" into the braced form expected by the
+// JSONPath parser, matching kubectl's custom-columns handling.
+func relaxedJSONPathExpression(expr string) (string, error) {
+	if expr == "" {
+		return expr, nil
+	}
+	m := relaxedJSONPath.FindStringSubmatch(expr)
+	if m == nil {
+		return "", errors.Errorf("unexpected path string %q, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'", expr)
+	}
+	field := m[1]
+	if field == "" {
+		field = m[2]
+	}
+	return "{." + field + "}", nil
+}
+
+// printCustomColumns prints one row per resource with the values selected
+// by the configured columns. Missing values print as "<none>" and multiple
+// matches are joined with commas.
+func (rp *ResourcePrinter) printCustomColumns(resources []*client.Object, w io.Writer) error {
+	columns := rp.options.CustomColumns
+	if len(columns) == 0 {
+		return errors.New("custom-columns format specified but no custom columns given")
+	}
+	parsers := make([]*jsonpath.JSONPath, len(columns))
+	for i, col := range columns {
+		expr, err := relaxedJSONPathExpression(col.FieldSpec)
+		if err != nil {
+			return err
+		}
+		parser := jsonpath.New(col.Header).AllowMissingKeys(true)
+		if err := parser.Parse(expr); err != nil {
+			return errors.Wrapf(err, "parse custom column %s", col.Header)
+		}
+		parsers[i] = parser
+	}
+
+	tw := printers.GetNewTabWriter(w)
+	if !rp.options.NoHeaders {
+		headers := make([]string, len(columns))
+		for i, col := range columns {
+			headers[i] = col.Header
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	for _, obj := range resources {
+		if obj == nil || *obj == nil {
+			continue
+		}
+		content, err := objectContent(*obj)
+		if err != nil {
+			return err
+		}
+		row := make([]string, len(parsers))
+		for i, parser := range parsers {
+			results, err := parser.FindResults(content)
+			if err != nil {
+				return errors.Wrapf(err, "evaluate custom column %s", columns[i].Header)
+			}
+			var values []string
+			for _, set := range results {
+				for _, v := range set {
+					values = append(values, fmt.Sprintf("%v", v.Interface()))
+				}
+			}
+			row[i] = strings.Join(values, ",")
+			if len(values) == 0 {
+				row[i] = "<none>"
+			}
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printJSONPath executes the configured JSONPath template against a single
+// resource, or against a v1 List of all resources when there are several,
+// as kubectl does.
+func (rp *ResourcePrinter) printJSONPath(resources []*client.Object, w io.Writer) error {
+	if rp.options.JSONPathTemplate == "" {
+		return errors.New("jsonpath format specified but no template given")
+	}
+	printer, err := printers.NewJSONPathPrinter(rp.options.JSONPathTemplate)
+	if err != nil {
+		return errors.Wrapf(err, "parse jsonpath template")
+	}
+	printer.AllowMissingKeys(true)
+
+	items := make([]any, 0, len(resources))
+	for _, obj := range resources {
+		if obj == nil || *obj == nil {
+			continue
+		}
+		content, err := objectContent(*obj)
+		if err != nil {
+			return err
+		}
+		items = append(items, content)
+	}
+	if len(items) == 1 {
+		return printer.PrintObj(&unstructured.Unstructured{Object: items[0].(map[string]any)}, w)
+	}
+	return printer.PrintObj(&unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}}, w)
+}
+
+// objectContent returns the unstructured map form of obj, converting typed
+// objects through the default unstructured converter.
+func objectContent(obj client.Object) (map[string]any, error) {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "convert %T to unstructured", obj)
+	}
+	return content, nil
+}
@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
 )
 
 // ServerFieldStripping controls which server-managed metadata fields are
@@ -42,6 +47,92 @@ type EncodeOptions struct {
 	// fields during encoding. The zero value (StripServerFieldsFull)
 	// strips all known server-set fields by default.
 	ServerFieldStripping ServerFieldStripping
+
+	// Indent sets the number of spaces per nesting level. Valid values
+	// are 2 through 9; zero keeps the default of 2.
+	Indent int
+
+	// NoLineWrap keeps long string values on a single line. The default
+	// encoder folds plain strings longer than 80 columns; output produced
+	// with KubernetesFieldOrder or any other style option is never folded.
+	NoLineWrap bool
+
+	// QuoteStyle selects how string values are quoted. The zero value
+	// (QuoteMinimal) quotes only strings that would otherwise be read back
+	// as another type.
+	QuoteStyle QuoteStyle
+
+	// SortBy orders the objects before they are encoded, using the same
+	// stable ordering as [SortObjects]. The zero value (SortByNone) keeps
+	// the input order.
+	SortBy SortField
+
+	// SortPriority supplies the weight used by SortByPriority (lower values
+	// first). When nil, the kind install order from [KindPriority] is used.
+	SortPriority func(client.Object) int
+
+	// LeadingSeparator writes a "---" line before the first document as
+	// well as between documents, as helm template does.
+	LeadingSeparator bool
+
+	// SeparatorBlankLines is the number of blank lines written before each
+	// "---" that follows a document. Zero writes the separator directly
+	// after the previous document.
+	SeparatorBlankLines int
+
+	// StripEmptyFields removes null values and empty status maps at any
+	// depth, for example creationTimestamp: null and status: {} inside
+	// StatefulSet volumeClaimTemplates. Server-field stripping only cleans
+	// the well-known metadata locations. Other empty maps such as
+	// emptyDir: {} carry meaning and are kept.
+	StripEmptyFields bool
+}
+
+// QuoteStyle controls how string scalars are quoted in YAML output.
+type QuoteStyle int
+
+const (
+	// QuoteMinimal quotes only ambiguous strings (for example "true",
+	// "1.0", "null" or ""), using double quotes.
+	QuoteMinimal QuoteStyle = iota
+
+	// QuoteSingle quotes ambiguous strings with single quotes.
+	QuoteSingle
+
+	// QuoteDouble double-quotes every single-line string value. Map keys
+	// and multi-line strings (emitted as literal blocks) are unaffected.
+	QuoteDouble
+)
+
+// styled reports whether opts require the yaml.v3 node encoder rather
+// than the default sigs.k8s.io/yaml marshaller.
+func (opts EncodeOptions) styled() bool {
+	return opts.KubernetesFieldOrder || opts.Indent != 0 || opts.NoLineWrap || opts.QuoteStyle != QuoteMinimal
+}
+
+// validate checks that opts contain supported style values.
+func (opts EncodeOptions) validate() error {
+	if opts.Indent != 0 && (opts.Indent < 2 || opts.Indent > 9) {
+		return errors.NewValidationError("Indent", strconv.Itoa(opts.Indent), "EncodeOptions",
+			[]string{"2", "3", "4", "5", "6", "7", "8", "9"})
+	}
+	switch opts.QuoteStyle {
+	case QuoteMinimal, QuoteSingle, QuoteDouble:
+	default:
+		return errors.NewValidationError("QuoteStyle", strconv.Itoa(int(opts.QuoteStyle)), "EncodeOptions",
+			[]string{"QuoteMinimal", "QuoteSingle", "QuoteDouble"})
+	}
+	switch opts.SortBy {
+	case SortByNone, SortByKind, SortByNamespace, SortByName, SortByPriority:
+	default:
+		return errors.NewValidationError("SortBy", string(opts.SortBy), "EncodeOptions",
+			[]string{string(SortByKind), string(SortByNamespace), string(SortByName), string(SortByPriority)})
+	}
+	if opts.SeparatorBlankLines < 0 {
+		return errors.NewValidationError("SeparatorBlankLines", strconv.Itoa(opts.SeparatorBlankLines), "EncodeOptions",
+			[]string{">= 0"})
+	}
+	return nil
 }
 
 // kubernetesKeyPriority maps well-known top-level Kubernetes resource
@@ -65,15 +156,28 @@ const (
 // marshalOrderedYAML converts a cleaned resource map to YAML bytes with
 // top-level keys in Kubernetes-conventional order.
 func marshalOrderedYAML(m map[string]any) ([]byte, error) {
-	node := mapToNode(m, true)
+	return marshalStyledYAML(m, EncodeOptions{KubernetesFieldOrder: true})
+}
+
+// marshalStyledYAML converts a cleaned resource map to YAML bytes using the
+// yaml.v3 encoder, applying the key order, indentation and quoting selected
+// by opts. The yaml.v3 encoder never folds long strings.
+func marshalStyledYAML(m map[string]any, opts EncodeOptions) ([]byte, error) {
+	node := mapToNode(m, opts.KubernetesFieldOrder)
+	applyQuoteStyle(node, opts.QuoteStyle)
 	doc := &yaml.Node{
 		Kind:    yaml.DocumentNode,
 		Content: []*yaml.Node{node},
 	}
 
+	indent := opts.Indent
+	if indent == 0 {
+		indent = 2
+	}
+
 	var buf bytes.Buffer
 	enc := yaml.NewEncoder(&buf)
-	enc.SetIndent(2)
+	enc.SetIndent(indent)
 	if err := enc.Encode(doc); err != nil {
 		return nil, fmt.Errorf("failed to encode ordered YAML: %w", err)
 	}
@@ -151,6 +255,57 @@ func valueToNode(v any) *yaml.Node {
 	}
 }
 
+// applyQuoteStyle sets the scalar style of every string value beneath node
+// according to style. Mapping keys are left untouched.
+func applyQuoteStyle(node *yaml.Node, style QuoteStyle) {
+	if style == QuoteMinimal {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			applyQuoteStyle(node.Content[i], style)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			applyQuoteStyle(item, style)
+		}
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" || strings.Contains(node.Value, "\n") {
+			return
+		}
+		switch style {
+		case QuoteDouble:
+			node.Style = yaml.DoubleQuotedStyle
+		case QuoteSingle:
+			if isAmbiguousScalar(node.Value) {
+				node.Style = yaml.SingleQuotedStyle
+			}
+		}
+	}
+}
+
+// yaml11Scalar matches plain scalars that YAML 1.1 parsers read as
+// booleans or base-60 numbers although YAML 1.2, and thus yaml.v3, reads
+// them as strings.
+var yaml11Scalar = regexp.MustCompile(`^(?:y|Y|yes|Yes|YES|n|N|no|No|NO|on|On|ON|off|Off|OFF` +
+	`|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?)$`)
+
+// isAmbiguousScalar reports whether s, written as a plain YAML scalar,
+// would be read back as something other than the same string by a YAML 1.2
+// or YAML 1.1 parser.
+func isAmbiguousScalar(s string) bool {
+	if yaml11Scalar.MatchString(s) {
+		return true
+	}
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return true
+	}
+	str, ok := v.(string)
+	return !ok || str != s
+}
+
 // floatToNode converts a float64 to a yaml.v3 ScalarNode, rendering
 // integer-valued floats without a decimal point (e.g. 8080 not 8080.0).
 func floatToNode(f float64) *yaml.Node {
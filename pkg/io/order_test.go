@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	sigsyaml "sigs.k8s.io/yaml"
 )
 
@@ -333,3 +335,218 @@ func TestMarshalOrderedYAML_RoundTrip(t *testing.T) {
 		t.Errorf("container image lost: got %v", container["image"])
 	}
 }
+
+func TestMarshalStyledYAML_Indent(t *testing.T) {
+	m := map[string]any{
+		"metadata": map[string]any{"name": "test"},
+	}
+
+	out, err := marshalStyledYAML(m, EncodeOptions{Indent: 4})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(out), "metadata:\n    name: test\n") {
+		t.Errorf("expected 4-space indentation, got:\n%s", out)
+	}
+}
+
+func TestMarshalStyledYAML_QuoteStyle(t *testing.T) {
+	m := map[string]any{
+		"data": map[string]any{
+			"enabled": "true",
+			"legacy":  "no",
+			"name":    "web",
+			"script":  "line1\nline2\n",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		style   QuoteStyle
+		want    []string
+		notWant []string
+	}{
+		{
+			name:  "minimal",
+			style: QuoteMinimal,
+			want:  []string{`enabled: "true"`, "name: web", "script: |"},
+		},
+		{
+			name:    "single",
+			style:   QuoteSingle,
+			want:    []string{"enabled: 'true'", "legacy: 'no'", "name: web", "script: |"},
+			notWant: []string{`"true"`, `"no"`},
+		},
+		{
+			name:    "double",
+			style:   QuoteDouble,
+			want:    []string{`enabled: "true"`, `name: "web"`, "script: |", "data:"},
+			notWant: []string{`"data":`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := marshalStyledYAML(m, EncodeOptions{QuoteStyle: tt.style})
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(string(out), w) {
+					t.Errorf("expected %q in output:\n%s", w, out)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(string(out), nw) {
+					t.Errorf("did not expect %q in output:\n%s", nw, out)
+				}
+			}
+
+			var roundTripped map[string]any
+			if err := sigsyaml.Unmarshal(out, &roundTripped); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			data := roundTripped["data"].(map[string]any)
+			if data["enabled"] != "true" {
+				t.Errorf("expected string \"true\" to round-trip, got %#v", data["enabled"])
+			}
+		})
+	}
+}
+
+func TestIsAmbiguousScalar(t *testing.T) {
+	for _, s := range []string{"true", "no", "Yes", "on", "OFF", "y", "1:20", "190:20:30.15", "1", "1.5", "null", "~", "", "a: b", "- x", " padded"} {
+		if !isAmbiguousScalar(s) {
+			t.Errorf("expected %q to be ambiguous", s)
+		}
+	}
+	for _, s := range []string{"web", "nginx:latest", "hello world", "none", "12:75", "10:00am"} {
+		if isAmbiguousScalar(s) {
+			t.Errorf("expected %q to be unambiguous", s)
+		}
+	}
+}
+
+func TestEncodeOptions_NoLineWrap(t *testing.T) {
+	long := strings.TrimSpace(strings.Repeat("word ", 30))
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("long")
+	cm.Object["data"] = map[string]any{"text": long}
+	var obj client.Object = cm
+
+	wrapped, err := EncodeObjectsToYAMLWithOptions([]*client.Object{&obj}, EncodeOptions{})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if strings.Contains(string(wrapped), "text: "+long) {
+		t.Fatalf("expected default encoder to fold long strings, got:\n%s", wrapped)
+	}
+
+	unwrapped, err := EncodeObjectsToYAMLWithOptions([]*client.Object{&obj}, EncodeOptions{NoLineWrap: true})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if !strings.Contains(string(unwrapped), "text: "+long+"\n") {
+		t.Errorf("expected long string on a single line, got:\n%s", unwrapped)
+	}
+}
+
+func TestEncodeOptions_Invalid(t *testing.T) {
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("x")
+	var obj client.Object = cm
+
+	for _, opts := range []EncodeOptions{{Indent: 1}, {Indent: 10}, {QuoteStyle: QuoteStyle(42)}} {
+		if _, err := EncodeObjectsToYAMLWithOptions([]*client.Object{&obj}, opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestEncodeOptions_SortAndSeparators(t *testing.T) {
+	newObj := func(kind, name string) *client.Object {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind(kind)
+		u.SetName(name)
+		var obj client.Object = u
+		return &obj
+	}
+	objects := []*client.Object{newObj("Service", "web"), newObj("Namespace", "apps"), newObj("ConfigMap", "cfg")}
+
+	out, err := EncodeObjectsToYAMLWithOptions(objects, EncodeOptions{
+		SortBy:              SortByPriority,
+		LeadingSeparator:    true,
+		SeparatorBlankLines: 1,
+	})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	s := string(out)
+	if !strings.HasPrefix(s, "---\n") {
+		t.Errorf("expected leading separator, got:\n%s", s)
+	}
+	if strings.Count(s, "\n\n---\n") != 2 {
+		t.Errorf("expected a blank line before each separator, got:\n%s", s)
+	}
+	ns, cm, svc := strings.Index(s, "kind: Namespace"), strings.Index(s, "kind: ConfigMap"), strings.Index(s, "kind: Service")
+	if ns > cm || cm > svc {
+		t.Errorf("expected install order Namespace, ConfigMap, Service, got:\n%s", s)
+	}
+
+	if _, err := EncodeObjectsToYAMLWithOptions(objects, EncodeOptions{SortBy: "size"}); err == nil {
+		t.Error("expected error for unknown SortBy")
+	}
+	if _, err := EncodeObjectsToYAMLWithOptions(objects, EncodeOptions{SeparatorBlankLines: -1}); err == nil {
+		t.Error("expected error for negative SeparatorBlankLines")
+	}
+}
+
+func TestEncodeOptions_StripEmptyFields(t *testing.T) {
+	sts := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "StatefulSet",
+		"metadata":   map[string]any{"name": "db"},
+		"spec": map[string]any{
+			"volumeClaimTemplates": []any{
+				map[string]any{
+					"metadata": map[string]any{"name": "data", "creationTimestamp": nil},
+					"spec":     map[string]any{"storageClassName": nil},
+					"status":   map[string]any{},
+				},
+			},
+			"template": map[string]any{
+				"spec": map[string]any{
+					"volumes": []any{map[string]any{"name": "tmp", "emptyDir": map[string]any{}}},
+				},
+			},
+		},
+	}}
+	var obj client.Object = sts
+
+	out, err := EncodeObjectsToYAMLWithOptions([]*client.Object{&obj}, EncodeOptions{StripEmptyFields: true})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	s := string(out)
+	for _, unwanted := range []string{"creationTimestamp", "storageClassName", "status"} {
+		if strings.Contains(s, unwanted) {
+			t.Errorf("expected %s to be stripped, got:\n%s", unwanted, s)
+		}
+	}
+	if !strings.Contains(s, "emptyDir: {}") {
+		t.Errorf("expected emptyDir to be kept, got:\n%s", s)
+	}
+
+	kept, err := EncodeObjectsToYAMLWithOptions([]*client.Object{&obj}, EncodeOptions{})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if !strings.Contains(string(kept), "creationTimestamp: null") {
+		t.Errorf("expected nested creationTimestamp without StripEmptyFields, got:\n%s", kept)
+	}
+}
@@ -1,6 +1,7 @@
 package io
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -24,8 +25,20 @@ const (
 	OutputFormatTable OutputFormat = "table"
 	OutputFormatWide  OutputFormat = "wide"
 	OutputFormatName  OutputFormat = "name"
+	// OutputFormatList emits a single JSON v1 List object whose items are
+	// the printed resources, for tooling that expects one JSON document.
+	OutputFormatList OutputFormat = "list"
+	// OutputFormatCustomColumns prints a table whose columns are selected
+	// by PrintOptions.CustomColumns, like kubectl -o custom-columns.
+	OutputFormatCustomColumns OutputFormat = "custom-columns"
+	// OutputFormatJSONPath executes PrintOptions.JSONPathTemplate, like
+	// kubectl -o jsonpath.
+	OutputFormatJSONPath OutputFormat = "jsonpath"
 )
 
+// outputFormatNames lists the accepted output format names.
+var outputFormatNames = []string{"yaml", "json", "table", "wide", "name", "list", "custom-columns", "jsonpath"}
+
 // PrintOptions contains configuration for resource printing
 type PrintOptions struct {
 	// OutputFormat specifies the desired output format
@@ -36,8 +49,20 @@ type PrintOptions struct {
 	ShowLabels bool
 	// ColumnLabels is a list of label keys to display as columns
 	ColumnLabels []string
-	// SortBy specifies the column to sort by (for table output)
+	// SortBy orders resources before printing and applies to every output
+	// format. It holds one of the SortField values ("kind", "namespace",
+	// "name" or "priority"); an empty value preserves the input order and
+	// any other value makes Print fail.
 	SortBy string
+	// SortPriority supplies the weight used by SortByPriority (lower values
+	// print first). When nil, resources are weighted by [KindPriority].
+	SortPriority func(client.Object) int
+	// CustomColumns selects the columns of OutputFormatCustomColumns. Use
+	// ParseCustomColumns to build them from a kubectl-style specification.
+	CustomColumns []CustomColumn
+	// JSONPathTemplate is the template executed by OutputFormatJSONPath,
+	// for example "{.metadata.name}". Missing keys print nothing.
+	JSONPathTemplate string
 }
 
 // ResourcePrinter provides a unified interface for printing Kubernetes resources
@@ -59,6 +84,11 @@ func (rp *ResourcePrinter) Print(resources []*client.Object, w io.Writer) error
 		return nil
 	}
 
+	resources, err := SortObjects(resources, SortField(rp.options.SortBy), rp.options.SortPriority)
+	if err != nil {
+		return err
+	}
+
 	switch rp.options.OutputFormat {
 	case OutputFormatYAML:
 		return rp.printYAML(resources, w)
@@ -70,8 +100,14 @@ func (rp *ResourcePrinter) Print(resources []*client.Object, w io.Writer) error
 		return rp.printTable(resources, w, true)
 	case OutputFormatName:
 		return rp.printNames(resources, w)
+	case OutputFormatList:
+		return rp.printList(resources, w)
+	case OutputFormatCustomColumns:
+		return rp.printCustomColumns(resources, w)
+	case OutputFormatJSONPath:
+		return rp.printJSONPath(resources, w)
 	default:
-		return errors.NewValidationError("OutputFormat", string(rp.options.OutputFormat), "ResourcePrinter", []string{"yaml", "json", "table", "wide", "name"})
+		return errors.NewValidationError("OutputFormat", string(rp.options.OutputFormat), "ResourcePrinter", outputFormatNames)
 	}
 }
 
@@ -95,6 +131,28 @@ func (rp *ResourcePrinter) printJSON(resources []*client.Object, w io.Writer) er
 	return err
 }
 
+// printList outputs resources as a single indented JSON v1 List, matching
+// the document kubectl emits for `get -o json` with multiple results.
+func (rp *ResourcePrinter) printList(resources []*client.Object, w io.Writer) error {
+	list := metav1.List{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+		Items:    make([]runtime.RawExtension, 0, len(resources)),
+	}
+	for _, obj := range resources {
+		if obj == nil || *obj == nil {
+			continue
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Object: *obj})
+	}
+	data, err := json.MarshalIndent(list, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "encode to JSON list")
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
+
 // printNames outputs resource names in kubectl-compatible format
 func (rp *ResourcePrinter) printNames(resources []*client.Object, w io.Writer) error {
 	for _, obj := range resources {
@@ -286,7 +344,13 @@ func ValidateOutputFormat(format string) (OutputFormat, error) {
 		return OutputFormatWide, nil
 	case "name":
 		return OutputFormatName, nil
+	case "list":
+		return OutputFormatList, nil
+	case "custom-columns":
+		return OutputFormatCustomColumns, nil
+	case "jsonpath":
+		return OutputFormatJSONPath, nil
 	default:
-		return "", errors.NewValidationError("format", format, "ParseOutputFormat", []string{"yaml", "json", "table", "wide", "name"})
+		return "", errors.NewValidationError("format", format, "ParseOutputFormat", outputFormatNames)
 	}
 }
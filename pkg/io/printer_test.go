@@ -2,6 +2,7 @@ package io_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -137,6 +138,7 @@ func TestValidateOutputFormat(t *testing.T) {
 		{"table", io.OutputFormatTable, false},
 		{"wide", io.OutputFormatWide, false},
 		{"name", io.OutputFormatName, false},
+		{"list", io.OutputFormatList, false},
 		{"invalid", "", true},
 		{"", "", true},
 	}
@@ -839,6 +841,8 @@ func TestValidateOutputFormat_EdgeCases(t *testing.T) {
 		{"TABLE", io.OutputFormatTable, false},
 		{"Wide", io.OutputFormatWide, false},
 		{"NAME", io.OutputFormatName, false},
+		{"custom-columns", io.OutputFormatCustomColumns, false},
+		{"JSONPath", io.OutputFormatJSONPath, false},
 		{"", "", true},
 		{"invalid", "", true},
 		{"xml", "", true},
@@ -865,6 +869,213 @@ func TestValidateOutputFormat_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestResourcePrinter_SortBy(t *testing.T) {
+	svc := createTestObject("v1", "Service", "web", "b")
+	cm := createTestConfigMap("zeta", "a")
+	ns := createTestObject("v1", "Namespace", "apps", "")
+	resources := []*client.Object{&svc, &cm, &ns}
+
+	tests := []struct {
+		sortBy   io.SortField
+		expected string
+	}{
+		{io.SortByNone, "service/web (namespace: b)\nconfigmap/zeta (namespace: a)\nnamespace/apps\n"},
+		{io.SortByKind, "configmap/zeta (namespace: a)\nnamespace/apps\nservice/web (namespace: b)\n"},
+		{io.SortByNamespace, "namespace/apps\nconfigmap/zeta (namespace: a)\nservice/web (namespace: b)\n"},
+		{io.SortByName, "namespace/apps\nservice/web (namespace: b)\nconfigmap/zeta (namespace: a)\n"},
+		{io.SortByPriority, "namespace/apps\nconfigmap/zeta (namespace: a)\nservice/web (namespace: b)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sortBy), func(t *testing.T) {
+			printer := io.NewResourcePrinter(io.PrintOptions{
+				OutputFormat: io.OutputFormatName,
+				SortBy:       string(tt.sortBy),
+			})
+			output, err := printer.PrintToString(resources)
+			if err != nil {
+				t.Fatalf("Failed to print: %v", err)
+			}
+			if output != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestResourcePrinter_SortByCustomPriority(t *testing.T) {
+	svc := createTestObject("v1", "Service", "web", "default")
+	cm := createTestConfigMap("cfg", "default")
+	resources := []*client.Object{&cm, &svc}
+
+	printer := io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat: io.OutputFormatYAML,
+		SortBy:       "priority",
+		SortPriority: func(obj client.Object) int {
+			if obj.GetObjectKind().GroupVersionKind().Kind == "Service" {
+				return 0
+			}
+			return 1
+		},
+	})
+
+	output, err := printer.PrintToString(resources)
+	if err != nil {
+		t.Fatalf("Failed to print YAML: %v", err)
+	}
+	if strings.Index(output, "kind: Service") > strings.Index(output, "kind: ConfigMap") {
+		t.Errorf("Expected Service before ConfigMap, got: %s", output)
+	}
+}
+
+func TestResourcePrinter_InvalidSortBy(t *testing.T) {
+	obj := createTestConfigMap("test-cm", "default")
+	printer := io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat: io.OutputFormatName,
+		SortBy:       "age",
+	})
+
+	var buf bytes.Buffer
+	if err := printer.Print([]*client.Object{&obj}, &buf); err == nil {
+		t.Fatal("Expected error for invalid sort field")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output on error, got %q", buf.String())
+	}
+}
+
+func TestResourcePrinter_PrintList(t *testing.T) {
+	cm1 := createTestConfigMap("b", "default")
+	cm2 := createTestConfigMap("a", "default")
+	resources := []*client.Object{&cm1, nil, &cm2}
+
+	printer := io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat: io.OutputFormatList,
+		SortBy:       "name",
+	})
+
+	var buf bytes.Buffer
+	if err := printer.Print(resources, &buf); err != nil {
+		t.Fatalf("Failed to print list: %v", err)
+	}
+
+	var list struct {
+		APIVersion string           `json:"apiVersion"`
+		Kind       string           `json:"kind"`
+		Items      []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &list); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if list.APIVersion != "v1" || list.Kind != "List" {
+		t.Errorf("Expected v1 List, got %s %s", list.APIVersion, list.Kind)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(list.Items))
+	}
+	first := list.Items[0]["metadata"].(map[string]any)["name"]
+	if first != "a" {
+		t.Errorf("Expected first item 'a', got %v", first)
+	}
+}
+
+func TestResourcePrinter_CustomColumns(t *testing.T) {
+	cm := createTestConfigMap("settings", "apps")
+	sa := createTestObject("v1", "ServiceAccount", "runner", "")
+	resources := []*client.Object{&cm, &sa}
+
+	columns, err := io.ParseCustomColumns("NAME:.metadata.name,NS:{.metadata.namespace},KEYS:data.key1")
+	if err != nil {
+		t.Fatalf("ParseCustomColumns: %v", err)
+	}
+	printer := io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat:  io.OutputFormatCustomColumns,
+		CustomColumns: columns,
+	})
+	out, err := printer.PrintToString(resources)
+	if err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	want := [][]string{{"NAME", "NS", "KEYS"}, {"settings", "apps", "value1"}, {"runner", "<none>", "<none>"}}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got:\n%s", len(want), out)
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("line %d: expected %v, got %v", i, want[i], got)
+		}
+	}
+
+	printer = io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat:  io.OutputFormatCustomColumns,
+		CustomColumns: columns,
+		NoHeaders:     true,
+	})
+	out, err = printer.PrintToString(resources)
+	if err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	if strings.Contains(out, "NAME") {
+		t.Errorf("expected no headers, got:\n%s", out)
+	}
+
+	for _, spec := range []string{"", "NAME", ":.metadata.name"} {
+		if _, err := io.ParseCustomColumns(spec); err == nil {
+			t.Errorf("expected error for spec %q", spec)
+		}
+	}
+	printer = io.NewResourcePrinter(io.PrintOptions{OutputFormat: io.OutputFormatCustomColumns})
+	if _, err := printer.PrintToString(resources); err == nil {
+		t.Error("expected error without columns")
+	}
+}
+
+func TestResourcePrinter_JSONPath(t *testing.T) {
+	cm := createTestConfigMap("settings", "apps")
+	sa := createTestObject("v1", "ServiceAccount", "runner", "")
+
+	printer := io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat:     io.OutputFormatJSONPath,
+		JSONPathTemplate: "{.metadata.name}/{.data.key2}{.spec.missing}",
+	})
+	out, err := printer.PrintToString([]*client.Object{&cm})
+	if err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	if out != "settings/value2" {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	printer = io.NewResourcePrinter(io.PrintOptions{
+		OutputFormat:     io.OutputFormatJSONPath,
+		JSONPathTemplate: `{range .items[*]}{.kind}:{.metadata.name}{"\n"}{end}`,
+	})
+	out, err = printer.PrintToString([]*client.Object{&cm, &sa})
+	if err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	if out != "ConfigMap:settings\nServiceAccount:runner\n" {
+		t.Errorf("unexpected list output %q", out)
+	}
+
+	for _, tmpl := range []string{"", "{.metadata.name"} {
+		printer = io.NewResourcePrinter(io.PrintOptions{OutputFormat: io.OutputFormatJSONPath, JSONPathTemplate: tmpl})
+		if _, err := printer.PrintToString([]*client.Object{&cm}); err == nil {
+			t.Errorf("expected error for template %q", tmpl)
+		}
+	}
+}
+
+func createTestObject(apiVersion, kind, name, namespace string) client.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
 // Helper function to create test ConfigMap
 func createTestConfigMap(name, namespace string) client.Object {
 	obj := &unstructured.Unstructured{}
@@ -10,6 +10,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -23,21 +24,75 @@ type ParseOptions struct {
 	// in the kure scheme. When true, unknown objects are returned as
 	// *unstructured.Unstructured instead of producing an error.
 	AllowUnstructured bool
+
+	// Strict decodes typed objects in strict mode. Unknown and duplicate
+	// fields do not fail decoding; the object is still returned and each
+	// finding is reported as a warning, so drift against the compiled API
+	// types surfaces at generation time.
+	Strict bool
+
+	// Warnings collects the findings of strict decoding. When it is set, a
+	// parse that only produced warnings returns a nil error. When it is nil,
+	// the findings are returned in the Warnings of a ParseErrors instead, so
+	// they are never dropped; use ParseErrors.HasErrors to tell them apart
+	// from decode failures.
+	Warnings *errors.Warnings
 }
 
+// strictCodecs decodes into the kure scheme and reports unknown or
+// duplicate fields as strict decoding errors.
+var strictCodecs = serializer.NewCodecFactory(kubernetes.Scheme, serializer.EnableStrict)
+
 func parse(yamlbytes []byte, opts ParseOptions) ([]client.Object, error) {
+	return parseReader(bytes.NewReader(yamlbytes), opts)
+}
+
+// parseReader collects every object decoded from r.
+func parseReader(r io.Reader, opts ParseOptions) ([]client.Object, error) {
+	retVal := make([]client.Object, 0)
+	err := parseStream(r, opts, func(obj runtime.Object) error {
+		retVal = append(retVal, obj.(client.Object))
+		return nil
+	})
+	return retVal, err
+}
+
+// parseStream decodes the YAML documents of r one at a time and passes each
+// object to fn. Documents that fail to decode are collected into a
+// ParseErrors value returned once the stream is exhausted, together with the
+// strict-mode warnings when opts.Warnings is nil. Errors returned
+// by fn and errors reading from r abort the stream immediately.
+func parseStream(r io.Reader, opts ParseOptions, fn func(runtime.Object) error) error {
 	// Parsing approach adapted from
 	// https://dx13.co.uk/articles/2021/01/15/kubernetes-types-using-go/
 
-	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(yamlbytes), 4096)
-	retVal := make([]runtime.Object, 0)
+	src := &readErrRecorder{r: r}
+	decoder := yamlutil.NewYAMLOrJSONDecoder(src, 4096)
 
 	if err := kubernetes.RegisterSchemes(); err != nil {
-		return nil, errors.Wrapf(err, "register schemes")
+		return errors.Wrapf(err, "register schemes")
 	}
 	decode := kubernetes.Codecs.UniversalDeserializer().Decode
+	if opts.Strict {
+		decode = strictCodecs.UniversalDeserializer().Decode
+	}
 
 	var errs []error
+	warnings := opts.Warnings
+	var ownWarnings *errors.Warnings
+	if warnings == nil {
+		ownWarnings = &errors.Warnings{}
+		warnings = ownWarnings
+	}
+	emit := func(obj runtime.Object) error {
+		if _, ok := obj.(client.Object); !ok {
+			errs = append(errs, errors.NewParseError("Kubernetes object",
+				fmt.Sprintf("object of type %T does not implement client.Object", obj),
+				0, 0, nil))
+			return nil
+		}
+		return fn(obj)
+	}
 
 	for {
 		var raw runtime.RawExtension
@@ -45,6 +100,9 @@ func parse(yamlbytes []byte, opts ParseOptions) ([]client.Object, error) {
 			if stderrors.Is(err, io.EOF) {
 				break
 			}
+			if src.err != nil {
+				return errors.Wrapf(src.err, "read YAML stream")
+			}
 			errs = append(errs, errors.NewParseError("YAML document", "failed to decode document", 0, 0, err))
 			continue
 		}
@@ -52,6 +110,10 @@ func parse(yamlbytes []byte, opts ParseOptions) ([]client.Object, error) {
 			continue
 		}
 		obj, _, err := decode(raw.Raw, nil, nil)
+		if err != nil && obj != nil && runtime.IsStrictDecodingError(err) {
+			addStrictWarnings(warnings, obj, err)
+			err = nil
+		}
 		if err != nil {
 			if opts.AllowUnstructured && runtime.IsNotRegisteredError(err) {
 				unstObj, _, unstErr := unstructured.UnstructuredJSONScheme.Decode(raw.Raw, nil, nil)
@@ -61,10 +123,12 @@ func parse(yamlbytes []byte, opts ParseOptions) ([]client.Object, error) {
 				}
 				if list, ok := unstObj.(*unstructured.UnstructuredList); ok {
 					for i := range list.Items {
-						retVal = append(retVal, &list.Items[i])
+						if err := emit(&list.Items[i]); err != nil {
+							return err
+						}
 					}
-				} else {
-					retVal = append(retVal, unstObj)
+				} else if err := emit(unstObj); err != nil {
+					return err
 				}
 				continue
 			}
@@ -75,24 +139,49 @@ func parse(yamlbytes []byte, opts ParseOptions) ([]client.Object, error) {
 			errs = append(errs, err)
 			continue
 		}
-		retVal = append(retVal, obj)
+		if err := emit(obj); err != nil {
+			return err
+		}
 	}
 
-	retValCO := make([]client.Object, 0, len(retVal))
-	for _, obj := range retVal {
-		co, ok := obj.(client.Object)
-		if !ok {
-			errs = append(errs, errors.NewParseError("Kubernetes object",
-				fmt.Sprintf("object of type %T does not implement client.Object", obj),
-				0, 0, nil))
-			continue
-		}
-		retValCO = append(retValCO, co)
+	if len(errs) > 0 || ownWarnings.Len() > 0 {
+		return &errors.ParseErrors{Errors: errs, Warnings: ownWarnings.Items()}
+	}
+	return nil
+}
+
+// addStrictWarnings records each finding of a strict decoding error for obj
+// in warnings, naming the object's kind and name.
+func addStrictWarnings(warnings *errors.Warnings, obj runtime.Object, err error) {
+	component := obj.GetObjectKind().GroupVersionKind().Kind
+	var name string
+	if co, ok := obj.(client.Object); ok {
+		name = co.GetName()
 	}
-	if len(errs) > 0 {
-		return retValCO, &errors.ParseErrors{Errors: errs}
+	strictErr, ok := runtime.AsStrictDecodingError(err)
+	if !ok {
+		warnings.Add(component, name, err.Error())
+		return
 	}
-	return retValCO, nil
+	for _, e := range strictErr.Errors() {
+		warnings.Add(component, name, e.Error())
+	}
+}
+
+// readErrRecorder remembers the first non-EOF error of the wrapped reader so
+// that I/O failures can be told apart from malformed documents, which the
+// decoder reports the same way.
+type readErrRecorder struct {
+	r   io.Reader
+	err error
+}
+
+func (rr *readErrRecorder) Read(p []byte) (int, error) {
+	n, err := rr.r.Read(p)
+	if err != nil && err != io.EOF && rr.err == nil {
+		rr.err = err
+	}
+	return n, err
 }
 
 // ParseFile reads the YAML file at path and returns the runtime objects
@@ -105,11 +194,12 @@ func ParseFile(path string) ([]client.Object, error) {
 // ParseFileWithOptions reads the YAML file at path and returns the runtime
 // objects defined within. Behavior is controlled by opts; see [ParseOptions].
 func ParseFileWithOptions(path string, opts ParseOptions) ([]client.Object, error) {
-	data, err := os.ReadFile(path)
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	return parse(data, opts)
+	defer f.Close()
+	return parseReader(f, opts)
 }
 
 // ParseYAML parses YAML bytes and returns the runtime objects
@@ -125,6 +215,38 @@ func ParseYAMLWithOptions(data []byte, opts ParseOptions) ([]client.Object, erro
 	return parse(data, opts)
 }
 
+// ParseStream decodes the multi-document YAML read from r one document at a
+// time and calls fn with each object, so large manifests such as full CRD
+// bundles never have to be held in memory at once. Every object passed to
+// fn also implements client.Object.
+//
+// Documents that fail to decode are skipped and reported together in a
+// ParseErrors value after the stream has been consumed. If fn returns an
+// error, or reading from r fails, decoding stops and that error is returned.
+func ParseStream(r io.Reader, fn func(runtime.Object) error) error {
+	return ParseStreamWithOptions(r, ParseOptions{}, fn)
+}
+
+// ParseStreamWithOptions is like [ParseStream] with behavior controlled by
+// opts; see [ParseOptions].
+func ParseStreamWithOptions(r io.Reader, opts ParseOptions, fn func(runtime.Object) error) error {
+	if r == nil {
+		return errors.New("ParseStream: reader must not be nil")
+	}
+	if fn == nil {
+		return errors.New("ParseStream: callback must not be nil")
+	}
+	return parseStream(r, opts, fn)
+}
+
+// RegisterScheme adds the types installed by addToScheme to the scheme used
+// by the parsers in this package, so downstream tools can decode their own
+// custom resources (for example Crossplane or Istio types) into typed
+// objects. See [kubernetes.RegisterScheme].
+func RegisterScheme(addToScheme func(*runtime.Scheme) error) error {
+	return kubernetes.RegisterScheme(addToScheme)
+}
+
 func checkType(obj runtime.Object) error {
 	if obj == nil {
 		return errors.ErrNilRuntimeObject
@@ -2,7 +2,10 @@ package io
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -437,3 +440,182 @@ notvalid
 		t.Fatalf("expected ParseErrors, got %T", err)
 	}
 }
+
+func TestParseStream(t *testing.T) {
+	var b strings.Builder
+	for i := range 50 {
+		fmt.Fprintf(&b, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm-%d\n---\n", i)
+	}
+
+	var names []string
+	err := ParseStream(strings.NewReader(b.String()), func(obj runtime.Object) error {
+		cm, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			t.Fatalf("expected ConfigMap, got %T", obj)
+		}
+		names = append(names, cm.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseStream returned error: %v", err)
+	}
+	if len(names) != 50 || names[0] != "cm-0" || names[49] != "cm-49" {
+		t.Fatalf("unexpected objects %v", names)
+	}
+}
+
+func TestParseStreamCallbackError(t *testing.T) {
+	data := `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: b
+`
+	stop := errors.New("stop")
+	calls := 0
+	err := ParseStream(strings.NewReader(data), func(runtime.Object) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected decoding to stop after 1 object, got %d calls", calls)
+	}
+}
+
+func TestParseStreamCollectsDecodeErrors(t *testing.T) {
+	data := `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+---
+notvalid
+---
+apiVersion: foo/v1
+kind: Unsupported
+metadata:
+  name: x
+`
+	calls := 0
+	err := ParseStream(strings.NewReader(data), func(runtime.Object) error {
+		calls++
+		return nil
+	})
+	if calls != 1 {
+		t.Fatalf("expected 1 valid object, got %d", calls)
+	}
+	var pe *errors2.ParseErrors
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected ParseErrors, got %T", err)
+	}
+	if len(pe.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(pe.Errors))
+	}
+
+	calls = 0
+	err = ParseStreamWithOptions(strings.NewReader(data), ParseOptions{AllowUnstructured: true}, func(runtime.Object) error {
+		calls++
+		return nil
+	})
+	if calls != 2 {
+		t.Fatalf("expected 2 objects with unstructured fallback, got %d", calls)
+	}
+	if !errors.As(err, &pe) || len(pe.Errors) != 1 {
+		t.Fatalf("expected 1 parse error, got %v", err)
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestParseStreamReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader("apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: sa\n---\n"), failingReader{readErr})
+	err := ParseStream(r, func(runtime.Object) error { return nil })
+	if !errors.Is(err, readErr) {
+		t.Fatalf("expected read error, got %v", err)
+	}
+}
+
+func TestParseStreamNilArguments(t *testing.T) {
+	if err := ParseStream(nil, func(runtime.Object) error { return nil }); err == nil {
+		t.Fatal("expected error for nil reader")
+	}
+	if err := ParseStream(strings.NewReader(""), nil); err == nil {
+		t.Fatal("expected error for nil callback")
+	}
+}
+
+func TestParseYAMLWithOptionsStrict(t *testing.T) {
+	data := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  labelz:
+    app: web
+data:
+  key: value
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+`)
+	objs, err := ParseYAMLWithOptions(data, ParseOptions{})
+	if err != nil || len(objs) != 2 {
+		t.Fatalf("expected lenient parse to succeed, got %d objects, %v", len(objs), err)
+	}
+
+	warnings := &errors2.Warnings{}
+	objs, err = ParseYAMLWithOptions(data, ParseOptions{Strict: true, Warnings: warnings})
+	if err != nil {
+		t.Fatalf("expected warnings only to leave the error nil, got %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("expected both objects in strict mode, got %d", len(objs))
+	}
+	if cm, ok := objs[0].(*corev1.ConfigMap); !ok || cm.Data["key"] != "value" {
+		t.Fatalf("unexpected first object: %#v", objs[0])
+	}
+	items := warnings.Items()
+	if len(items) != 1 {
+		t.Fatalf("expected 1 warning, got %v", items)
+	}
+	w := items[0]
+	if w.Component != "ConfigMap" || w.Path != "settings" || !strings.Contains(w.Message, "labelz") {
+		t.Errorf("unexpected warning %+v", w)
+	}
+
+	// Without a collector the findings are returned in ParseErrors.
+	objs, err = ParseYAMLWithOptions(data, ParseOptions{Strict: true})
+	if len(objs) != 2 {
+		t.Fatalf("expected both objects without a collector, got %d", len(objs))
+	}
+	var pe *errors2.ParseErrors
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected ParseErrors without a collector, got %v", err)
+	}
+	if pe.HasErrors() {
+		t.Fatalf("expected warnings only, got errors %v", pe.Errors)
+	}
+	if len(pe.Warnings) != 1 || !strings.Contains(pe.Warnings[0].Message, "labelz") {
+		t.Errorf("unexpected warnings %v", pe.Warnings)
+	}
+
+	// Decode failures are still returned as errors alongside the warnings.
+	warnings = &errors2.Warnings{}
+	broken := append(append([]byte{}, data...), []byte("---\napiVersion: v1\nkind: ConfigMap\nmetadata: [\n")...)
+	if _, err := ParseYAMLWithOptions(broken, ParseOptions{Strict: true, Warnings: warnings}); err == nil {
+		t.Error("expected an error for the malformed document")
+	}
+	if warnings.Len() != 1 {
+		t.Errorf("expected the warning to be collected next to the error, got %v", warnings.Items())
+	}
+}
@@ -0,0 +1,82 @@
+package io_test
+
+import (
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/io"
+)
+
+func TestKindPriority(t *testing.T) {
+	if io.KindPriority("Namespace") >= io.KindPriority("Deployment") {
+		t.Error("Expected Namespace to sort before Deployment")
+	}
+	if io.KindPriority("CustomResourceDefinition") >= io.KindPriority("ClusterRole") {
+		t.Error("Expected CustomResourceDefinition to sort before ClusterRole")
+	}
+	if io.KindPriority("Ingress") >= io.KindPriority("HelmRelease") {
+		t.Error("Expected unknown kinds to sort after known kinds")
+	}
+	if io.KindPriority("HelmRelease") != io.KindPriority("Kustomization") {
+		t.Error("Expected unknown kinds to share the same priority")
+	}
+}
+
+func TestSortObjects(t *testing.T) {
+	deploy := createTestObject("apps/v1", "Deployment", "web", "default")
+	ns := createTestObject("v1", "Namespace", "default", "")
+	cm := createTestConfigMap("web", "default")
+	input := []*client.Object{&deploy, nil, &ns, &cm}
+
+	sorted, err := io.SortObjects(input, io.SortByPriority, nil)
+	if err != nil {
+		t.Fatalf("SortObjects returned error: %v", err)
+	}
+
+	wantKinds := []string{"Namespace", "ConfigMap", "Deployment"}
+	for i, kind := range wantKinds {
+		got := (*sorted[i]).GetObjectKind().GroupVersionKind().Kind
+		if got != kind {
+			t.Errorf("position %d: expected %s, got %s", i, kind, got)
+		}
+	}
+	if sorted[3] != nil {
+		t.Error("Expected nil entry to be sorted last")
+	}
+
+	// The input slice must not be reordered.
+	if input[0] != &deploy || input[1] != nil {
+		t.Error("SortObjects modified its input slice")
+	}
+}
+
+func TestSortObjects_Stable(t *testing.T) {
+	a := createTestConfigMap("same", "default")
+	b := createTestConfigMap("same", "default")
+	sorted, err := io.SortObjects([]*client.Object{&a, &b}, io.SortByName, nil)
+	if err != nil {
+		t.Fatalf("SortObjects returned error: %v", err)
+	}
+	if sorted[0] != &a || sorted[1] != &b {
+		t.Error("Expected equal keys to keep their input order")
+	}
+}
+
+func TestSortObjects_None(t *testing.T) {
+	a := createTestConfigMap("b", "default")
+	b := createTestConfigMap("a", "default")
+	sorted, err := io.SortObjects([]*client.Object{&a, &b}, io.SortByNone, nil)
+	if err != nil {
+		t.Fatalf("SortObjects returned error: %v", err)
+	}
+	if sorted[0] != &a || sorted[1] != &b {
+		t.Error("Expected SortByNone to preserve input order")
+	}
+}
+
+func TestSortObjects_InvalidField(t *testing.T) {
+	if _, err := io.SortObjects(nil, "age", nil); err == nil {
+		t.Fatal("Expected error for unknown sort field")
+	}
+}
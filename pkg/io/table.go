@@ -8,6 +8,7 @@ import (
 	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -86,6 +87,38 @@ func KindSpecificColumns(gvk metav1.GroupVersionKind) []TableColumn {
 		return serviceColumns(base)
 	case "configmap", "secret":
 		return configColumns(base)
+	case "helmrelease":
+		return readyColumns(base,
+			TableColumn{Header: "CHART", Width: 20, Accessor: getHelmReleaseChart},
+			TableColumn{Header: "VERSION", Width: 10, Accessor: getHelmReleaseVersion})
+	case "kustomization":
+		if gvk.Group == "kustomize.config.k8s.io" {
+			return base
+		}
+		return readyColumns(base,
+			TableColumn{Header: "PATH", Width: 20, Accessor: func(obj client.Object) string {
+				return nestedStringOrNone(obj, "spec", "path")
+			}},
+			TableColumn{Header: "REVISION", Width: 20, Accessor: func(obj client.Object) string {
+				return nestedStringOrNone(obj, "status", "lastAppliedRevision")
+			}})
+	case "gitrepository":
+		return readyColumns(base,
+			TableColumn{Header: "URL", Width: 30, Accessor: func(obj client.Object) string {
+				return nestedStringOrNone(obj, "spec", "url")
+			}},
+			TableColumn{Header: "REF", Width: 12, Accessor: getGitRepositoryRef},
+			TableColumn{Header: "REVISION", Width: 20, WideOnly: true, Accessor: func(obj client.Object) string {
+				return nestedStringOrNone(obj, "status", "artifact", "revision")
+			}})
+	case "certificate":
+		return readyColumns(base,
+			TableColumn{Header: "SECRET", Width: 20, Accessor: func(obj client.Object) string {
+				return nestedStringOrNone(obj, "spec", "secretName")
+			}},
+			TableColumn{Header: "EXPIRY", Width: 20, Accessor: func(obj client.Object) string {
+				return nestedStringOrNone(obj, "status", "notAfter")
+			}})
 	default:
 		return base
 	}
@@ -222,6 +255,32 @@ func configColumns(base []TableColumn) []TableColumn {
 	return base
 }
 
+// readyColumns customizes columns for resources that report readiness
+// through a Ready condition, such as Flux and cert-manager objects. The READY
+// column shows the condition status and extra columns are inserted after it
+// in the given order.
+func readyColumns(base []TableColumn, extra ...TableColumn) []TableColumn {
+	columns := make([]TableColumn, 0, len(base)+len(extra))
+
+	for _, col := range base {
+		if col.Header != "READY" {
+			if col.Priority > 2 {
+				col.Priority += len(extra) // Shift priorities to make room for new columns
+			}
+			columns = append(columns, col)
+			continue
+		}
+		col.Accessor = getReadyCondition
+		columns = append(columns, col)
+		for i, e := range extra {
+			e.Priority = col.Priority + i + 1
+			columns = append(columns, e)
+		}
+	}
+
+	return columns
+}
+
 // SimpleTablePrinter provides a basic table printer implementation without k8s.io/cli-runtime dependency
 type SimpleTablePrinter struct {
 	columns   []TableColumn
@@ -611,3 +670,59 @@ func getConfigDataCount(obj client.Object) string {
 
 	return "0"
 }
+
+// getReadyCondition returns the status of the Ready condition ("True",
+// "False" or "Unknown").
+func getReadyCondition(obj client.Object) string {
+	content, err := objectContent(obj)
+	if err != nil {
+		return "Unknown"
+	}
+	conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		if status, ok := cond["status"].(string); ok && status != "" {
+			return status
+		}
+	}
+	return "Unknown"
+}
+
+// nestedStringOrNone returns the string at fields within obj, or "<none>"
+// when it is missing or empty.
+func nestedStringOrNone(obj client.Object, fields ...string) string {
+	content, err := objectContent(obj)
+	if err != nil {
+		return "<none>"
+	}
+	if v, _, _ := unstructured.NestedString(content, fields...); v != "" {
+		return v
+	}
+	return "<none>"
+}
+
+func getHelmReleaseChart(obj client.Object) string {
+	if chart := nestedStringOrNone(obj, "spec", "chart", "spec", "chart"); chart != "<none>" {
+		return chart
+	}
+	return nestedStringOrNone(obj, "spec", "chartRef", "name")
+}
+
+func getHelmReleaseVersion(obj client.Object) string {
+	if version := nestedStringOrNone(obj, "spec", "chart", "spec", "version"); version != "<none>" {
+		return version
+	}
+	return nestedStringOrNone(obj, "status", "lastAttemptedRevision")
+}
+
+func getGitRepositoryRef(obj client.Object) string {
+	for _, field := range []string{"branch", "tag", "semver", "name", "commit"} {
+		if v := nestedStringOrNone(obj, "spec", "ref", field); v != "<none>" {
+			return v
+		}
+	}
+	return "<none>"
+}
@@ -1576,3 +1576,69 @@ func createTestPodInvalidSpec(name, namespace string) client.Object {
 	obj.Object["spec"] = "invalid-spec-type" // Invalid type
 	return obj
 }
+
+func TestKindSpecificColumns_FluxAndCertManager(t *testing.T) {
+	ready := []any{map[string]any{"type": "Ready", "status": "True"}}
+	tests := []struct {
+		gvk     metav1.GroupVersionKind
+		content map[string]any
+		want    map[string]string
+	}{
+		{
+			gvk: metav1.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
+			content: map[string]any{
+				"spec":   map[string]any{"chart": map[string]any{"spec": map[string]any{"chart": "podinfo", "version": "6.5.0"}}},
+				"status": map[string]any{"conditions": ready},
+			},
+			want: map[string]string{"READY": "True", "CHART": "podinfo", "VERSION": "6.5.0"},
+		},
+		{
+			gvk: metav1.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"},
+			content: map[string]any{
+				"spec":   map[string]any{"path": "./apps"},
+				"status": map[string]any{"lastAppliedRevision": "main@sha1:abc"},
+			},
+			want: map[string]string{"READY": "Unknown", "PATH": "./apps", "REVISION": "main@sha1:abc"},
+		},
+		{
+			gvk: metav1.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "GitRepository"},
+			content: map[string]any{
+				"spec":   map[string]any{"url": "https://github.com/org/fleet", "ref": map[string]any{"tag": "v1.0.0"}},
+				"status": map[string]any{"conditions": []any{map[string]any{"type": "Ready", "status": "False"}}},
+			},
+			want: map[string]string{"READY": "False", "URL": "https://github.com/org/fleet", "REF": "v1.0.0", "REVISION": "<none>"},
+		},
+		{
+			gvk: metav1.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+			content: map[string]any{
+				"spec":   map[string]any{"secretName": "web-tls"},
+				"status": map[string]any{"conditions": ready, "notAfter": "2027-01-01T00:00:00Z"},
+			},
+			want: map[string]string{"READY": "True", "SECRET": "web-tls", "EXPIRY": "2027-01-01T00:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gvk.Kind, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: tt.content}
+			obj.SetAPIVersion(tt.gvk.Group + "/" + tt.gvk.Version)
+			obj.SetKind(tt.gvk.Kind)
+			obj.SetName("example")
+
+			got := map[string]string{}
+			for _, col := range io.KindSpecificColumns(tt.gvk) {
+				got[col.Header] = col.Accessor(obj)
+			}
+			for header, want := range tt.want {
+				if got[header] != want {
+					t.Errorf("column %s = %q, want %q", header, got[header], want)
+				}
+			}
+		})
+	}
+
+	kustomizeConfig := io.KindSpecificColumns(metav1.GroupVersionKind{Group: "kustomize.config.k8s.io", Version: "v1beta1", Kind: "Kustomization"})
+	if len(kustomizeConfig) != len(io.DefaultColumns()) {
+		t.Error("expected default columns for kustomize.config.k8s.io Kustomization")
+	}
+}
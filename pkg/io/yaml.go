@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	kjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -110,7 +111,18 @@ func EncodeObjectsToYAML(objects []*client.Object) ([]byte, error) {
 // configurable output options. When opts.KubernetesFieldOrder is true,
 // top-level fields are emitted in the conventional order used by kubectl,
 // Helm, and Kustomize (apiVersion, kind, metadata, spec, ..., status last).
+// Indent, NoLineWrap and QuoteStyle adjust the output style. SortBy orders
+// the objects first, LeadingSeparator and SeparatorBlankLines control the
+// "---" document separators and StripEmptyFields drops nulls and empty status
+// maps at any depth. Invalid option values return a ValidationError.
 func EncodeObjectsToYAMLWithOptions(objects []*client.Object, opts EncodeOptions) ([]byte, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	objects, err := SortObjects(objects, opts.SortBy, opts.SortPriority)
+	if err != nil {
+		return nil, err
+	}
 	var buf bytes.Buffer
 	for i, obj := range objects {
 		cleaned, err := marshalCleanResource(*obj, opts)
@@ -118,6 +130,9 @@ func EncodeObjectsToYAMLWithOptions(objects []*client.Object, opts EncodeOptions
 			return nil, err
 		}
 		if i > 0 {
+			buf.WriteString(strings.Repeat("\n", opts.SeparatorBlankLines))
+		}
+		if i > 0 || opts.LeadingSeparator {
 			buf.WriteString("---\n")
 		}
 		buf.Write(cleaned)
@@ -149,9 +164,12 @@ func marshalCleanResource(obj client.Object, opts EncodeOptions) ([]byte, error)
 	}
 
 	cleanResourceMap(raw, opts.ServerFieldStripping)
+	if opts.StripEmptyFields {
+		stripEmptyFields(raw)
+	}
 
-	if opts.KubernetesFieldOrder {
-		return marshalOrderedYAML(raw)
+	if opts.styled() {
+		return marshalStyledYAML(raw, opts)
 	}
 
 	out, err := yaml.Marshal(raw)
@@ -257,6 +275,26 @@ func removeEmptyStatus(m map[string]any) {
 	}
 }
 
+// stripEmptyFields recursively deletes null values and empty "status" maps
+// from m and from every map nested in it, including maps inside lists.
+func stripEmptyFields(m map[string]any) {
+	for k, v := range m {
+		switch val := v.(type) {
+		case nil:
+			delete(m, k)
+		case map[string]any:
+			stripEmptyFields(val)
+		case []any:
+			for _, item := range val {
+				if im, ok := item.(map[string]any); ok {
+					stripEmptyFields(im)
+				}
+			}
+		}
+	}
+	removeEmptyStatus(m)
+}
+
 // isDeepEmpty returns true if a map is empty or contains only zero-value primitives
 // and empty maps recursively. After JSON round-trip, numbers are float64, booleans
 // are bool, and strings are string — all checked against their zero values.
@@ -300,6 +338,7 @@ func PrintObjects(objects []*client.Object, format OutputFormat, options PrintOp
 		ShowLabels:   options.ShowLabels,
 		ColumnLabels: options.ColumnLabels,
 		SortBy:       options.SortBy,
+		SortPriority: options.SortPriority,
 	})
 	return printer.Print(objects, w)
 }
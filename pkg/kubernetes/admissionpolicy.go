@@ -0,0 +1,189 @@
+package kubernetes
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateValidatingAdmissionPolicy returns a cluster-scoped
+// ValidatingAdmissionPolicy with default labels and annotations. The failure
+// policy defaults to Fail.
+func CreateValidatingAdmissionPolicy(name string) *admissionregistrationv1.ValidatingAdmissionPolicy {
+	failurePolicy := admissionregistrationv1.Fail
+	return &admissionregistrationv1.ValidatingAdmissionPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ValidatingAdmissionPolicy",
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+		},
+	}
+}
+
+// CreateAdmissionResourceRule returns a rule matching the given operations on
+// resources of the listed API groups and versions. "*" matches everything.
+func CreateAdmissionResourceRule(apiGroups, apiVersions, resources []string, operations ...admissionregistrationv1.OperationType) admissionregistrationv1.NamedRuleWithOperations {
+	return admissionregistrationv1.NamedRuleWithOperations{
+		RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   apiGroups,
+				APIVersions: apiVersions,
+				Resources:   resources,
+			},
+		},
+	}
+}
+
+// SetValidatingAdmissionPolicyFailurePolicy sets how errors evaluating the
+// policy are handled.
+func SetValidatingAdmissionPolicyFailurePolicy(policy *admissionregistrationv1.ValidatingAdmissionPolicy, fp admissionregistrationv1.FailurePolicyType) {
+	if policy == nil {
+		panic("SetValidatingAdmissionPolicyFailurePolicy: policy must not be nil")
+	}
+	policy.Spec.FailurePolicy = &fp
+}
+
+// SetValidatingAdmissionPolicyParamKind sets the kind of the resources that
+// parameterise the policy.
+func SetValidatingAdmissionPolicyParamKind(policy *admissionregistrationv1.ValidatingAdmissionPolicy, apiVersion, kind string) {
+	if policy == nil {
+		panic("SetValidatingAdmissionPolicyParamKind: policy must not be nil")
+	}
+	policy.Spec.ParamKind = &admissionregistrationv1.ParamKind{APIVersion: apiVersion, Kind: kind}
+}
+
+// SetValidatingAdmissionPolicyMatchConstraints replaces the resources the
+// policy applies to.
+func SetValidatingAdmissionPolicyMatchConstraints(policy *admissionregistrationv1.ValidatingAdmissionPolicy, match *admissionregistrationv1.MatchResources) {
+	if policy == nil {
+		panic("SetValidatingAdmissionPolicyMatchConstraints: policy must not be nil")
+	}
+	policy.Spec.MatchConstraints = match
+}
+
+// AddValidatingAdmissionPolicyResourceRule appends a resource rule to the
+// policy's match constraints, creating them if needed.
+func AddValidatingAdmissionPolicyResourceRule(policy *admissionregistrationv1.ValidatingAdmissionPolicy, rule admissionregistrationv1.NamedRuleWithOperations) {
+	if policy == nil {
+		panic("AddValidatingAdmissionPolicyResourceRule: policy must not be nil")
+	}
+	if policy.Spec.MatchConstraints == nil {
+		policy.Spec.MatchConstraints = &admissionregistrationv1.MatchResources{}
+	}
+	policy.Spec.MatchConstraints.ResourceRules = append(policy.Spec.MatchConstraints.ResourceRules, rule)
+}
+
+// AddValidatingAdmissionPolicyValidation appends a CEL validation. A request
+// is rejected with message when expression evaluates to false.
+func AddValidatingAdmissionPolicyValidation(policy *admissionregistrationv1.ValidatingAdmissionPolicy, expression, message string) {
+	if policy == nil {
+		panic("AddValidatingAdmissionPolicyValidation: policy must not be nil")
+	}
+	policy.Spec.Validations = append(policy.Spec.Validations, admissionregistrationv1.Validation{
+		Expression: expression,
+		Message:    message,
+	})
+}
+
+// AddValidatingAdmissionPolicyMatchCondition appends a CEL condition that must
+// hold for the policy to be evaluated at all.
+func AddValidatingAdmissionPolicyMatchCondition(policy *admissionregistrationv1.ValidatingAdmissionPolicy, name, expression string) {
+	if policy == nil {
+		panic("AddValidatingAdmissionPolicyMatchCondition: policy must not be nil")
+	}
+	policy.Spec.MatchConditions = append(policy.Spec.MatchConditions, admissionregistrationv1.MatchCondition{
+		Name:       name,
+		Expression: expression,
+	})
+}
+
+// AddValidatingAdmissionPolicyVariable appends a CEL variable that
+// validations can reference as variables.<name>.
+func AddValidatingAdmissionPolicyVariable(policy *admissionregistrationv1.ValidatingAdmissionPolicy, name, expression string) {
+	if policy == nil {
+		panic("AddValidatingAdmissionPolicyVariable: policy must not be nil")
+	}
+	policy.Spec.Variables = append(policy.Spec.Variables, admissionregistrationv1.Variable{
+		Name:       name,
+		Expression: expression,
+	})
+}
+
+// AddValidatingAdmissionPolicyAuditAnnotation appends an audit annotation
+// whose value is computed by the CEL valueExpression.
+func AddValidatingAdmissionPolicyAuditAnnotation(policy *admissionregistrationv1.ValidatingAdmissionPolicy, key, valueExpression string) {
+	if policy == nil {
+		panic("AddValidatingAdmissionPolicyAuditAnnotation: policy must not be nil")
+	}
+	policy.Spec.AuditAnnotations = append(policy.Spec.AuditAnnotations, admissionregistrationv1.AuditAnnotation{
+		Key:             key,
+		ValueExpression: valueExpression,
+	})
+}
+
+// CreateValidatingAdmissionPolicyBinding returns a binding that enforces the
+// named policy with the Deny action.
+func CreateValidatingAdmissionPolicyBinding(name, policyName string) *admissionregistrationv1.ValidatingAdmissionPolicyBinding {
+	return &admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ValidatingAdmissionPolicyBinding",
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName:        policyName,
+			ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+		},
+	}
+}
+
+// SetValidatingAdmissionPolicyBindingValidationActions replaces the actions
+// taken when a validation fails, e.g. Warn and Audit for a dry run.
+func SetValidatingAdmissionPolicyBindingValidationActions(binding *admissionregistrationv1.ValidatingAdmissionPolicyBinding, actions ...admissionregistrationv1.ValidationAction) {
+	if binding == nil {
+		panic("SetValidatingAdmissionPolicyBindingValidationActions: binding must not be nil")
+	}
+	binding.Spec.ValidationActions = actions
+}
+
+// SetValidatingAdmissionPolicyBindingParamRef sets the parameter resource
+// used by the bound policy. An empty namespace refers to a cluster-scoped
+// resource or, for namespaced kinds, the namespace of the request.
+func SetValidatingAdmissionPolicyBindingParamRef(binding *admissionregistrationv1.ValidatingAdmissionPolicyBinding, name, namespace string, notFound admissionregistrationv1.ParameterNotFoundActionType) {
+	if binding == nil {
+		panic("SetValidatingAdmissionPolicyBindingParamRef: binding must not be nil")
+	}
+	binding.Spec.ParamRef = &admissionregistrationv1.ParamRef{
+		Name:                    name,
+		Namespace:               namespace,
+		ParameterNotFoundAction: &notFound,
+	}
+}
+
+// SetValidatingAdmissionPolicyBindingMatchResources narrows the resources the
+// binding applies to within the policy's match constraints.
+func SetValidatingAdmissionPolicyBindingMatchResources(binding *admissionregistrationv1.ValidatingAdmissionPolicyBinding, match *admissionregistrationv1.MatchResources) {
+	if binding == nil {
+		panic("SetValidatingAdmissionPolicyBindingMatchResources: binding must not be nil")
+	}
+	binding.Spec.MatchResources = match
+}
@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateValidatingAdmissionPolicy(t *testing.T) {
+	policy := CreateValidatingAdmissionPolicy("require-replicas")
+	if policy.Name != "require-replicas" || policy.Namespace != "" {
+		t.Fatalf("metadata mismatch: %s/%s", policy.Namespace, policy.Name)
+	}
+	if policy.Kind != "ValidatingAdmissionPolicy" || policy.APIVersion != "admissionregistration.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", policy.APIVersion, policy.Kind)
+	}
+	if policy.Spec.FailurePolicy == nil || *policy.Spec.FailurePolicy != admissionregistrationv1.Fail {
+		t.Errorf("expected failure policy Fail, got %v", policy.Spec.FailurePolicy)
+	}
+}
+
+func TestValidatingAdmissionPolicyNilErrors(t *testing.T) {
+	assertPanics(t, func() { SetValidatingAdmissionPolicyFailurePolicy(nil, admissionregistrationv1.Ignore) })
+	assertPanics(t, func() { SetValidatingAdmissionPolicyParamKind(nil, "v1", "ConfigMap") })
+	assertPanics(t, func() { SetValidatingAdmissionPolicyMatchConstraints(nil, nil) })
+	assertPanics(t, func() {
+		AddValidatingAdmissionPolicyResourceRule(nil, admissionregistrationv1.NamedRuleWithOperations{})
+	})
+	assertPanics(t, func() { AddValidatingAdmissionPolicyValidation(nil, "true", "") })
+	assertPanics(t, func() { AddValidatingAdmissionPolicyMatchCondition(nil, "n", "true") })
+	assertPanics(t, func() { AddValidatingAdmissionPolicyVariable(nil, "n", "1") })
+	assertPanics(t, func() { AddValidatingAdmissionPolicyAuditAnnotation(nil, "k", "'v'") })
+	assertPanics(t, func() { SetValidatingAdmissionPolicyBindingValidationActions(nil) })
+	assertPanics(t, func() {
+		SetValidatingAdmissionPolicyBindingParamRef(nil, "p", "", admissionregistrationv1.DenyAction)
+	})
+	assertPanics(t, func() { SetValidatingAdmissionPolicyBindingMatchResources(nil, nil) })
+}
+
+func TestValidatingAdmissionPolicyFunctions(t *testing.T) {
+	policy := CreateValidatingAdmissionPolicy("require-replicas")
+
+	SetValidatingAdmissionPolicyFailurePolicy(policy, admissionregistrationv1.Ignore)
+	if *policy.Spec.FailurePolicy != admissionregistrationv1.Ignore {
+		t.Errorf("failure policy not set")
+	}
+
+	SetValidatingAdmissionPolicyParamKind(policy, "v1", "ConfigMap")
+	if pk := policy.Spec.ParamKind; pk == nil || pk.APIVersion != "v1" || pk.Kind != "ConfigMap" {
+		t.Errorf("param kind not set")
+	}
+
+	rule := CreateAdmissionResourceRule([]string{"apps"}, []string{"v1"}, []string{"deployments"},
+		admissionregistrationv1.Create, admissionregistrationv1.Update)
+	AddValidatingAdmissionPolicyResourceRule(policy, rule)
+	mc := policy.Spec.MatchConstraints
+	if mc == nil || len(mc.ResourceRules) != 1 {
+		t.Fatalf("resource rule not added: %+v", mc)
+	}
+	if !reflect.DeepEqual(mc.ResourceRules[0].Resources, []string{"deployments"}) ||
+		len(mc.ResourceRules[0].Operations) != 2 {
+		t.Errorf("unexpected resource rule %+v", mc.ResourceRules[0])
+	}
+
+	AddValidatingAdmissionPolicyMatchCondition(policy, "not-system", "!request.namespace.startsWith('kube-')")
+	AddValidatingAdmissionPolicyVariable(policy, "replicas", "object.spec.replicas")
+	AddValidatingAdmissionPolicyValidation(policy, "variables.replicas >= 2", "at least two replicas are required")
+	AddValidatingAdmissionPolicyAuditAnnotation(policy, "replicas", "string(variables.replicas)")
+	if len(policy.Spec.MatchConditions) != 1 || policy.Spec.MatchConditions[0].Name != "not-system" {
+		t.Errorf("match condition not added")
+	}
+	if len(policy.Spec.Variables) != 1 || policy.Spec.Variables[0].Name != "replicas" {
+		t.Errorf("variable not added")
+	}
+	if len(policy.Spec.Validations) != 1 || policy.Spec.Validations[0].Message != "at least two replicas are required" {
+		t.Errorf("validation not added")
+	}
+	if len(policy.Spec.AuditAnnotations) != 1 || policy.Spec.AuditAnnotations[0].Key != "replicas" {
+		t.Errorf("audit annotation not added")
+	}
+
+	match := &admissionregistrationv1.MatchResources{}
+	SetValidatingAdmissionPolicyMatchConstraints(policy, match)
+	if policy.Spec.MatchConstraints != match {
+		t.Errorf("match constraints not replaced")
+	}
+}
+
+func TestValidatingAdmissionPolicyBindingFunctions(t *testing.T) {
+	binding := CreateValidatingAdmissionPolicyBinding("require-replicas-prod", "require-replicas")
+	if binding.Kind != "ValidatingAdmissionPolicyBinding" || binding.Spec.PolicyName != "require-replicas" {
+		t.Fatalf("unexpected binding %s %+v", binding.Kind, binding.Spec)
+	}
+	if !reflect.DeepEqual(binding.Spec.ValidationActions, []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}) {
+		t.Errorf("expected default Deny action, got %v", binding.Spec.ValidationActions)
+	}
+
+	SetValidatingAdmissionPolicyBindingValidationActions(binding, admissionregistrationv1.Warn, admissionregistrationv1.Audit)
+	if len(binding.Spec.ValidationActions) != 2 || binding.Spec.ValidationActions[0] != admissionregistrationv1.Warn {
+		t.Errorf("validation actions not set")
+	}
+
+	SetValidatingAdmissionPolicyBindingParamRef(binding, "replica-limits", "policy-params", admissionregistrationv1.DenyAction)
+	ref := binding.Spec.ParamRef
+	if ref == nil || ref.Name != "replica-limits" || ref.Namespace != "policy-params" ||
+		ref.ParameterNotFoundAction == nil || *ref.ParameterNotFoundAction != admissionregistrationv1.DenyAction {
+		t.Errorf("unexpected param ref %+v", ref)
+	}
+
+	match := &admissionregistrationv1.MatchResources{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+	}
+	SetValidatingAdmissionPolicyBindingMatchResources(binding, match)
+	if binding.Spec.MatchResources != match {
+		t.Errorf("match resources not set")
+	}
+}
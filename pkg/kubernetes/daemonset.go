@@ -4,6 +4,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/go-kure/kure/pkg/errors"
 )
@@ -144,3 +145,26 @@ func SetDaemonSetRevisionHistoryLimit(ds *appsv1.DaemonSet, limit *int32) {
 	}
 	ds.Spec.RevisionHistoryLimit = limit
 }
+
+// SetDaemonSetMinReadySeconds sets the minimum ready seconds.
+func SetDaemonSetMinReadySeconds(ds *appsv1.DaemonSet, secs int32) {
+	if ds == nil {
+		panic("SetDaemonSetMinReadySeconds: ds must not be nil")
+	}
+	ds.Spec.MinReadySeconds = secs
+}
+
+// SetDaemonSetRollingUpdate switches the DaemonSet to rolling updates with
+// the given maxUnavailable and maxSurge. A nil value keeps the API default.
+func SetDaemonSetRollingUpdate(ds *appsv1.DaemonSet, maxUnavailable, maxSurge *intstr.IntOrString) {
+	if ds == nil {
+		panic("SetDaemonSetRollingUpdate: ds must not be nil")
+	}
+	ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+		Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+			MaxUnavailable: maxUnavailable,
+			MaxSurge:       maxSurge,
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestAddDaemonSetTopologySpreadConstraints(t *testing.T) {
@@ -162,6 +163,22 @@ func TestDaemonSetFunctions(t *testing.T) {
 	if ds.Spec.RevisionHistoryLimit == nil || *ds.Spec.RevisionHistoryLimit != 3 {
 		t.Errorf("revision history limit not set")
 	}
+
+	SetDaemonSetMinReadySeconds(ds, 10)
+	if ds.Spec.MinReadySeconds != 10 {
+		t.Errorf("min ready seconds not set")
+	}
+
+	maxUnavailable := intstr.FromInt32(0)
+	maxSurge := intstr.FromString("25%")
+	SetDaemonSetRollingUpdate(ds, &maxUnavailable, &maxSurge)
+	ru := ds.Spec.UpdateStrategy.RollingUpdate
+	if ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType || ru == nil {
+		t.Fatalf("rolling update not set")
+	}
+	if ru.MaxUnavailable.IntValue() != 0 || ru.MaxSurge.String() != "25%" {
+		t.Errorf("unexpected rolling update %+v", ru)
+	}
 }
 
 func TestDaemonSetNilGuards(t *testing.T) {
@@ -197,6 +214,8 @@ func TestDaemonSetNilGuards(t *testing.T) {
 	assertPanics(t, func() { SetDaemonSetNodeSelector(nil, nil) })
 	assertPanics(t, func() { SetDaemonSetUpdateStrategy(nil, appsv1.DaemonSetUpdateStrategy{}) })
 	assertPanics(t, func() { SetDaemonSetRevisionHistoryLimit(nil, &rhl) })
+	assertPanics(t, func() { SetDaemonSetMinReadySeconds(nil, 1) })
+	assertPanics(t, func() { SetDaemonSetRollingUpdate(nil, nil, nil) })
 
 	// Secondary nil guard: spec == nil with valid receiver.
 	ds := CreateDaemonSet("test", "default")
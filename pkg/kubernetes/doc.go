@@ -15,6 +15,14 @@
 // The scheme is registered lazily on first use and is safe for concurrent
 // access.
 //
+// # Validation
+//
+// [ValidateObject] checks an object against Kubernetes naming and value
+// constraints and [ValidateCronSchedule] checks CronJob schedules.
+// A [Validator] value runs the same checks inside the builders: its
+// Create*, container, port, schedule and workload metadata methods reject
+// invalid input before creating or changing an object.
+//
 // # HPA Builders
 //
 // [CreateHorizontalPodAutoscaler] allocates a fully initialised
@@ -30,6 +38,19 @@
 // All setter/adder functions return an error when passed a nil HPA pointer,
 // using [github.com/go-kure/kure/pkg/errors.ErrNilHorizontalPodAutoscaler].
 //
+// # Workload Metadata
+//
+// [SetWorkloadLabels] and [SetWorkloadAnnotations] merge metadata into a
+// Deployment, StatefulSet, DaemonSet, Job or CronJob and, optionally, its
+// pod template.
+//
+// # VPA Builders
+//
+// [CreateVerticalPodAutoscaler] allocates an autoscaling.k8s.io/v1
+// VerticalPodAutoscaler, configured with [SetVPATargetRef],
+// [SetVPAUpdateMode], [SetVPAMinReplicas] and [AddVPAContainerPolicy]
+// (see [CreateVPAContainerPolicy]). The helpers panic on a nil VPA.
+//
 // # PDB Builders
 //
 // [CreatePodDisruptionBudget] allocates a fully initialised policy/v1
@@ -93,6 +114,10 @@
 // All setter/adder functions return an error when passed a nil Service pointer,
 // using [github.com/go-kure/kure/pkg/errors.ErrNilService].
 //
+// [CreateExternalService] pairs a headless Service ([CreateHeadlessService])
+// with an EndpointSlice ([CreateEndpointSlice]) listing addresses outside the
+// cluster.
+//
 // # Ingress Builders
 //
 // [CreateIngress] allocates a fully initialised networking/v1 Ingress.
@@ -139,6 +164,40 @@
 // when passed a nil pointer, using
 // [github.com/go-kure/kure/pkg/errors.ErrNilHTTPRoute].
 //
+// # Gateway and GRPCRoute Builders
+//
+// [CreateGateway] allocates a gateway/v1 Gateway for a GatewayClass and
+// [CreateGatewayListener] a listener for it:
+//
+//   - [SetGatewayClassName] — GatewayClass
+//   - [AddGatewayListener], [SetGatewayListeners] — listeners
+//   - [SetGatewayListenerHostname], [SetGatewayListenerAllowedRoutes] — listener options
+//   - [AddGatewayAddress], [SetGatewayInfrastructure] — addresses and infrastructure
+//
+// [CreateGRPCRoute] mirrors [CreateHTTPRoute] with [AddGRPCRouteHostname],
+// [AddGRPCRouteParentRef], [AddGRPCRouteRule] and the rule helpers
+// [AddGRPCRouteRuleMatch], [AddGRPCRouteRuleFilter] and
+// [AddGRPCRouteRuleBackendRef]. Helpers taking a Gateway or GRPCRoute panic
+// when passed a nil pointer.
+//
+// # ValidatingAdmissionPolicy Builders
+//
+// [CreateValidatingAdmissionPolicy] and [CreateValidatingAdmissionPolicyBinding]
+// allocate admissionregistration/v1 objects. Policies are configured with
+// [AddValidatingAdmissionPolicyResourceRule] (see [CreateAdmissionResourceRule]),
+// [AddValidatingAdmissionPolicyValidation] and the other CEL helpers;
+// bindings with [SetValidatingAdmissionPolicyBindingValidationActions],
+// [SetValidatingAdmissionPolicyBindingParamRef] and
+// [SetValidatingAdmissionPolicyBindingMatchResources].
+//
+// # PriorityClass and RuntimeClass Builders
+//
+// [CreatePriorityClass] and [CreateRuntimeClass] allocate the cluster-scoped
+// scheduling/v1 and node/v1 objects, configured with [SetPriorityClassValue],
+// [SetPriorityClassGlobalDefault], [SetPriorityClassDescription],
+// [SetPriorityClassPreemptionPolicy], [SetRuntimeClassOverhead],
+// [SetRuntimeClassNodeSelector] and [AddRuntimeClassToleration].
+//
 // # PSA Security Context Helpers
 //
 // [RestrictedPodSecurityContext], [BaselinePodSecurityContext], and
@@ -154,6 +213,10 @@
 // [ValidateContainerPSA] and [ValidatePodSpecPSA] check compliance against a
 // given PSA level.
 //
+// [ApplyRestrictedSecurityProfile] and [ApplyBaselineSecurityProfile] bring
+// the pod template of a Deployment, StatefulSet, DaemonSet, Job or CronJob up
+// to the given level in place.
+//
 // # ResourceRequirements Builder
 //
 // [CreateResourceRequirements] returns an empty ResourceRequirements.
@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// EndpointSliceManagedBy is the endpointslice.kubernetes.io/managed-by value
+// of EndpointSlices created by this package. It keeps the EndpointSlice
+// controller from adopting or removing them.
+const EndpointSliceManagedBy = "kure"
+
+// CreateHeadlessService returns a headless Service without a selector. Its
+// endpoints are not managed by Kubernetes and are supplied through
+// EndpointSlices, e.g. from [CreateEndpointSlice].
+func CreateHeadlessService(name, namespace string) *corev1.Service {
+	svc := CreateService(name, namespace)
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
+	svc.Spec.Selector = nil
+	return svc
+}
+
+// CreateEndpointSlice returns an empty EndpointSlice of the given address type
+// that belongs to the named Service.
+func CreateEndpointSlice(name, namespace, serviceName string, addressType discoveryv1.AddressType) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "EndpointSlice",
+			APIVersion: discoveryv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: serviceName,
+				discoveryv1.LabelManagedBy:   EndpointSliceManagedBy,
+			},
+		},
+		AddressType: addressType,
+		Endpoints:   []discoveryv1.Endpoint{},
+		Ports:       []discoveryv1.EndpointPort{},
+	}
+}
+
+// AddEndpointSliceEndpoint appends a ready endpoint with the given addresses.
+func AddEndpointSliceEndpoint(slice *discoveryv1.EndpointSlice, addresses ...string) {
+	if slice == nil {
+		panic("AddEndpointSliceEndpoint: slice must not be nil")
+	}
+	ready := true
+	slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+		Addresses:  addresses,
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+	})
+}
+
+// AddEndpointSlicePort appends a port. The name must match the name of the
+// corresponding Service port.
+func AddEndpointSlicePort(slice *discoveryv1.EndpointSlice, name string, port int32, protocol corev1.Protocol) {
+	if slice == nil {
+		panic("AddEndpointSlicePort: slice must not be nil")
+	}
+	slice.Ports = append(slice.Ports, discoveryv1.EndpointPort{
+		Name:     &name,
+		Port:     &port,
+		Protocol: &protocol,
+	})
+}
+
+// CreateExternalService returns a headless Service and a matching
+// EndpointSlice that point at addresses outside the cluster, so external
+// backends can be addressed like in-cluster services. Each Service port is
+// mirrored on the EndpointSlice, using its numeric target port if set.
+func CreateExternalService(name, namespace string, addressType discoveryv1.AddressType, addresses []string, ports ...corev1.ServicePort) (*corev1.Service, *discoveryv1.EndpointSlice) {
+	svc := CreateHeadlessService(name, namespace)
+	slice := CreateEndpointSlice(name, namespace, name, addressType)
+	for _, p := range ports {
+		if p.Protocol == "" {
+			p.Protocol = corev1.ProtocolTCP
+		}
+		AddServicePort(svc, p)
+		target := p.Port
+		if p.TargetPort.Type == intstr.Int && p.TargetPort.IntVal != 0 {
+			target = p.TargetPort.IntVal
+		}
+		AddEndpointSlicePort(slice, p.Name, target, p.Protocol)
+	}
+	for _, addr := range addresses {
+		AddEndpointSliceEndpoint(slice, addr)
+	}
+	return svc, slice
+}
@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCreateHeadlessService(t *testing.T) {
+	svc := CreateHeadlessService("db", "default")
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		t.Errorf("expected clusterIP None, got %q", svc.Spec.ClusterIP)
+	}
+	if svc.Spec.Selector != nil {
+		t.Errorf("expected no selector, got %v", svc.Spec.Selector)
+	}
+}
+
+func TestEndpointSliceNilErrors(t *testing.T) {
+	assertPanics(t, func() { AddEndpointSliceEndpoint(nil, "10.0.0.1") })
+	assertPanics(t, func() { AddEndpointSlicePort(nil, "http", 80, corev1.ProtocolTCP) })
+}
+
+func TestCreateEndpointSlice(t *testing.T) {
+	slice := CreateEndpointSlice("db-1", "default", "db", discoveryv1.AddressTypeIPv4)
+	if slice.Kind != "EndpointSlice" || slice.APIVersion != "discovery.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", slice.APIVersion, slice.Kind)
+	}
+	if slice.Labels[discoveryv1.LabelServiceName] != "db" {
+		t.Errorf("expected service-name label, got %v", slice.Labels)
+	}
+	if slice.Labels[discoveryv1.LabelManagedBy] != EndpointSliceManagedBy {
+		t.Errorf("expected managed-by label, got %v", slice.Labels)
+	}
+
+	AddEndpointSliceEndpoint(slice, "10.0.0.1")
+	AddEndpointSlicePort(slice, "postgres", 5432, corev1.ProtocolTCP)
+	if len(slice.Endpoints) != 1 || slice.Endpoints[0].Addresses[0] != "10.0.0.1" {
+		t.Fatalf("endpoint not added: %+v", slice.Endpoints)
+	}
+	if r := slice.Endpoints[0].Conditions.Ready; r == nil || !*r {
+		t.Errorf("expected endpoint to be ready")
+	}
+	if len(slice.Ports) != 1 || *slice.Ports[0].Name != "postgres" || *slice.Ports[0].Port != 5432 {
+		t.Errorf("port not added: %+v", slice.Ports)
+	}
+}
+
+func TestCreateExternalService(t *testing.T) {
+	svc, slice := CreateExternalService("legacy-db", "apps", discoveryv1.AddressTypeIPv4,
+		[]string{"192.168.10.5", "192.168.10.6"},
+		corev1.ServicePort{Name: "postgres", Port: 5432},
+		corev1.ServicePort{Name: "metrics", Port: 80, TargetPort: intstr.FromInt32(9187)},
+	)
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone || len(svc.Spec.Ports) != 2 {
+		t.Fatalf("unexpected service spec %+v", svc.Spec)
+	}
+	if svc.Spec.Ports[0].Protocol != corev1.ProtocolTCP {
+		t.Errorf("expected default TCP protocol, got %q", svc.Spec.Ports[0].Protocol)
+	}
+	if slice.Name != "legacy-db" || slice.Namespace != "apps" || slice.Labels[discoveryv1.LabelServiceName] != "legacy-db" {
+		t.Errorf("slice not bound to service: %s/%s %v", slice.Namespace, slice.Name, slice.Labels)
+	}
+	if len(slice.Endpoints) != 2 {
+		t.Errorf("expected 2 endpoints, got %d", len(slice.Endpoints))
+	}
+	if len(slice.Ports) != 2 || *slice.Ports[0].Port != 5432 || *slice.Ports[1].Port != 9187 {
+		t.Errorf("unexpected slice ports %+v", slice.Ports)
+	}
+	if *slice.Ports[1].Name != "metrics" {
+		t.Errorf("slice port name must match the service port, got %q", *slice.Ports[1].Name)
+	}
+}
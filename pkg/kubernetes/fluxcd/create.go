@@ -1,15 +1,25 @@
 package fluxcd
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	fluxv1 "github.com/controlplaneio-fluxcd/flux-operator/api/v1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
+	notificationv1beta2 "github.com/fluxcd/notification-controller/api/v1beta2"
 	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
+	"github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourceWatcherv1beta1 "github.com/fluxcd/source-watcher/api/v2/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
 )
 
 // CreateGitRepository returns a new GitRepository with TypeMeta and ObjectMeta set.
@@ -152,6 +162,148 @@ func CreateReceiver(name, namespace string) *notificationv1.Receiver {
 	}
 }
 
+// NotificationAPIVersionsFor returns the notification-controller API versions
+// understood by the given Flux release, e.g. "v2.0.1" or "2.4". Flux 2.0
+// serves Alert and Provider as v1beta2; v1beta3 is used from Flux 2.1 on.
+// Receiver is v1 for every Flux 2 release.
+func NotificationAPIVersionsFor(fluxVersion string) (NotificationAPIVersions, error) {
+	legacy, err := servesNotificationV1Beta2(fluxVersion)
+	if err != nil {
+		return NotificationAPIVersions{}, err
+	}
+	versions := NotificationAPIVersions{
+		Alert:    notificationv1beta3.GroupVersion.String(),
+		Provider: notificationv1beta3.GroupVersion.String(),
+		Receiver: notificationv1.GroupVersion.String(),
+	}
+	if legacy {
+		versions.Alert = notificationv1beta2.GroupVersion.String()
+		versions.Provider = notificationv1beta2.GroupVersion.String()
+	}
+	return versions, nil
+}
+
+// servesNotificationV1Beta2 parses fluxVersion and reports whether the
+// release serves Alert and Provider only as v1beta2.
+func servesNotificationV1Beta2(fluxVersion string) (bool, error) {
+	parts := strings.SplitN(strings.TrimPrefix(fluxVersion, "v"), ".", 3)
+	if len(parts) < 2 {
+		return false, errors.Errorf("invalid Flux version %q: expected <major>.<minor>", fluxVersion)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid Flux version %q", fluxVersion)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid Flux version %q", fluxVersion)
+	}
+	if major != 2 {
+		return false, errors.Errorf("unsupported Flux version %q: only Flux 2 is supported", fluxVersion)
+	}
+	return minor == 0, nil
+}
+
+// CreateProviderForFlux returns a new Provider of the API version served by
+// the given Flux release: a *v1beta2.Provider for Flux 2.0 and a
+// *v1beta3.Provider, as returned by CreateProvider, from Flux 2.1 on.
+func CreateProviderForFlux(name, namespace, fluxVersion string) (client.Object, error) {
+	return ProviderForFlux(CreateProvider(name, namespace), fluxVersion)
+}
+
+// CreateAlertForFlux returns a new Alert of the API version served by the
+// given Flux release: a *v1beta2.Alert for Flux 2.0 and a *v1beta3.Alert, as
+// returned by CreateAlert, from Flux 2.1 on.
+func CreateAlertForFlux(name, namespace, fluxVersion string) (client.Object, error) {
+	return AlertForFlux(CreateAlert(name, namespace), fluxVersion)
+}
+
+// ProviderForFlux converts a Provider configured with the SetProvider*
+// helpers to the API version served by the given Flux release. From Flux 2.1
+// on provider is returned unchanged. For Flux 2.0 the result is a
+// *v1beta2.Provider; the v1beta3-only fields proxySecretRef,
+// serviceAccountName and commitStatusExpr are rejected because Flux 2.0
+// would drop them.
+func ProviderForFlux(provider *notificationv1beta3.Provider, fluxVersion string) (client.Object, error) {
+	if provider == nil {
+		return nil, errors.ErrNilObject
+	}
+	legacy, err := servesNotificationV1Beta2(fluxVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !legacy {
+		return provider, nil
+	}
+	spec := provider.Spec.DeepCopy()
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"proxySecretRef", spec.ProxySecretRef != nil},
+		{"serviceAccountName", spec.ServiceAccountName != ""},
+		{"commitStatusExpr", spec.CommitStatusExpr != ""},
+	} {
+		if f.set {
+			return nil, errors.ResourceValidationError("Provider", provider.Name, "spec."+f.name,
+				fmt.Sprintf("not supported by %s, which Flux %s serves", notificationv1beta2.GroupVersion, fluxVersion), nil)
+		}
+	}
+	return &notificationv1beta2.Provider{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       notificationv1beta2.ProviderKind,
+			APIVersion: notificationv1beta2.GroupVersion.String(),
+		},
+		ObjectMeta: *provider.ObjectMeta.DeepCopy(),
+		Spec: notificationv1beta2.ProviderSpec{
+			Type:          spec.Type,
+			Interval:      spec.Interval,
+			Channel:       spec.Channel,
+			Username:      spec.Username,
+			Address:       spec.Address,
+			Timeout:       spec.Timeout,
+			Proxy:         spec.Proxy,
+			SecretRef:     spec.SecretRef,
+			CertSecretRef: spec.CertSecretRef,
+			Suspend:       spec.Suspend,
+		},
+	}, nil
+}
+
+// AlertForFlux converts an Alert configured with the SetAlert* helpers to
+// the API version served by the given Flux release: alert itself from Flux
+// 2.1 on and a *v1beta2.Alert with the same spec for Flux 2.0.
+func AlertForFlux(alert *notificationv1beta3.Alert, fluxVersion string) (client.Object, error) {
+	if alert == nil {
+		return nil, errors.ErrNilObject
+	}
+	legacy, err := servesNotificationV1Beta2(fluxVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !legacy {
+		return alert, nil
+	}
+	spec := alert.Spec.DeepCopy()
+	return &notificationv1beta2.Alert{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       notificationv1beta2.AlertKind,
+			APIVersion: notificationv1beta2.GroupVersion.String(),
+		},
+		ObjectMeta: *alert.ObjectMeta.DeepCopy(),
+		Spec: notificationv1beta2.AlertSpec{
+			ProviderRef:   spec.ProviderRef,
+			EventSeverity: spec.EventSeverity,
+			EventSources:  spec.EventSources,
+			InclusionList: spec.InclusionList,
+			EventMetadata: spec.EventMetadata,
+			ExclusionList: spec.ExclusionList,
+			Summary:       spec.Summary,
+			Suspend:       spec.Suspend,
+		},
+	}, nil
+}
+
 // CreateImageUpdateAutomation returns a new ImageUpdateAutomation with TypeMeta and ObjectMeta set.
 func CreateImageUpdateAutomation(name, namespace string) *imagev1.ImageUpdateAutomation {
 	return &imagev1.ImageUpdateAutomation{
@@ -166,6 +318,34 @@ func CreateImageUpdateAutomation(name, namespace string) *imagev1.ImageUpdateAut
 	}
 }
 
+// CreateImageRepository returns a new ImageRepository with TypeMeta and ObjectMeta set.
+func CreateImageRepository(name, namespace string) *imagereflectv1.ImageRepository {
+	return &imagereflectv1.ImageRepository{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ImageRepository",
+			APIVersion: imagereflectv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+// CreateImagePolicy returns a new ImagePolicy with TypeMeta and ObjectMeta set.
+func CreateImagePolicy(name, namespace string) *imagereflectv1.ImagePolicy {
+	return &imagereflectv1.ImagePolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ImagePolicy",
+			APIVersion: imagereflectv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
 // CreateResourceSet returns a new ResourceSet with TypeMeta and ObjectMeta set.
 func CreateResourceSet(name, namespace string) *fluxv1.ResourceSet {
 	return &fluxv1.ResourceSet{
@@ -249,3 +429,34 @@ func CreateArtifactGenerator(name, namespace string) *sourceWatcherv1beta1.Artif
 		},
 	}
 }
+
+// Kustomization converts the config to a Flux Kustomization object.
+func Kustomization(cfg *KustomizationConfig) *kustv1.Kustomization {
+	if cfg == nil {
+		return nil
+	}
+	obj := CreateKustomization(cfg.Name, cfg.Namespace)
+	SetKustomizationPath(obj, cfg.Path)
+	SetKustomizationInterval(obj, cfg.Interval)
+	SetKustomizationPrune(obj, cfg.Prune)
+	if cfg.Wait {
+		SetKustomizationWait(obj, true)
+	}
+	SetKustomizationSourceRef(obj, kustv1.CrossNamespaceSourceReference{
+		Kind:      cfg.SourceRef.Kind,
+		Name:      cfg.SourceRef.Name,
+		Namespace: cfg.SourceRef.Namespace,
+	})
+	for _, dep := range cfg.DependsOn {
+		AddKustomizationDependsOn(obj, kustv1.DependencyReference{Name: dep.Name, Namespace: dep.Namespace})
+	}
+	for _, hc := range cfg.HealthChecks {
+		AddKustomizationHealthCheck(obj, meta.NamespacedObjectKindReference{
+			APIVersion: hc.APIVersion,
+			Kind:       hc.Kind,
+			Name:       hc.Name,
+			Namespace:  hc.Namespace,
+		})
+	}
+	return obj
+}
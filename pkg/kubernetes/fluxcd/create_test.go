@@ -2,11 +2,16 @@ package fluxcd
 
 import (
 	"testing"
+	"time"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
+	notificationv1beta2 "github.com/fluxcd/notification-controller/api/v1beta2"
+	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
+	"github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourceWatcherv1beta1 "github.com/fluxcd/source-watcher/api/v2/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -146,6 +151,32 @@ func TestCreateImageUpdateAutomation(t *testing.T) {
 	}
 }
 
+func TestCreateImageRepository(t *testing.T) {
+	obj := CreateImageRepository("podinfo", "flux-system")
+	if obj == nil {
+		t.Fatal("expected non-nil ImageRepository")
+	}
+	if obj.Name != "podinfo" || obj.Namespace != "flux-system" {
+		t.Errorf("unexpected metadata %s/%s", obj.Namespace, obj.Name)
+	}
+	if obj.Kind != "ImageRepository" || obj.APIVersion != imagereflectv1.GroupVersion.String() {
+		t.Errorf("unexpected TypeMeta %s %s", obj.APIVersion, obj.Kind)
+	}
+}
+
+func TestCreateImagePolicy(t *testing.T) {
+	obj := CreateImagePolicy("podinfo", "flux-system")
+	if obj == nil {
+		t.Fatal("expected non-nil ImagePolicy")
+	}
+	if obj.Name != "podinfo" || obj.Namespace != "flux-system" {
+		t.Errorf("unexpected metadata %s/%s", obj.Namespace, obj.Name)
+	}
+	if obj.Kind != "ImagePolicy" || obj.APIVersion != imagereflectv1.GroupVersion.String() {
+		t.Errorf("unexpected TypeMeta %s %s", obj.APIVersion, obj.Kind)
+	}
+}
+
 func TestCreateResourceSet(t *testing.T) {
 	obj := CreateResourceSet("test-resourceset", "flux-system")
 	if obj == nil {
@@ -159,6 +190,113 @@ func TestCreateResourceSet(t *testing.T) {
 	}
 }
 
+func TestNotificationAPIVersionsFor(t *testing.T) {
+	tests := []struct {
+		version string
+		alert   string
+	}{
+		{"v2.0.1", "notification.toolkit.fluxcd.io/v1beta2"},
+		{"2.1", "notification.toolkit.fluxcd.io/v1beta3"},
+		{"v2.6.4", "notification.toolkit.fluxcd.io/v1beta3"},
+	}
+	for _, tt := range tests {
+		versions, err := NotificationAPIVersionsFor(tt.version)
+		if err != nil {
+			t.Fatalf("NotificationAPIVersionsFor(%q): %v", tt.version, err)
+		}
+		if versions.Alert != tt.alert || versions.Provider != tt.alert {
+			t.Errorf("%s: unexpected Alert/Provider versions %+v", tt.version, versions)
+		}
+		if versions.Receiver != notificationv1.GroupVersion.String() {
+			t.Errorf("%s: unexpected Receiver version %q", tt.version, versions.Receiver)
+		}
+	}
+
+	for _, version := range []string{"", "latest", "v1.5.0", "v2.x"} {
+		if _, err := NotificationAPIVersionsFor(version); err == nil {
+			t.Errorf("expected error for version %q", version)
+		}
+	}
+}
+
+func TestCreateNotificationForFlux(t *testing.T) {
+	obj, err := CreateProviderForFlux("slack", "flux-system", "v2.0.0")
+	if err != nil {
+		t.Fatalf("CreateProviderForFlux: %v", err)
+	}
+	provider, ok := obj.(*notificationv1beta2.Provider)
+	if !ok {
+		t.Fatalf("expected a v1beta2 Provider for Flux 2.0, got %T", obj)
+	}
+	if provider.APIVersion != "notification.toolkit.fluxcd.io/v1beta2" || provider.Kind != "Provider" || provider.Name != "slack" {
+		t.Errorf("unexpected provider %s %s %s", provider.APIVersion, provider.Kind, provider.Name)
+	}
+	obj, err = CreateAlertForFlux("on-call", "flux-system", "v2.4.0")
+	if err != nil {
+		t.Fatalf("CreateAlertForFlux: %v", err)
+	}
+	if alert, ok := obj.(*notificationv1beta3.Alert); !ok || alert.APIVersion != "notification.toolkit.fluxcd.io/v1beta3" || alert.Kind != "Alert" {
+		t.Errorf("expected a v1beta3 Alert for Flux 2.4, got %#v", obj)
+	}
+	if _, err := CreateAlertForFlux("on-call", "flux-system", "bogus"); err == nil {
+		t.Error("expected error for an invalid Flux version")
+	}
+}
+
+func TestProviderForFlux(t *testing.T) {
+	provider := CreateProvider("slack", "flux-system")
+	SetProviderType(provider, "slack")
+	SetProviderChannel(provider, "alerts")
+	SetProviderSecretRef(provider, &meta.LocalObjectReference{Name: "slack-url"})
+
+	obj, err := ProviderForFlux(provider, "v2.0.1")
+	if err != nil {
+		t.Fatalf("ProviderForFlux: %v", err)
+	}
+	legacy, ok := obj.(*notificationv1beta2.Provider)
+	if !ok {
+		t.Fatalf("expected a v1beta2 Provider, got %T", obj)
+	}
+	if legacy.Name != "slack" || legacy.Spec.Type != "slack" || legacy.Spec.Channel != "alerts" || legacy.Spec.SecretRef == nil || legacy.Spec.SecretRef.Name != "slack-url" {
+		t.Errorf("unexpected v1beta2 provider %+v", legacy)
+	}
+
+	if obj, err := ProviderForFlux(provider, "v2.3.0"); err != nil || obj != provider {
+		t.Errorf("expected the provider unchanged for Flux 2.3, got %T, %v", obj, err)
+	}
+
+	provider.Spec.ServiceAccountName = "notifier"
+	if _, err := ProviderForFlux(provider, "v2.0.1"); err == nil {
+		t.Error("expected error for serviceAccountName on Flux 2.0")
+	}
+	if _, err := ProviderForFlux(nil, "v2.0.1"); err == nil {
+		t.Error("expected error for a nil provider")
+	}
+}
+
+func TestAlertForFlux(t *testing.T) {
+	alert := CreateAlert("on-call", "flux-system")
+	SetAlertProviderRef(alert, meta.LocalObjectReference{Name: "slack"})
+	SetAlertEventSeverity(alert, "error")
+	AddAlertEventSource(alert, notificationv1.CrossNamespaceObjectReference{Kind: "Kustomization", Name: "apps"})
+
+	obj, err := AlertForFlux(alert, "2.0")
+	if err != nil {
+		t.Fatalf("AlertForFlux: %v", err)
+	}
+	legacy, ok := obj.(*notificationv1beta2.Alert)
+	if !ok {
+		t.Fatalf("expected a v1beta2 Alert, got %T", obj)
+	}
+	if legacy.APIVersion != "notification.toolkit.fluxcd.io/v1beta2" || legacy.Spec.ProviderRef.Name != "slack" ||
+		legacy.Spec.EventSeverity != "error" || len(legacy.Spec.EventSources) != 1 {
+		t.Errorf("unexpected v1beta2 alert %+v", legacy)
+	}
+	if _, err := AlertForFlux(nil, "2.0"); err == nil {
+		t.Error("expected error for a nil alert")
+	}
+}
+
 func TestCreateResourceSetInputProvider(t *testing.T) {
 	obj := CreateResourceSetInputProvider("input-provider", "flux-system")
 	if obj == nil {
@@ -290,3 +428,45 @@ func TestCreateArtifactGenerator(t *testing.T) {
 		t.Errorf("expected APIVersion %q, got %q", sourceWatcherv1beta1.GroupVersion.String(), obj.APIVersion)
 	}
 }
+
+func TestKustomization(t *testing.T) {
+	cfg := &KustomizationConfig{
+		Name:      "apps",
+		Namespace: "flux-system",
+		Path:      "./clusters/prod/apps",
+		Interval:  metav1.Duration{Duration: 10 * time.Minute},
+		Prune:     true,
+		Wait:      true,
+		SourceRef: SourceReference{Kind: "GitRepository", Name: "fleet"},
+		DependsOn: []DependencyReference{{Name: "infrastructure"}, {Name: "crds", Namespace: "platform"}},
+		HealthChecks: []HealthCheckReference{
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: "podinfo", Namespace: "apps"},
+		},
+	}
+	obj := Kustomization(cfg)
+	if obj == nil {
+		t.Fatal("expected non-nil Kustomization")
+	}
+	if obj.Name != "apps" || obj.Namespace != "flux-system" || obj.Kind != kustv1.KustomizationKind {
+		t.Errorf("unexpected object %s %s/%s", obj.Kind, obj.Namespace, obj.Name)
+	}
+	spec := obj.Spec
+	if spec.Path != "./clusters/prod/apps" || spec.Interval.Duration != 10*time.Minute || !spec.Prune || !spec.Wait {
+		t.Errorf("unexpected spec %+v", spec)
+	}
+	if spec.SourceRef.Kind != "GitRepository" || spec.SourceRef.Name != "fleet" {
+		t.Errorf("unexpected sourceRef %+v", spec.SourceRef)
+	}
+	if len(spec.DependsOn) != 2 || spec.DependsOn[1].Namespace != "platform" {
+		t.Errorf("unexpected dependsOn %+v", spec.DependsOn)
+	}
+	if len(spec.HealthChecks) != 1 || spec.HealthChecks[0].Kind != "Deployment" || spec.HealthChecks[0].Namespace != "apps" {
+		t.Errorf("unexpected healthChecks %+v", spec.HealthChecks)
+	}
+}
+
+func TestKustomizationNilConfig(t *testing.T) {
+	if obj := Kustomization(nil); obj != nil {
+		t.Errorf("expected nil Kustomization, got %+v", obj)
+	}
+}
@@ -15,9 +15,9 @@
 // Applications covered include sources (GitRepository, OCIRepository,
 // HelmRepository, Bucket, ExternalArtifact, ArtifactGenerator), workloads
 // (Kustomization, HelmRelease), the notification stack (Provider, Alert,
-// Receiver), image automation (ImageUpdateAutomation), and objects from the
-// Flux operator (FluxInstance, FluxReport, ResourceSet,
-// ResourceSetInputProvider).
+// Receiver), image automation (ImageRepository, ImagePolicy,
+// ImageUpdateAutomation), and objects from the Flux operator (FluxInstance,
+// FluxReport, ResourceSet, ResourceSetInputProvider).
 //
 // # Constructors
 //
@@ -37,6 +37,17 @@
 //	fluxcd.SetKustomizationPath(ks, "./deploy")
 //	fluxcd.SetKustomizationPrune(ks, true)
 //
+// A Kustomization can also be built from a KustomizationConfig, which uses
+// plain types for the common spec fields:
+//
+//	ks := fluxcd.Kustomization(&fluxcd.KustomizationConfig{
+//	        Name:      "app",
+//	        Namespace: "flux-system",
+//	        Path:      "./deploy",
+//	        Prune:     true,
+//	        SourceRef: fluxcd.SourceReference{Kind: "GitRepository", Name: "app-repo"},
+//	})
+//
 // # Update helpers
 //
 // Additional functions prefixed with Set or Add expose granular control over
@@ -6,6 +6,7 @@ import (
 	fluxv1 "github.com/controlplaneio-fluxcd/flux-operator/api/v1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
 	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
@@ -16,6 +17,8 @@ import (
 	sourceWatcherv1beta1 "github.com/fluxcd/source-watcher/api/v2/v1beta1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/go-kure/kure/pkg/errors"
 )
 
 // GitRepository setters
@@ -544,6 +547,70 @@ func AddKustomizationPatch(k *kustv1.Kustomization, patch kustomize.Patch) {
 	k.Spec.Patches = append(k.Spec.Patches, patch)
 }
 
+// SetKustomizationImages replaces the image transformations.
+func SetKustomizationImages(k *kustv1.Kustomization, images []kustomize.Image) {
+	k.Spec.Images = images
+}
+
+// CreateKustomizeImage returns an image transformation that replaces the
+// name, tag or digest of the images named name. Empty values are left
+// unchanged.
+func CreateKustomizeImage(name, newName, newTag, digest string) kustomize.Image {
+	return kustomize.Image{Name: name, NewName: newName, NewTag: newTag, Digest: digest}
+}
+
+// CreatePatchTarget returns a patch target selecting resources by kind, name
+// and namespace. Empty values match any resource.
+func CreatePatchTarget(kind, name, namespace string) *kustomize.Selector {
+	return &kustomize.Selector{Kind: kind, Name: name, Namespace: namespace}
+}
+
+// SetPatchTargetGroupVersion restricts the target to an API group and version.
+func SetPatchTargetGroupVersion(sel *kustomize.Selector, group, version string) {
+	sel.Group = group
+	sel.Version = version
+}
+
+// SetPatchTargetLabelSelector restricts the target to resources matching the
+// label selector, e.g. "app=frontend".
+func SetPatchTargetLabelSelector(sel *kustomize.Selector, selector string) {
+	sel.LabelSelector = selector
+}
+
+// SetPatchTargetAnnotationSelector restricts the target to resources
+// matching the annotation selector.
+func SetPatchTargetAnnotationSelector(sel *kustomize.Selector, selector string) {
+	sel.AnnotationSelector = selector
+}
+
+// CreateStrategicMergePatch returns a strategic merge patch. target may be
+// nil when the patch itself identifies the resource by apiVersion, kind and
+// metadata.name.
+func CreateStrategicMergePatch(patch string, target *kustomize.Selector) kustomize.Patch {
+	return kustomize.Patch{Patch: patch, Target: target}
+}
+
+// CreateJSON6902Patch returns a JSON 6902 patch applying ops to the
+// resources selected by target, which is required.
+func CreateJSON6902Patch(target *kustomize.Selector, ops ...JSON6902Operation) (kustomize.Patch, error) {
+	if target == nil {
+		return kustomize.Patch{}, errors.New("a JSON 6902 patch requires a target")
+	}
+	for _, op := range ops {
+		switch op.Op {
+		case "add", "remove", "replace", "move", "copy", "test":
+		default:
+			return kustomize.Patch{}, errors.NewValidationError("op", op.Op, "JSON6902Operation",
+				[]string{"add", "remove", "replace", "move", "copy", "test"})
+		}
+	}
+	raw, err := json.Marshal(ops)
+	if err != nil {
+		return kustomize.Patch{}, err
+	}
+	return kustomize.Patch{Patch: string(raw), Target: target}, nil
+}
+
 // SetKustomizationNamePrefix sets the name prefix.
 func SetKustomizationNamePrefix(k *kustv1.Kustomization, prefix string) {
 	k.Spec.NamePrefix = prefix
@@ -656,6 +723,13 @@ func SetHelmReleaseKubeConfig(obj *helmv2.HelmRelease, cfg *meta.KubeConfigRefer
 	obj.Spec.KubeConfig = cfg
 }
 
+// CreateKubeConfigReference returns a KubeConfig reference to the given key
+// of a Secret. An empty key lets the controller fall back to "value" or
+// "value.yaml".
+func CreateKubeConfigReference(secretName, key string) *meta.KubeConfigReference {
+	return &meta.KubeConfigReference{SecretRef: &meta.SecretKeyReference{Name: secretName, Key: key}}
+}
+
 // SetHelmReleaseSuspend configures the suspend flag.
 func SetHelmReleaseSuspend(obj *helmv2.HelmRelease, suspend bool) {
 	obj.Spec.Suspend = suspend
@@ -751,6 +825,19 @@ func AddHelmReleaseValuesFrom(obj *helmv2.HelmRelease, ref helmv2.ValuesReferenc
 	obj.Spec.ValuesFrom = append(obj.Spec.ValuesFrom, ref)
 }
 
+// CreateValuesReference returns a valuesFrom reference to a ConfigMap or
+// Secret. An empty valuesKey selects "values.yaml"; a non-empty targetPath
+// merges the single value at that path instead of the whole document.
+func CreateValuesReference(kind, name, valuesKey, targetPath string, optional bool) helmv2.ValuesReference {
+	return helmv2.ValuesReference{
+		Kind:       kind,
+		Name:       name,
+		ValuesKey:  valuesKey,
+		TargetPath: targetPath,
+		Optional:   optional,
+	}
+}
+
 // SetHelmReleaseValues sets the values for the release.
 func SetHelmReleaseValues(obj *helmv2.HelmRelease, values *apiextensionsv1.JSON) {
 	obj.Spec.Values = values
@@ -1337,6 +1424,107 @@ func SetObservedPolicies(auto *imagev1.ImageUpdateAutomation, policies imagev1.O
 	auto.Status.ObservedPolicies = policies
 }
 
+// ImageRepository setters
+
+// SetImageRepositoryImage sets the image to scan, without a tag.
+func SetImageRepositoryImage(repo *imagereflectv1.ImageRepository, image string) {
+	repo.Spec.Image = image
+}
+
+// SetImageRepositoryInterval sets the scan interval.
+func SetImageRepositoryInterval(repo *imagereflectv1.ImageRepository, interval metav1.Duration) {
+	repo.Spec.Interval = interval
+}
+
+// SetImageRepositoryTimeout sets the timeout for scanning the registry.
+func SetImageRepositoryTimeout(repo *imagereflectv1.ImageRepository, timeout *metav1.Duration) {
+	repo.Spec.Timeout = timeout
+}
+
+// SetImageRepositorySecretRef sets the secret holding the registry credentials.
+func SetImageRepositorySecretRef(repo *imagereflectv1.ImageRepository, ref *meta.LocalObjectReference) {
+	repo.Spec.SecretRef = ref
+}
+
+// SetImageRepositoryServiceAccountName sets the service account whose image
+// pull secrets are used for authentication.
+func SetImageRepositoryServiceAccountName(repo *imagereflectv1.ImageRepository, name string) {
+	repo.Spec.ServiceAccountName = name
+}
+
+// SetImageRepositoryCertSecretRef sets the secret holding the TLS certificates.
+func SetImageRepositoryCertSecretRef(repo *imagereflectv1.ImageRepository, ref *meta.LocalObjectReference) {
+	repo.Spec.CertSecretRef = ref
+}
+
+// SetImageRepositoryProvider sets the cloud provider used for authentication.
+func SetImageRepositoryProvider(repo *imagereflectv1.ImageRepository, provider string) {
+	repo.Spec.Provider = provider
+}
+
+// AddImageRepositoryExclusion appends a regular expression for tags to exclude.
+func AddImageRepositoryExclusion(repo *imagereflectv1.ImageRepository, pattern string) {
+	repo.Spec.ExclusionList = append(repo.Spec.ExclusionList, pattern)
+}
+
+// SetImageRepositoryInsecure allows connecting to a registry over plain HTTP.
+func SetImageRepositoryInsecure(repo *imagereflectv1.ImageRepository, insecure bool) {
+	repo.Spec.Insecure = insecure
+}
+
+// SetImageRepositoryAccessFrom sets the namespaces allowed to reference the repository.
+func SetImageRepositoryAccessFrom(repo *imagereflectv1.ImageRepository, accessFrom *acl.AccessFrom) {
+	repo.Spec.AccessFrom = accessFrom
+}
+
+// SetImageRepositorySuspend sets the suspend flag.
+func SetImageRepositorySuspend(repo *imagereflectv1.ImageRepository, suspend bool) {
+	repo.Spec.Suspend = suspend
+}
+
+// ImagePolicy setters
+
+// SetImagePolicyImageRepositoryRef sets the ImageRepository the policy selects tags from.
+func SetImagePolicyImageRepositoryRef(policy *imagereflectv1.ImagePolicy, ref meta.NamespacedObjectReference) {
+	policy.Spec.ImageRepositoryRef = ref
+}
+
+// SetImagePolicySemVer selects the highest tag within the semver range.
+// It replaces any other policy choice.
+func SetImagePolicySemVer(policy *imagereflectv1.ImagePolicy, semverRange string) {
+	policy.Spec.Policy = imagereflectv1.ImagePolicyChoice{
+		SemVer: &imagereflectv1.SemVerPolicy{Range: semverRange},
+	}
+}
+
+// SetImagePolicyAlphabetical selects tags in alphabetical order, "asc" or
+// "desc". It replaces any other policy choice.
+func SetImagePolicyAlphabetical(policy *imagereflectv1.ImagePolicy, order string) {
+	policy.Spec.Policy = imagereflectv1.ImagePolicyChoice{
+		Alphabetical: &imagereflectv1.AlphabeticalPolicy{Order: order},
+	}
+}
+
+// SetImagePolicyNumerical selects tags in numerical order, "asc" or "desc".
+// It replaces any other policy choice.
+func SetImagePolicyNumerical(policy *imagereflectv1.ImagePolicy, order string) {
+	policy.Spec.Policy = imagereflectv1.ImagePolicyChoice{
+		Numerical: &imagereflectv1.NumericalPolicy{Order: order},
+	}
+}
+
+// SetImagePolicyFilterTags filters the tags before the policy is applied.
+// extract optionally names the capture group used as the policy input.
+func SetImagePolicyFilterTags(policy *imagereflectv1.ImagePolicy, pattern, extract string) {
+	policy.Spec.FilterTags = &imagereflectv1.TagFilter{Pattern: pattern, Extract: extract}
+}
+
+// SetImagePolicyDigestReflectionPolicy sets when the digest of the latest
+// image is looked up.
+func SetImagePolicyDigestReflectionPolicy(policy *imagereflectv1.ImagePolicy, reflection imagereflectv1.ReflectionPolicy) {
+	policy.Spec.DigestReflectionPolicy = reflection
+}
+
 // ResourceSet setters
 
 // AddResourceSetInput appends an input to the ResourceSet.
@@ -8,6 +8,7 @@ import (
 	fluxv1 "github.com/controlplaneio-fluxcd/flux-operator/api/v1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
 	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
@@ -900,6 +901,60 @@ func TestAddKustomizationPatch(t *testing.T) {
 	}
 }
 
+func TestSetKustomizationImages(t *testing.T) {
+	obj := CreateKustomization("ks", "ns")
+	AddKustomizationImage(obj, kustomize.Image{Name: "old"})
+	SetKustomizationImages(obj, []kustomize.Image{
+		CreateKustomizeImage("nginx", "registry.example.com/nginx", "1.27", ""),
+		CreateKustomizeImage("redis", "", "", "sha256:abc"),
+	})
+	if len(obj.Spec.Images) != 2 || obj.Spec.Images[0].NewName != "registry.example.com/nginx" || obj.Spec.Images[1].Digest != "sha256:abc" {
+		t.Errorf("unexpected images %+v", obj.Spec.Images)
+	}
+}
+
+func TestCreateStrategicMergePatch(t *testing.T) {
+	target := CreatePatchTarget("Deployment", "", "apps")
+	SetPatchTargetGroupVersion(target, "apps", "v1")
+	SetPatchTargetLabelSelector(target, "tier=frontend")
+	SetPatchTargetAnnotationSelector(target, "kure.dev/patch=true")
+
+	obj := CreateKustomization("ks", "ns")
+	AddKustomizationPatch(obj, CreateStrategicMergePatch("spec:\n  replicas: 3\n", target))
+	if len(obj.Spec.Patches) != 1 {
+		t.Fatal("Patch not appended")
+	}
+	got := obj.Spec.Patches[0].Target
+	if got == nil || got.Group != "apps" || got.Version != "v1" || got.Kind != "Deployment" ||
+		got.Namespace != "apps" || got.LabelSelector != "tier=frontend" || got.AnnotationSelector != "kure.dev/patch=true" {
+		t.Errorf("unexpected target %+v", got)
+	}
+}
+
+func TestCreateJSON6902Patch(t *testing.T) {
+	patch, err := CreateJSON6902Patch(CreatePatchTarget("Deployment", "web", ""),
+		JSON6902Operation{Op: "replace", Path: "/spec/replicas", Value: 2},
+		JSON6902Operation{Op: "remove", Path: "/metadata/labels/debug"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `[{"op":"replace","path":"/spec/replicas","value":2},{"op":"remove","path":"/metadata/labels/debug"}]`
+	if patch.Patch != want {
+		t.Errorf("got patch %s, want %s", patch.Patch, want)
+	}
+	if patch.Target == nil || patch.Target.Name != "web" {
+		t.Errorf("unexpected target %+v", patch.Target)
+	}
+
+	if _, err := CreateJSON6902Patch(nil, JSON6902Operation{Op: "remove", Path: "/spec"}); err == nil {
+		t.Error("expected error for missing target")
+	}
+	if _, err := CreateJSON6902Patch(CreatePatchTarget("Deployment", "", ""), JSON6902Operation{Op: "merge", Path: "/spec"}); err == nil {
+		t.Error("expected error for unknown operation")
+	}
+}
+
 func TestSetKustomizationNamePrefix(t *testing.T) {
 	obj := CreateKustomization("ks", "ns")
 	SetKustomizationNamePrefix(obj, "prod-")
@@ -1167,6 +1222,18 @@ func TestSetHelmReleaseKubeConfig(t *testing.T) {
 	}
 }
 
+func TestCreateKubeConfigReference(t *testing.T) {
+	obj := CreateHelmRelease("hr", "ns")
+	SetHelmReleaseKubeConfig(obj, CreateKubeConfigReference("remote-kubeconfig", "value.yaml"))
+	cfg := obj.Spec.KubeConfig
+	if cfg == nil || cfg.SecretRef == nil {
+		t.Fatal("KubeConfig secretRef not set")
+	}
+	if cfg.SecretRef.Name != "remote-kubeconfig" || cfg.SecretRef.Key != "value.yaml" {
+		t.Errorf("unexpected secretRef %+v", cfg.SecretRef)
+	}
+}
+
 func TestSetHelmReleaseSuspend(t *testing.T) {
 	obj := CreateHelmRelease("hr", "ns")
 	SetHelmReleaseSuspend(obj, true)
@@ -1330,6 +1397,19 @@ func TestAddHelmReleaseValuesFrom(t *testing.T) {
 	}
 }
 
+func TestCreateValuesReference(t *testing.T) {
+	obj := CreateHelmRelease("hr", "ns")
+	AddHelmReleaseValuesFrom(obj, CreateValuesReference("Secret", "db", "password", "database.password", true))
+	if len(obj.Spec.ValuesFrom) != 1 {
+		t.Fatalf("expected 1 valuesFrom entry, got %d", len(obj.Spec.ValuesFrom))
+	}
+	ref := obj.Spec.ValuesFrom[0]
+	if ref.Kind != "Secret" || ref.Name != "db" || ref.ValuesKey != "password" ||
+		ref.TargetPath != "database.password" || !ref.Optional {
+		t.Errorf("unexpected valuesFrom reference %+v", ref)
+	}
+}
+
 func TestSetHelmReleaseValues(t *testing.T) {
 	obj := CreateHelmRelease("hr", "ns")
 	values := &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)}
@@ -2031,6 +2111,101 @@ func TestSetImageUpdateAutomationSuspend(t *testing.T) {
 	}
 }
 
+// ImageRepository setters
+
+func TestImageRepositorySetters(t *testing.T) {
+	obj := CreateImageRepository("podinfo", "flux-system")
+	interval := metav1.Duration{Duration: 5 * time.Minute}
+	timeout := &metav1.Duration{Duration: time.Minute}
+	secret := &meta.LocalObjectReference{Name: "registry-auth"}
+	cert := &meta.LocalObjectReference{Name: "registry-ca"}
+	access := &acl.AccessFrom{NamespaceSelectors: []acl.NamespaceSelector{{MatchLabels: map[string]string{"team": "a"}}}}
+
+	SetImageRepositoryImage(obj, "ghcr.io/stefanprodan/podinfo")
+	SetImageRepositoryInterval(obj, interval)
+	SetImageRepositoryTimeout(obj, timeout)
+	SetImageRepositorySecretRef(obj, secret)
+	SetImageRepositoryServiceAccountName(obj, "image-scanner")
+	SetImageRepositoryCertSecretRef(obj, cert)
+	SetImageRepositoryProvider(obj, "generic")
+	AddImageRepositoryExclusion(obj, "^.*\\.sig$")
+	AddImageRepositoryExclusion(obj, "^dev-")
+	SetImageRepositoryInsecure(obj, true)
+	SetImageRepositoryAccessFrom(obj, access)
+	SetImageRepositorySuspend(obj, true)
+
+	spec := obj.Spec
+	if spec.Image != "ghcr.io/stefanprodan/podinfo" {
+		t.Errorf("got Image %q", spec.Image)
+	}
+	if spec.Interval != interval || spec.Timeout != timeout {
+		t.Error("Interval or Timeout not set")
+	}
+	if spec.SecretRef != secret || spec.CertSecretRef != cert || spec.AccessFrom != access {
+		t.Error("references not set")
+	}
+	if spec.ServiceAccountName != "image-scanner" || spec.Provider != "generic" {
+		t.Errorf("got ServiceAccountName %q, Provider %q", spec.ServiceAccountName, spec.Provider)
+	}
+	if len(spec.ExclusionList) != 2 || spec.ExclusionList[1] != "^dev-" {
+		t.Errorf("got ExclusionList %v", spec.ExclusionList)
+	}
+	if !spec.Insecure || !spec.Suspend {
+		t.Error("Insecure or Suspend not set")
+	}
+}
+
+// ImagePolicy setters
+
+func TestSetImagePolicyImageRepositoryRef(t *testing.T) {
+	obj := CreateImagePolicy("podinfo", "flux-system")
+	SetImagePolicyImageRepositoryRef(obj, meta.NamespacedObjectReference{Name: "podinfo", Namespace: "images"})
+	if obj.Spec.ImageRepositoryRef.Name != "podinfo" || obj.Spec.ImageRepositoryRef.Namespace != "images" {
+		t.Errorf("got ImageRepositoryRef %+v", obj.Spec.ImageRepositoryRef)
+	}
+}
+
+func TestSetImagePolicyChoice(t *testing.T) {
+	obj := CreateImagePolicy("podinfo", "flux-system")
+
+	SetImagePolicySemVer(obj, ">=1.0.0 <2.0.0")
+	if obj.Spec.Policy.SemVer == nil || obj.Spec.Policy.SemVer.Range != ">=1.0.0 <2.0.0" {
+		t.Errorf("got SemVer %+v", obj.Spec.Policy.SemVer)
+	}
+
+	SetImagePolicyAlphabetical(obj, "asc")
+	if obj.Spec.Policy.SemVer != nil {
+		t.Error("expected the alphabetical policy to replace semver")
+	}
+	if obj.Spec.Policy.Alphabetical == nil || obj.Spec.Policy.Alphabetical.Order != "asc" {
+		t.Errorf("got Alphabetical %+v", obj.Spec.Policy.Alphabetical)
+	}
+
+	SetImagePolicyNumerical(obj, "desc")
+	if obj.Spec.Policy.Alphabetical != nil {
+		t.Error("expected the numerical policy to replace alphabetical")
+	}
+	if obj.Spec.Policy.Numerical == nil || obj.Spec.Policy.Numerical.Order != "desc" {
+		t.Errorf("got Numerical %+v", obj.Spec.Policy.Numerical)
+	}
+}
+
+func TestSetImagePolicyFilterTags(t *testing.T) {
+	obj := CreateImagePolicy("podinfo", "flux-system")
+	SetImagePolicyFilterTags(obj, `^main-[a-f0-9]+-(?P<ts>[0-9]+)`, "$ts")
+	if obj.Spec.FilterTags == nil || obj.Spec.FilterTags.Extract != "$ts" {
+		t.Errorf("got FilterTags %+v", obj.Spec.FilterTags)
+	}
+}
+
+func TestSetImagePolicyDigestReflectionPolicy(t *testing.T) {
+	obj := CreateImagePolicy("podinfo", "flux-system")
+	SetImagePolicyDigestReflectionPolicy(obj, imagereflectv1.ReflectAlways)
+	if obj.Spec.DigestReflectionPolicy != imagereflectv1.ReflectAlways {
+		t.Errorf("got DigestReflectionPolicy %q", obj.Spec.DigestReflectionPolicy)
+	}
+}
+
 func TestCreateCrossNamespaceSourceReference(t *testing.T) {
 	ref := CreateCrossNamespaceSourceReference("v1", "GitRepository", "repo", "flux-system")
 	if ref.APIVersion != "v1" || ref.Kind != "GitRepository" || ref.Name != "repo" || ref.Namespace != "flux-system" {
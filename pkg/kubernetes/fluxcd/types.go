@@ -0,0 +1,63 @@
+package fluxcd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KustomizationConfig describes a Flux Kustomization. It covers the commonly
+// used spec fields with plain types; use the Set*/Add* helpers on the
+// returned object for the rest.
+type KustomizationConfig struct {
+	Name         string                 `yaml:"name"`
+	Namespace    string                 `yaml:"namespace"`
+	Path         string                 `yaml:"path,omitempty"`
+	Interval     metav1.Duration        `yaml:"interval"`
+	Prune        bool                   `yaml:"prune"`
+	Wait         bool                   `yaml:"wait,omitempty"`
+	SourceRef    SourceReference        `yaml:"sourceRef"`
+	DependsOn    []DependencyReference  `yaml:"dependsOn,omitempty"`
+	HealthChecks []HealthCheckReference `yaml:"healthChecks,omitempty"`
+}
+
+// SourceReference identifies the Flux source of a Kustomization, e.g. a
+// GitRepository or OCIRepository. An empty Namespace refers to the
+// Kustomization's namespace.
+type SourceReference struct {
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// DependencyReference names a Kustomization that must be ready first. An
+// empty Namespace refers to the dependent Kustomization's namespace.
+type DependencyReference struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// HealthCheckReference names a workload whose readiness is checked after
+// the Kustomization is applied.
+type HealthCheckReference struct {
+	APIVersion string `yaml:"apiVersion,omitempty"`
+	Kind       string `yaml:"kind"`
+	Name       string `yaml:"name"`
+	Namespace  string `yaml:"namespace,omitempty"`
+}
+
+// JSON6902Operation is a single RFC 6902 JSON patch operation, used with
+// CreateJSON6902Patch. Op is one of add, remove, replace, move, copy or test;
+// From is only used by move and copy.
+type JSON6902Operation struct {
+	Op    string `json:"op" yaml:"op"`
+	Path  string `json:"path" yaml:"path"`
+	From  string `json:"from,omitempty" yaml:"from,omitempty"`
+	Value any    `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// NotificationAPIVersions holds the apiVersion emitted for each
+// notification-controller kind. See NotificationAPIVersionsFor.
+type NotificationAPIVersions struct {
+	Alert    string
+	Provider string
+	Receiver string
+}
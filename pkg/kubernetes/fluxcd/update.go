@@ -4,6 +4,7 @@ import (
 	fluxv1 "github.com/controlplaneio-fluxcd/flux-operator/api/v1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
 	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
@@ -76,6 +77,16 @@ func SetImageUpdateAutomationSpec(obj *imagev1.ImageUpdateAutomation, spec image
 	obj.Spec = spec
 }
 
+// SetImageRepositorySpec replaces the spec on the ImageRepository object.
+func SetImageRepositorySpec(obj *imagereflectv1.ImageRepository, spec imagereflectv1.ImageRepositorySpec) {
+	obj.Spec = spec
+}
+
+// SetImagePolicySpec replaces the spec on the ImagePolicy object.
+func SetImagePolicySpec(obj *imagereflectv1.ImagePolicy, spec imagereflectv1.ImagePolicySpec) {
+	obj.Spec = spec
+}
+
 // SetResourceSetSpec replaces the spec on the ResourceSet object.
 func SetResourceSetSpec(obj *fluxv1.ResourceSet, spec fluxv1.ResourceSetSpec) {
 	obj.Spec = spec
@@ -7,6 +7,7 @@ import (
 	fluxv1 "github.com/controlplaneio-fluxcd/flux-operator/api/v1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
 	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
@@ -318,6 +319,30 @@ func TestSetImageUpdateAutomationSpec(t *testing.T) {
 	}
 }
 
+func TestSetImageRepositorySpec(t *testing.T) {
+	repo := CreateImageRepository("podinfo", "flux-system")
+	SetImageRepositorySpec(repo, imagereflectv1.ImageRepositorySpec{
+		Image:    "ghcr.io/stefanprodan/podinfo",
+		Interval: metav1.Duration{Duration: 5 * time.Minute},
+	})
+	if repo.Spec.Image != "ghcr.io/stefanprodan/podinfo" {
+		t.Errorf("expected Image 'ghcr.io/stefanprodan/podinfo', got %s", repo.Spec.Image)
+	}
+}
+
+func TestSetImagePolicySpec(t *testing.T) {
+	policy := CreateImagePolicy("podinfo", "flux-system")
+	SetImagePolicySpec(policy, imagereflectv1.ImagePolicySpec{
+		ImageRepositoryRef: meta.NamespacedObjectReference{Name: "podinfo"},
+		Policy: imagereflectv1.ImagePolicyChoice{
+			SemVer: &imagereflectv1.SemVerPolicy{Range: "5.x"},
+		},
+	})
+	if policy.Spec.ImageRepositoryRef.Name != "podinfo" || policy.Spec.Policy.SemVer == nil {
+		t.Errorf("unexpected spec %+v", policy.Spec)
+	}
+}
+
 func TestSetResourceSetSpec(t *testing.T) {
 	resourceSet := CreateResourceSet("test-resourceset", "flux-system")
 
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// CreateGateway returns a Gateway of the given GatewayClass with default
+// labels, annotations, and an empty listener slice.
+func CreateGateway(name, namespace, className string) *gwapiv1.Gateway {
+	return &gwapiv1.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Gateway",
+			APIVersion: gwapiv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+		Spec: gwapiv1.GatewaySpec{
+			GatewayClassName: gwapiv1.ObjectName(className),
+			Listeners:        []gwapiv1.Listener{},
+		},
+	}
+}
+
+// CreateGatewayListener returns a listener accepting the given protocol on
+// port. Routes from the Gateway's namespace are allowed by default.
+func CreateGatewayListener(name string, protocol gwapiv1.ProtocolType, port int32) gwapiv1.Listener {
+	return gwapiv1.Listener{
+		Name:     gwapiv1.SectionName(name),
+		Protocol: protocol,
+		Port:     gwapiv1.PortNumber(port),
+	}
+}
+
+// SetGatewayClassName sets the GatewayClass of the Gateway.
+func SetGatewayClassName(gw *gwapiv1.Gateway, className string) {
+	if gw == nil {
+		panic("SetGatewayClassName: gw must not be nil")
+	}
+	gw.Spec.GatewayClassName = gwapiv1.ObjectName(className)
+}
+
+// AddGatewayListener appends a listener to the Gateway.
+func AddGatewayListener(gw *gwapiv1.Gateway, listener gwapiv1.Listener) {
+	if gw == nil {
+		panic("AddGatewayListener: gw must not be nil")
+	}
+	gw.Spec.Listeners = append(gw.Spec.Listeners, listener)
+}
+
+// SetGatewayListeners replaces all listeners on the Gateway.
+func SetGatewayListeners(gw *gwapiv1.Gateway, listeners []gwapiv1.Listener) {
+	if gw == nil {
+		panic("SetGatewayListeners: gw must not be nil")
+	}
+	gw.Spec.Listeners = listeners
+}
+
+// SetGatewayListenerHostname restricts a listener to the given hostname.
+func SetGatewayListenerHostname(listener *gwapiv1.Listener, hostname gwapiv1.Hostname) {
+	listener.Hostname = &hostname
+}
+
+// SetGatewayListenerAllowedRoutes sets which routes may attach to a listener.
+func SetGatewayListenerAllowedRoutes(listener *gwapiv1.Listener, allowed *gwapiv1.AllowedRoutes) {
+	listener.AllowedRoutes = allowed
+}
+
+// AddGatewayAddress appends a requested address to the Gateway.
+func AddGatewayAddress(gw *gwapiv1.Gateway, addr gwapiv1.GatewaySpecAddress) {
+	if gw == nil {
+		panic("AddGatewayAddress: gw must not be nil")
+	}
+	gw.Spec.Addresses = append(gw.Spec.Addresses, addr)
+}
+
+// SetGatewayInfrastructure sets the labels and annotations propagated to the
+// resources generated for the Gateway.
+func SetGatewayInfrastructure(gw *gwapiv1.Gateway, infra *gwapiv1.GatewayInfrastructure) {
+	if gw == nil {
+		panic("SetGatewayInfrastructure: gw must not be nil")
+	}
+	gw.Spec.Infrastructure = infra
+}
@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"testing"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestCreateGateway(t *testing.T) {
+	gw := CreateGateway("edge", "infra", "cilium")
+	if gw.Name != "edge" || gw.Namespace != "infra" {
+		t.Fatalf("metadata mismatch: %s/%s", gw.Namespace, gw.Name)
+	}
+	if gw.Kind != "Gateway" || gw.APIVersion != gwapiv1.GroupVersion.String() {
+		t.Errorf("unexpected type %s %s", gw.APIVersion, gw.Kind)
+	}
+	if gw.Spec.GatewayClassName != "cilium" {
+		t.Errorf("unexpected class %q", gw.Spec.GatewayClassName)
+	}
+	if gw.Labels["app"] != "edge" {
+		t.Errorf("expected label app=edge, got %v", gw.Labels)
+	}
+	if len(gw.Spec.Listeners) != 0 {
+		t.Errorf("expected empty listeners, got %v", gw.Spec.Listeners)
+	}
+}
+
+func TestGatewayNilErrors(t *testing.T) {
+	assertPanics(t, func() { SetGatewayClassName(nil, "cilium") })
+	assertPanics(t, func() { AddGatewayListener(nil, gwapiv1.Listener{}) })
+	assertPanics(t, func() { SetGatewayListeners(nil, nil) })
+	assertPanics(t, func() { AddGatewayAddress(nil, gwapiv1.GatewaySpecAddress{}) })
+	assertPanics(t, func() { SetGatewayInfrastructure(nil, nil) })
+}
+
+func TestGatewayFunctions(t *testing.T) {
+	gw := CreateGateway("edge", "infra", "cilium")
+
+	SetGatewayClassName(gw, "istio")
+	if gw.Spec.GatewayClassName != "istio" {
+		t.Errorf("class name not set")
+	}
+
+	listener := CreateGatewayListener("https", gwapiv1.HTTPSProtocolType, 443)
+	SetGatewayListenerHostname(&listener, "*.example.com")
+	from := gwapiv1.NamespacesFromAll
+	SetGatewayListenerAllowedRoutes(&listener, &gwapiv1.AllowedRoutes{
+		Namespaces: &gwapiv1.RouteNamespaces{From: &from},
+	})
+	AddGatewayListener(gw, listener)
+	if len(gw.Spec.Listeners) != 1 {
+		t.Fatalf("expected 1 listener, got %d", len(gw.Spec.Listeners))
+	}
+	got := gw.Spec.Listeners[0]
+	if got.Name != "https" || got.Port != 443 || got.Protocol != gwapiv1.HTTPSProtocolType {
+		t.Errorf("unexpected listener %+v", got)
+	}
+	if got.Hostname == nil || *got.Hostname != "*.example.com" {
+		t.Errorf("listener hostname not set")
+	}
+	if got.AllowedRoutes == nil || *got.AllowedRoutes.Namespaces.From != gwapiv1.NamespacesFromAll {
+		t.Errorf("listener allowed routes not set")
+	}
+
+	SetGatewayListeners(gw, []gwapiv1.Listener{
+		CreateGatewayListener("http", gwapiv1.HTTPProtocolType, 80),
+		CreateGatewayListener("grpc", gwapiv1.HTTPSProtocolType, 8443),
+	})
+	if len(gw.Spec.Listeners) != 2 {
+		t.Errorf("listeners not set")
+	}
+
+	AddGatewayAddress(gw, gwapiv1.GatewaySpecAddress{Value: "10.0.0.10"})
+	if len(gw.Spec.Addresses) != 1 || gw.Spec.Addresses[0].Value != "10.0.0.10" {
+		t.Errorf("address not added")
+	}
+
+	infra := &gwapiv1.GatewayInfrastructure{
+		Labels: map[gwapiv1.LabelKey]gwapiv1.LabelValue{"team": "platform"},
+	}
+	SetGatewayInfrastructure(gw, infra)
+	if gw.Spec.Infrastructure != infra {
+		t.Errorf("infrastructure not set")
+	}
+}
@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// CreateGRPCRoute returns a GRPCRoute with default labels, annotations,
+// and empty rule and hostname slices.
+func CreateGRPCRoute(name, namespace string) *gwapiv1.GRPCRoute {
+	return &gwapiv1.GRPCRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "GRPCRoute",
+			APIVersion: gwapiv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+		Spec: gwapiv1.GRPCRouteSpec{
+			Hostnames: []gwapiv1.Hostname{},
+			Rules:     []gwapiv1.GRPCRouteRule{},
+		},
+	}
+}
+
+// AddGRPCRouteHostname appends a hostname to the GRPCRoute.
+func AddGRPCRouteHostname(route *gwapiv1.GRPCRoute, hostname gwapiv1.Hostname) {
+	if route == nil {
+		panic("AddGRPCRouteHostname: route must not be nil")
+	}
+	route.Spec.Hostnames = append(route.Spec.Hostnames, hostname)
+}
+
+// SetGRPCRouteHostnames replaces all hostnames on the GRPCRoute.
+func SetGRPCRouteHostnames(route *gwapiv1.GRPCRoute, hostnames []gwapiv1.Hostname) {
+	if route == nil {
+		panic("SetGRPCRouteHostnames: route must not be nil")
+	}
+	route.Spec.Hostnames = hostnames
+}
+
+// AddGRPCRouteParentRef appends a parent reference (typically a Gateway) to the GRPCRoute.
+func AddGRPCRouteParentRef(route *gwapiv1.GRPCRoute, ref gwapiv1.ParentReference) {
+	if route == nil {
+		panic("AddGRPCRouteParentRef: route must not be nil")
+	}
+	route.Spec.ParentRefs = append(route.Spec.ParentRefs, ref)
+}
+
+// SetGRPCRouteParentRefs replaces the parent references on the GRPCRoute.
+func SetGRPCRouteParentRefs(route *gwapiv1.GRPCRoute, refs []gwapiv1.ParentReference) {
+	if route == nil {
+		panic("SetGRPCRouteParentRefs: route must not be nil")
+	}
+	route.Spec.ParentRefs = refs
+}
+
+// AddGRPCRouteRule appends a routing rule to the GRPCRoute.
+func AddGRPCRouteRule(route *gwapiv1.GRPCRoute, rule gwapiv1.GRPCRouteRule) {
+	if route == nil {
+		panic("AddGRPCRouteRule: route must not be nil")
+	}
+	route.Spec.Rules = append(route.Spec.Rules, rule)
+}
+
+// SetGRPCRouteRules replaces the routing rules on the GRPCRoute.
+func SetGRPCRouteRules(route *gwapiv1.GRPCRoute, rules []gwapiv1.GRPCRouteRule) {
+	if route == nil {
+		panic("SetGRPCRouteRules: route must not be nil")
+	}
+	route.Spec.Rules = rules
+}
+
+// AddGRPCRouteRuleMatch appends a match condition to a GRPCRouteRule.
+func AddGRPCRouteRuleMatch(rule *gwapiv1.GRPCRouteRule, match gwapiv1.GRPCRouteMatch) {
+	rule.Matches = append(rule.Matches, match)
+}
+
+// AddGRPCRouteRuleFilter appends a filter to a GRPCRouteRule.
+func AddGRPCRouteRuleFilter(rule *gwapiv1.GRPCRouteRule, filter gwapiv1.GRPCRouteFilter) {
+	rule.Filters = append(rule.Filters, filter)
+}
+
+// AddGRPCRouteRuleBackendRef appends a backend reference to a GRPCRouteRule.
+func AddGRPCRouteRuleBackendRef(rule *gwapiv1.GRPCRouteRule, ref gwapiv1.GRPCBackendRef) {
+	rule.BackendRefs = append(rule.BackendRefs, ref)
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"testing"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestCreateGRPCRoute(t *testing.T) {
+	route := CreateGRPCRoute("api", "ns")
+	if route.Name != "api" || route.Namespace != "ns" {
+		t.Fatalf("metadata mismatch: %s/%s", route.Namespace, route.Name)
+	}
+	if route.Kind != "GRPCRoute" {
+		t.Errorf("unexpected kind %q", route.Kind)
+	}
+	if route.Labels["app"] != "api" {
+		t.Errorf("expected label app=api, got %v", route.Labels)
+	}
+	if len(route.Spec.Hostnames) != 0 || len(route.Spec.Rules) != 0 {
+		t.Errorf("expected empty hostnames and rules, got %+v", route.Spec)
+	}
+}
+
+func TestGRPCRouteNilErrors(t *testing.T) {
+	assertPanics(t, func() { AddGRPCRouteHostname(nil, "example.com") })
+	assertPanics(t, func() { SetGRPCRouteHostnames(nil, nil) })
+	assertPanics(t, func() { AddGRPCRouteParentRef(nil, gwapiv1.ParentReference{}) })
+	assertPanics(t, func() { SetGRPCRouteParentRefs(nil, nil) })
+	assertPanics(t, func() { AddGRPCRouteRule(nil, gwapiv1.GRPCRouteRule{}) })
+	assertPanics(t, func() { SetGRPCRouteRules(nil, nil) })
+}
+
+func TestGRPCRouteFunctions(t *testing.T) {
+	route := CreateGRPCRoute("api", "ns")
+
+	AddGRPCRouteHostname(route, "grpc.example.com")
+	SetGRPCRouteHostnames(route, []gwapiv1.Hostname{"a.example.com", "b.example.com"})
+	if len(route.Spec.Hostnames) != 2 {
+		t.Errorf("hostnames not set")
+	}
+
+	AddGRPCRouteParentRef(route, gwapiv1.ParentReference{Name: "edge"})
+	if len(route.Spec.ParentRefs) != 1 || route.Spec.ParentRefs[0].Name != "edge" {
+		t.Errorf("parent ref not added")
+	}
+	SetGRPCRouteParentRefs(route, []gwapiv1.ParentReference{{Name: "gw-1"}, {Name: "gw-2"}})
+	if len(route.Spec.ParentRefs) != 2 {
+		t.Errorf("parent refs not set")
+	}
+
+	rule := gwapiv1.GRPCRouteRule{}
+	service := "helloworld.Greeter"
+	AddGRPCRouteRuleMatch(&rule, gwapiv1.GRPCRouteMatch{
+		Method: &gwapiv1.GRPCMethodMatch{Service: &service},
+	})
+	AddGRPCRouteRuleFilter(&rule, gwapiv1.GRPCRouteFilter{
+		Type: gwapiv1.GRPCRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gwapiv1.HTTPHeaderFilter{
+			Set: []gwapiv1.HTTPHeader{{Name: "X-Custom", Value: "val"}},
+		},
+	})
+	AddGRPCRouteRuleBackendRef(&rule, gwapiv1.GRPCBackendRef{
+		BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: gwapiv1.BackendObjectReference{Name: "greeter", Port: ptrPort(9000)},
+		},
+	})
+	if len(rule.Matches) != 1 || len(rule.Filters) != 1 || len(rule.BackendRefs) != 1 {
+		t.Fatalf("rule helpers did not append: %+v", rule)
+	}
+
+	AddGRPCRouteRule(route, rule)
+	if len(route.Spec.Rules) != 1 || route.Spec.Rules[0].BackendRefs[0].Name != "greeter" {
+		t.Errorf("rule not added")
+	}
+	SetGRPCRouteRules(route, []gwapiv1.GRPCRouteRule{{}, {}})
+	if len(route.Spec.Rules) != 2 {
+		t.Errorf("rules not set")
+	}
+}
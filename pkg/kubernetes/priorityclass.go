@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreatePriorityClass returns a cluster-scoped PriorityClass with the given
+// priority value and default labels and annotations.
+func CreatePriorityClass(name string, value int32) *schedulingv1.PriorityClass {
+	return &schedulingv1.PriorityClass{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PriorityClass",
+			APIVersion: schedulingv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+		Value: value,
+	}
+}
+
+// SetPriorityClassValue sets the priority of pods using the class.
+func SetPriorityClassValue(pc *schedulingv1.PriorityClass, value int32) {
+	if pc == nil {
+		panic("SetPriorityClassValue: pc must not be nil")
+	}
+	pc.Value = value
+}
+
+// SetPriorityClassGlobalDefault makes the class the default for pods
+// without a priorityClassName. Only one class should be the global default.
+func SetPriorityClassGlobalDefault(pc *schedulingv1.PriorityClass, globalDefault bool) {
+	if pc == nil {
+		panic("SetPriorityClassGlobalDefault: pc must not be nil")
+	}
+	pc.GlobalDefault = globalDefault
+}
+
+// SetPriorityClassDescription sets the human readable description.
+func SetPriorityClassDescription(pc *schedulingv1.PriorityClass, description string) {
+	if pc == nil {
+		panic("SetPriorityClassDescription: pc must not be nil")
+	}
+	pc.Description = description
+}
+
+// SetPriorityClassPreemptionPolicy sets whether pods of the class may
+// preempt lower priority pods.
+func SetPriorityClassPreemptionPolicy(pc *schedulingv1.PriorityClass, policy corev1.PreemptionPolicy) {
+	if pc == nil {
+		panic("SetPriorityClassPreemptionPolicy: pc must not be nil")
+	}
+	pc.PreemptionPolicy = &policy
+}
@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCreatePriorityClass(t *testing.T) {
+	pc := CreatePriorityClass("platform-critical", 1000000)
+	if pc.Name != "platform-critical" || pc.Namespace != "" {
+		t.Fatalf("metadata mismatch: %s/%s", pc.Namespace, pc.Name)
+	}
+	if pc.Kind != "PriorityClass" || pc.APIVersion != "scheduling.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", pc.APIVersion, pc.Kind)
+	}
+	if pc.Value != 1000000 {
+		t.Errorf("unexpected value %d", pc.Value)
+	}
+}
+
+func TestPriorityClassNilErrors(t *testing.T) {
+	assertPanics(t, func() { SetPriorityClassValue(nil, 1) })
+	assertPanics(t, func() { SetPriorityClassGlobalDefault(nil, true) })
+	assertPanics(t, func() { SetPriorityClassDescription(nil, "") })
+	assertPanics(t, func() { SetPriorityClassPreemptionPolicy(nil, corev1.PreemptNever) })
+}
+
+func TestPriorityClassFunctions(t *testing.T) {
+	pc := CreatePriorityClass("batch", 100)
+	SetPriorityClassValue(pc, 200)
+	SetPriorityClassGlobalDefault(pc, true)
+	SetPriorityClassDescription(pc, "batch workloads")
+	SetPriorityClassPreemptionPolicy(pc, corev1.PreemptNever)
+	if pc.Value != 200 || !pc.GlobalDefault || pc.Description != "batch workloads" {
+		t.Errorf("unexpected priority class %+v", pc)
+	}
+	if pc.PreemptionPolicy == nil || *pc.PreemptionPolicy != corev1.PreemptNever {
+		t.Errorf("preemption policy not set")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/go-kure/kure/pkg/errors"
 )
@@ -168,6 +169,94 @@ func ValidatePodSpecPSA(spec *corev1.PodSpec, level PSALevel) error {
 	}
 }
 
+// ApplyRestrictedSecurityProfile hardens the pod template of a Deployment,
+// StatefulSet, DaemonSet, Job or CronJob to the restricted Pod Security
+// Standards level. The pod runs as non-root with the RuntimeDefault seccomp
+// profile, and every container and init container drops ALL capabilities,
+// disallows privilege escalation and mounts a read-only root filesystem.
+// Settings the restricted level permits, such as runAsUser or a Localhost
+// seccomp profile, are kept.
+func ApplyRestrictedSecurityProfile(obj runtime.Object) error {
+	tmpl, err := workloadPodTemplate(obj)
+	if err != nil {
+		return err
+	}
+	spec := &tmpl.Spec
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	spec.SecurityContext.RunAsNonRoot = boolPtr(true)
+	spec.SecurityContext.SeccompProfile = defaultSeccompProfile(spec.SecurityContext.SeccompProfile)
+	for _, c := range podSpecContainers(spec) {
+		sc := baselineContainerSecurityContext(c)
+		sc.RunAsNonRoot = boolPtr(true)
+		sc.ReadOnlyRootFilesystem = boolPtr(true)
+		if sc.Capabilities == nil {
+			sc.Capabilities = &corev1.Capabilities{}
+		}
+		if !hasDropAll(sc.Capabilities.Drop) {
+			sc.Capabilities.Drop = append(sc.Capabilities.Drop, "ALL")
+		}
+	}
+	return nil
+}
+
+// ApplyBaselineSecurityProfile hardens the pod template of a Deployment,
+// StatefulSet, DaemonSet, Job or CronJob to the baseline Pod Security
+// Standards level: the RuntimeDefault seccomp profile, no privileged
+// containers and no privilege escalation.
+func ApplyBaselineSecurityProfile(obj runtime.Object) error {
+	tmpl, err := workloadPodTemplate(obj)
+	if err != nil {
+		return err
+	}
+	spec := &tmpl.Spec
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	spec.SecurityContext.SeccompProfile = defaultSeccompProfile(spec.SecurityContext.SeccompProfile)
+	for _, c := range podSpecContainers(spec) {
+		baselineContainerSecurityContext(c)
+	}
+	return nil
+}
+
+// podSpecContainers returns pointers to all containers and init containers.
+func podSpecContainers(spec *corev1.PodSpec) []*corev1.Container {
+	containers := make([]*corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	for i := range spec.InitContainers {
+		containers = append(containers, &spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		containers = append(containers, &spec.Containers[i])
+	}
+	return containers
+}
+
+// baselineContainerSecurityContext applies the baseline settings to c and
+// returns its security context.
+func baselineContainerSecurityContext(c *corev1.Container) *corev1.SecurityContext {
+	if c.SecurityContext == nil {
+		c.SecurityContext = &corev1.SecurityContext{}
+	}
+	sc := c.SecurityContext
+	if sc.Privileged != nil && *sc.Privileged {
+		sc.Privileged = boolPtr(false)
+	}
+	sc.AllowPrivilegeEscalation = boolPtr(false)
+	sc.SeccompProfile = defaultSeccompProfile(sc.SeccompProfile)
+	return sc
+}
+
+// defaultSeccompProfile returns p unless it is unset or Unconfined, in which
+// case RuntimeDefault is returned.
+func defaultSeccompProfile(p *corev1.SeccompProfile) *corev1.SeccompProfile {
+	if p != nil && p.Type != corev1.SeccompProfileTypeUnconfined {
+		return p
+	}
+	return &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+}
+
 func psaField(prefix, suffix string) string {
 	if prefix == "" {
 		return suffix
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	kerrors "github.com/go-kure/kure/pkg/errors"
@@ -459,3 +460,105 @@ func TestValidatePodSpecPSA(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyRestrictedSecurityProfile(t *testing.T) {
+	dep := CreateDeployment("web", "default")
+	if err := AddDeploymentInitContainer(dep, &corev1.Container{Name: "migrate"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddDeploymentContainer(dep, &corev1.Container{
+		Name: "app",
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	uid := int64(1000)
+	dep.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsUser: &uid}
+
+	if err := ApplyRestrictedSecurityProfile(dep); err != nil {
+		t.Fatalf("ApplyRestrictedSecurityProfile: %v", err)
+	}
+	spec := &dep.Spec.Template.Spec
+	if err := ValidatePodSpecPSA(spec, PSARestricted); err != nil {
+		t.Errorf("expected restricted compliance, got %v", err)
+	}
+	if spec.SecurityContext.RunAsUser == nil || *spec.SecurityContext.RunAsUser != 1000 {
+		t.Error("expected runAsUser to be kept")
+	}
+	app := spec.Containers[0].SecurityContext
+	if app.ReadOnlyRootFilesystem == nil || !*app.ReadOnlyRootFilesystem {
+		t.Error("expected read-only root filesystem")
+	}
+	if len(app.Capabilities.Add) != 1 || len(app.Capabilities.Drop) != 1 {
+		t.Errorf("unexpected capabilities %+v", app.Capabilities)
+	}
+
+	// Applying twice must not duplicate the ALL drop.
+	if err := ApplyRestrictedSecurityProfile(dep); err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Containers[0].SecurityContext.Capabilities.Drop) != 1 {
+		t.Error("ALL capability drop duplicated")
+	}
+
+	cj := CreateCronJob("backup", "default", "@daily")
+	cj.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{{Name: "backup"}}
+	if err := ApplyRestrictedSecurityProfile(cj); err != nil {
+		t.Fatalf("ApplyRestrictedSecurityProfile(CronJob): %v", err)
+	}
+	if err := ValidatePodSpecPSA(&cj.Spec.JobTemplate.Spec.Template.Spec, PSARestricted); err != nil {
+		t.Errorf("expected restricted CronJob, got %v", err)
+	}
+}
+
+func TestApplyBaselineSecurityProfile(t *testing.T) {
+	sts := CreateStatefulSet("db", "default")
+	privileged := true
+	if err := AddStatefulSetContainer(sts, &corev1.Container{
+		Name:            "db",
+		SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sts.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+	}
+
+	if err := ApplyBaselineSecurityProfile(sts); err != nil {
+		t.Fatalf("ApplyBaselineSecurityProfile: %v", err)
+	}
+	spec := &sts.Spec.Template.Spec
+	if err := ValidatePodSpecPSA(spec, PSABaseline); err != nil {
+		t.Errorf("expected baseline compliance, got %v", err)
+	}
+	if spec.SecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("expected RuntimeDefault seccomp, got %v", spec.SecurityContext.SeccompProfile.Type)
+	}
+	if spec.SecurityContext.RunAsNonRoot != nil {
+		t.Error("baseline profile must not force runAsNonRoot")
+	}
+
+	ds := CreateDaemonSet("agent", "kube-system")
+	if err := ApplyBaselineSecurityProfile(ds); err != nil {
+		t.Errorf("ApplyBaselineSecurityProfile(DaemonSet): %v", err)
+	}
+	job := CreateJob("once", "default")
+	if err := ApplyBaselineSecurityProfile(job); err != nil {
+		t.Errorf("ApplyBaselineSecurityProfile(Job): %v", err)
+	}
+}
+
+func TestApplySecurityProfileErrors(t *testing.T) {
+	if err := ApplyRestrictedSecurityProfile(nil); !errors.Is(err, kerrors.ErrNilObject) {
+		t.Errorf("expected ErrNilObject, got %v", err)
+	}
+	var dep *appsv1.Deployment
+	if err := ApplyBaselineSecurityProfile(dep); !errors.Is(err, kerrors.ErrNilObject) {
+		t.Errorf("expected ErrNilObject for a nil Deployment, got %v", err)
+	}
+	if err := ApplyRestrictedSecurityProfile(&corev1.Pod{}); err == nil {
+		t.Error("expected error for an unsupported type")
+	}
+}
@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// CreateRuntimeClass returns a cluster-scoped RuntimeClass that selects the
+// given CRI handler, with default labels and annotations.
+func CreateRuntimeClass(name, handler string) *nodev1.RuntimeClass {
+	return &nodev1.RuntimeClass{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RuntimeClass",
+			APIVersion: nodev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+		Handler: handler,
+	}
+}
+
+// SetRuntimeClassOverhead sets the resources accounted to each pod on top of
+// its containers' requests.
+func SetRuntimeClassOverhead(rc *nodev1.RuntimeClass, podFixed corev1.ResourceList) {
+	if rc == nil {
+		panic("SetRuntimeClassOverhead: rc must not be nil")
+	}
+	rc.Overhead = &nodev1.Overhead{PodFixed: podFixed}
+}
+
+// SetRuntimeClassOverheadQuantities is a convenience wrapper around
+// SetRuntimeClassOverhead taking CPU and memory quantities such as "250m"
+// and "120Mi".
+func SetRuntimeClassOverheadQuantities(rc *nodev1.RuntimeClass, cpu, memory string) error {
+	cpuQty, err := resource.ParseQuantity(cpu)
+	if err != nil {
+		return errors.Wrapf(err, "invalid quantity %q for resource %s", cpu, corev1.ResourceCPU)
+	}
+	memQty, err := resource.ParseQuantity(memory)
+	if err != nil {
+		return errors.Wrapf(err, "invalid quantity %q for resource %s", memory, corev1.ResourceMemory)
+	}
+	SetRuntimeClassOverhead(rc, corev1.ResourceList{
+		corev1.ResourceCPU:    cpuQty,
+		corev1.ResourceMemory: memQty,
+	})
+	return nil
+}
+
+// SetRuntimeClassNodeSelector restricts pods using the class to nodes with
+// the given labels.
+func SetRuntimeClassNodeSelector(rc *nodev1.RuntimeClass, nodeSelector map[string]string) {
+	if rc == nil {
+		panic("SetRuntimeClassNodeSelector: rc must not be nil")
+	}
+	if rc.Scheduling == nil {
+		rc.Scheduling = &nodev1.Scheduling{}
+	}
+	rc.Scheduling.NodeSelector = nodeSelector
+}
+
+// AddRuntimeClassToleration appends a toleration added to pods using the
+// class.
+func AddRuntimeClassToleration(rc *nodev1.RuntimeClass, toleration corev1.Toleration) {
+	if rc == nil {
+		panic("AddRuntimeClassToleration: rc must not be nil")
+	}
+	if rc.Scheduling == nil {
+		rc.Scheduling = &nodev1.Scheduling{}
+	}
+	rc.Scheduling.Tolerations = append(rc.Scheduling.Tolerations, toleration)
+}
@@ -0,0 +1,46 @@
+package kubernetes
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCreateRuntimeClass(t *testing.T) {
+	rc := CreateRuntimeClass("gvisor", "runsc")
+	if rc.Name != "gvisor" || rc.Handler != "runsc" {
+		t.Fatalf("unexpected runtime class %s handler %s", rc.Name, rc.Handler)
+	}
+	if rc.Kind != "RuntimeClass" || rc.APIVersion != "node.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", rc.APIVersion, rc.Kind)
+	}
+}
+
+func TestRuntimeClassNilErrors(t *testing.T) {
+	assertPanics(t, func() { SetRuntimeClassOverhead(nil, nil) })
+	assertPanics(t, func() { SetRuntimeClassNodeSelector(nil, nil) })
+	assertPanics(t, func() { AddRuntimeClassToleration(nil, corev1.Toleration{}) })
+}
+
+func TestRuntimeClassFunctions(t *testing.T) {
+	rc := CreateRuntimeClass("kata", "kata-qemu")
+
+	if err := SetRuntimeClassOverheadQuantities(rc, "250m", "160Mi"); err != nil {
+		t.Fatalf("SetRuntimeClassOverheadQuantities: %v", err)
+	}
+	if rc.Overhead == nil || rc.Overhead.PodFixed.Cpu().String() != "250m" || rc.Overhead.PodFixed.Memory().String() != "160Mi" {
+		t.Errorf("unexpected overhead %+v", rc.Overhead)
+	}
+	if err := SetRuntimeClassOverheadQuantities(rc, "lots", "160Mi"); err == nil {
+		t.Error("expected error for an invalid quantity")
+	}
+
+	SetRuntimeClassNodeSelector(rc, map[string]string{"kata": "true"})
+	AddRuntimeClassToleration(rc, corev1.Toleration{Key: "kata", Operator: corev1.TolerationOpExists})
+	if rc.Scheduling == nil || rc.Scheduling.NodeSelector["kata"] != "true" {
+		t.Errorf("node selector not set")
+	}
+	if len(rc.Scheduling.Tolerations) != 1 || rc.Scheduling.Tolerations[0].Key != "kata" {
+		t.Errorf("toleration not added")
+	}
+}
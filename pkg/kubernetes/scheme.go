@@ -13,23 +13,32 @@ import (
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
+	notificationv1beta2 "github.com/fluxcd/notification-controller/api/v1beta2"
 	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourceWatcherv1beta1 "github.com/fluxcd/source-watcher/api/v2/v1beta1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	metallbv1beta1 "go.universe.tf/metallb/api/v1beta1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	netv1 "k8s.io/api/networking/v1"
+	nodev1 "k8s.io/api/node/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storv1 "k8s.io/api/storage/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/go-kure/kure/pkg/errors"
 )
 
 var (
@@ -37,6 +46,7 @@ var (
 	Codecs       = serializer.NewCodecFactory(Scheme)
 	registerOnce sync.Once
 	registerErr  error
+	extraMu      sync.Mutex
 )
 
 // addSchemeFunc is a function that adds types to a scheme
@@ -52,6 +62,30 @@ func RegisterSchemes() error {
 	return registerErr
 }
 
+// RegisterScheme adds the types installed by addToScheme, typically the
+// AddToScheme function of a generated API package, to Scheme so that the kure
+// parsers decode them into typed objects. The built-in schemes are registered
+// first. Registering a GroupVersionKind that is already bound to a different
+// Go type returns an error instead of panicking. RegisterScheme should be
+// called during program initialization, before objects are decoded.
+func RegisterScheme(addToScheme func(*runtime.Scheme) error) (err error) {
+	if addToScheme == nil {
+		return errors.New("RegisterScheme: addToScheme must not be nil")
+	}
+	if err := RegisterSchemes(); err != nil {
+		return err
+	}
+
+	extraMu.Lock()
+	defer extraMu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("register scheme: %v", r)
+		}
+	}()
+	return addToScheme(Scheme)
+}
+
 // registerAllSchemes registers all schemes and returns the first error encountered
 func registerAllSchemes() error {
 	// List of all AddToScheme functions to register
@@ -61,7 +95,12 @@ func registerAllSchemes() error {
 		rbacv1.AddToScheme,
 		batchv1.AddToScheme,
 		netv1.AddToScheme,
+		discoveryv1.AddToScheme,
 		storv1.AddToScheme,
+		schedulingv1.AddToScheme,
+		nodev1.AddToScheme,
+		admissionregistrationv1.AddToScheme,
+		vpav1.AddToScheme,
 		apiextensionsv1.AddToScheme,
 		cmacme.AddToScheme,
 		certv1.AddToScheme,
@@ -69,8 +108,10 @@ func registerAllSchemes() error {
 		fluxv1.AddToScheme,
 		helmv2.AddToScheme,
 		imagev1.AddToScheme,
+		imagereflectv1.AddToScheme,
 		kustv1.AddToScheme,
 		notificationv1.AddToScheme,
+		notificationv1beta2.AddToScheme,
 		notificationv1beta3.AddToScheme,
 		sourcev1.AddToScheme,
 		sourceWatcherv1beta1.AddToScheme,
@@ -13,16 +13,22 @@ import (
 	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	metallbv1beta1 "go.universe.tf/metallb/api/v1beta1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	netv1 "k8s.io/api/networking/v1"
+	nodev1 "k8s.io/api/node/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storv1 "k8s.io/api/storage/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestSchemeInitialization(t *testing.T) {
@@ -146,6 +152,7 @@ func TestScheme_RegisteredTypes(t *testing.T) {
 	networkingTypes := []runtime.Object{
 		&netv1.Ingress{},
 		&netv1.NetworkPolicy{},
+		&discoveryv1.EndpointSlice{},
 	}
 
 	for _, obj := range networkingTypes {
@@ -158,6 +165,57 @@ func TestScheme_RegisteredTypes(t *testing.T) {
 		}
 	}
 
+	// Test the admission policy and VPA types are registered
+	admissionTypes := []runtime.Object{
+		&admissionregistrationv1.ValidatingAdmissionPolicy{},
+		&admissionregistrationv1.ValidatingAdmissionPolicyBinding{},
+		&vpav1.VerticalPodAutoscaler{},
+	}
+
+	for _, obj := range admissionTypes {
+		gvks, _, err := Scheme.ObjectKinds(obj)
+		if err != nil {
+			t.Errorf("failed to get GVKs for %T: %v", obj, err)
+		}
+		if len(gvks) == 0 {
+			t.Errorf("no GVKs found for %T", obj)
+		}
+	}
+
+	// Test the Gateway API types are registered
+	gatewayTypes := []runtime.Object{
+		&gwapiv1.GatewayClass{},
+		&gwapiv1.Gateway{},
+		&gwapiv1.HTTPRoute{},
+		&gwapiv1.GRPCRoute{},
+	}
+
+	for _, obj := range gatewayTypes {
+		gvks, _, err := Scheme.ObjectKinds(obj)
+		if err != nil {
+			t.Errorf("failed to get GVKs for %T: %v", obj, err)
+		}
+		if len(gvks) == 0 {
+			t.Errorf("no GVKs found for %T", obj)
+		}
+	}
+
+	// Test the scheduling and node types are registered
+	clusterTypes := []runtime.Object{
+		&schedulingv1.PriorityClass{},
+		&nodev1.RuntimeClass{},
+	}
+
+	for _, obj := range clusterTypes {
+		gvks, _, err := Scheme.ObjectKinds(obj)
+		if err != nil {
+			t.Errorf("failed to get GVKs for %T: %v", obj, err)
+		}
+		if len(gvks) == 0 {
+			t.Errorf("no GVKs found for %T", obj)
+		}
+	}
+
 	// Test some storage types are registered
 	storageTypes := []runtime.Object{
 		&storv1.StorageClass{},
@@ -818,3 +876,36 @@ func TestScheme_PreferredVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterScheme(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "scheme-test.kure.dev", Version: "v1", Kind: "Widget"}
+	err := RegisterScheme(func(s *runtime.Scheme) error {
+		s.AddKnownTypeWithName(gvk, &corev1.ConfigMap{})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterScheme: %v", err)
+	}
+	if !Scheme.Recognizes(gvk) {
+		t.Fatalf("expected %s to be registered", gvk)
+	}
+	if !Scheme.Recognizes(corev1.SchemeGroupVersion.WithKind("Pod")) {
+		t.Error("expected built-in schemes to be registered as well")
+	}
+
+	conflict := RegisterScheme(func(s *runtime.Scheme) error {
+		s.AddKnownTypeWithName(gvk, &corev1.Secret{})
+		return nil
+	})
+	if conflict == nil {
+		t.Error("expected error for a conflicting registration")
+	}
+
+	sentinel := stderrors.New("boom")
+	if err := RegisterScheme(func(*runtime.Scheme) error { return sentinel }); !stderrors.Is(err, sentinel) {
+		t.Errorf("expected addToScheme error, got %v", err)
+	}
+	if err := RegisterScheme(nil); err == nil {
+		t.Error("expected error for nil addToScheme")
+	}
+}
@@ -191,3 +191,37 @@ func SetStatefulSetMinReadySeconds(sts *appsv1.StatefulSet, secs int32) {
 	}
 	sts.Spec.MinReadySeconds = secs
 }
+
+// SetStatefulSetPersistentVolumeClaimRetentionPolicy sets whether the PVCs
+// created from the volume claim templates are retained or deleted when the
+// StatefulSet is deleted and when it is scaled down.
+func SetStatefulSetPersistentVolumeClaimRetentionPolicy(sts *appsv1.StatefulSet, whenDeleted, whenScaled appsv1.PersistentVolumeClaimRetentionPolicyType) {
+	if sts == nil {
+		panic("SetStatefulSetPersistentVolumeClaimRetentionPolicy: sts must not be nil")
+	}
+	sts.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: whenDeleted,
+		WhenScaled:  whenScaled,
+	}
+}
+
+// SetStatefulSetUpdatePartition switches the StatefulSet to rolling updates
+// and only updates pods with an ordinal greater than or equal to partition.
+func SetStatefulSetUpdatePartition(sts *appsv1.StatefulSet, partition int32) {
+	if sts == nil {
+		panic("SetStatefulSetUpdatePartition: sts must not be nil")
+	}
+	sts.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+	if sts.Spec.UpdateStrategy.RollingUpdate == nil {
+		sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+	}
+	sts.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+}
+
+// SetStatefulSetOrdinals sets the ordinal assigned to the first replica.
+func SetStatefulSetOrdinals(sts *appsv1.StatefulSet, start int32) {
+	if sts == nil {
+		panic("SetStatefulSetOrdinals: sts must not be nil")
+	}
+	sts.Spec.Ordinals = &appsv1.StatefulSetOrdinals{Start: start}
+}
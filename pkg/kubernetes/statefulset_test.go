@@ -188,6 +188,26 @@ func TestStatefulSetFunctions(t *testing.T) {
 	if sts.Spec.MinReadySeconds != 5 {
 		t.Errorf("min ready seconds not set")
 	}
+
+	SetStatefulSetPersistentVolumeClaimRetentionPolicy(sts, appsv1.DeletePersistentVolumeClaimRetentionPolicyType, appsv1.RetainPersistentVolumeClaimRetentionPolicyType)
+	if p := sts.Spec.PersistentVolumeClaimRetentionPolicy; p == nil ||
+		p.WhenDeleted != appsv1.DeletePersistentVolumeClaimRetentionPolicyType ||
+		p.WhenScaled != appsv1.RetainPersistentVolumeClaimRetentionPolicyType {
+		t.Errorf("pvc retention policy not set")
+	}
+
+	SetStatefulSetUpdatePartition(sts, 2)
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru == nil || ru.Partition == nil || *ru.Partition != 2 {
+		t.Errorf("update partition not set")
+	}
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		t.Errorf("update partition should select rolling updates")
+	}
+
+	SetStatefulSetOrdinals(sts, 1)
+	if sts.Spec.Ordinals == nil || sts.Spec.Ordinals.Start != 1 {
+		t.Errorf("ordinals not set")
+	}
 }
 
 func TestStatefulSetNilGuards(t *testing.T) {
@@ -228,4 +248,9 @@ func TestStatefulSetNilGuards(t *testing.T) {
 	assertPanics(t, func() { SetStatefulSetPodManagementPolicy(nil, appsv1.OrderedReadyPodManagement) })
 	assertPanics(t, func() { SetStatefulSetRevisionHistoryLimit(nil, &rhl) })
 	assertPanics(t, func() { SetStatefulSetMinReadySeconds(nil, 1) })
+	assertPanics(t, func() {
+		SetStatefulSetPersistentVolumeClaimRetentionPolicy(nil, appsv1.RetainPersistentVolumeClaimRetentionPolicyType, appsv1.RetainPersistentVolumeClaimRetentionPolicyType)
+	})
+	assertPanics(t, func() { SetStatefulSetUpdatePartition(nil, 1) })
+	assertPanics(t, func() { SetStatefulSetOrdinals(nil, 1) })
 }
@@ -0,0 +1,407 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// ValidateObject checks obj against Kubernetes naming and value constraints:
+// DNS-1123 names and namespaces, label and annotation syntax, container names
+// and port ranges of workloads, Service ports, and CronJob schedules. It
+// returns the first violation found, or nil.
+func ValidateObject(obj client.Object) error {
+	if obj == nil {
+		return errors.ErrNilObject
+	}
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = fmt.Sprintf("%T", obj)
+	}
+	name := obj.GetName()
+
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return validationFailure(kind, name, "metadata.name", msgs)
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		if msgs := validation.IsDNS1123Label(ns); len(msgs) > 0 {
+			return validationFailure(kind, name, "metadata.namespace", msgs)
+		}
+	}
+	if err := validateLabels(kind, name, "metadata.labels", obj.GetLabels()); err != nil {
+		return err
+	}
+	if err := validateAnnotations(kind, name, "metadata.annotations", obj.GetAnnotations()); err != nil {
+		return err
+	}
+
+	switch o := obj.(type) {
+	case *corev1.Service:
+		for i, p := range o.Spec.Ports {
+			if msgs := validation.IsValidPortNum(int(p.Port)); len(msgs) > 0 {
+				return validationFailure(kind, name, fmt.Sprintf("spec.ports[%d].port", i), msgs)
+			}
+		}
+	case *batchv1.CronJob:
+		if err := ValidateCronSchedule(o.Spec.Schedule); err != nil {
+			return errors.ResourceValidationError(kind, name, "spec.schedule", err.Error(), nil)
+		}
+	}
+
+	if tmpl, err := workloadPodTemplate(obj); err == nil {
+		if err := validateLabels(kind, name, "spec.template.metadata.labels", tmpl.Labels); err != nil {
+			return err
+		}
+		for _, c := range podSpecContainers(&tmpl.Spec) {
+			if err := validateContainer(c); err != nil {
+				return errors.ResourceValidationError(kind, name, "spec.template.spec", err.Error(), nil)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateCronSchedule checks that schedule is a five-field cron expression
+// or one of the @yearly, @annually, @monthly, @weekly, @daily, @midnight and
+// @hourly macros, as accepted by the CronJob controller. Time zones must be
+// set through spec.timeZone, not in the schedule.
+func ValidateCronSchedule(schedule string) error {
+	switch schedule {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return nil
+	}
+	if strings.Contains(schedule, "TZ=") {
+		return errors.Errorf("cron schedule %q: set the time zone in spec.timeZone", schedule)
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return errors.Errorf("cron schedule %q: expected 5 fields, got %d", schedule, len(fields))
+	}
+	bounds := []struct {
+		name     string
+		min, max int
+	}{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day of month", 1, 31},
+		{"month", 1, 12},
+		{"day of week", 0, 7},
+	}
+	for i, field := range fields {
+		if err := validateCronField(field, bounds[i].min, bounds[i].max); err != nil {
+			return errors.Errorf("cron schedule %q: %s field: %v", schedule, bounds[i].name, err)
+		}
+	}
+	return nil
+}
+
+// validateCronField checks a comma separated list of "*", "?", values, ranges
+// and steps. Month and weekday names are accepted without range checks.
+func validateCronField(field string, min, max int) error {
+	for _, part := range strings.Split(field, ",") {
+		base, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return errors.Errorf("invalid step %q", step)
+			}
+		}
+		if base == "*" || base == "?" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(base, "-")
+		values := []string{lo}
+		if isRange {
+			values = append(values, hi)
+		}
+		for _, v := range values {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				if slices.Contains(cronNames, strings.ToUpper(v)) {
+					continue
+				}
+				return errors.Errorf("invalid value %q", v)
+			}
+			if n < min || n > max {
+				return errors.Errorf("value %d out of range %d-%d", n, min, max)
+			}
+		}
+	}
+	return nil
+}
+
+// cronNames are the month and weekday names accepted in cron fields.
+var cronNames = []string{
+	"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+	"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT",
+}
+
+// validateContainer checks the container name and port numbers.
+func validateContainer(c *corev1.Container) error {
+	if msgs := validation.IsDNS1123Label(c.Name); len(msgs) > 0 {
+		return errors.Errorf("container name %q: %s", c.Name, strings.Join(msgs, "; "))
+	}
+	for _, p := range c.Ports {
+		if msgs := validation.IsValidPortNum(int(p.ContainerPort)); len(msgs) > 0 {
+			return errors.Errorf("container %q port %d: %s", c.Name, p.ContainerPort, strings.Join(msgs, "; "))
+		}
+		if p.Name != "" {
+			if msgs := validation.IsValidPortName(p.Name); len(msgs) > 0 {
+				return errors.Errorf("container %q port name %q: %s", c.Name, p.Name, strings.Join(msgs, "; "))
+			}
+		}
+	}
+	return nil
+}
+
+func validateLabels(kind, name, field string, labels map[string]string) error {
+	for k, v := range labels {
+		if msgs := validation.IsQualifiedName(k); len(msgs) > 0 {
+			return validationFailure(kind, name, field, append([]string{fmt.Sprintf("key %q", k)}, msgs...))
+		}
+		if msgs := validation.IsValidLabelValue(v); len(msgs) > 0 {
+			return validationFailure(kind, name, field, append([]string{fmt.Sprintf("value %q of %q", v, k)}, msgs...))
+		}
+	}
+	return nil
+}
+
+func validateAnnotations(kind, name, field string, annotations map[string]string) error {
+	for k := range annotations {
+		if msgs := validation.IsQualifiedName(strings.ToLower(k)); len(msgs) > 0 {
+			return validationFailure(kind, name, field, append([]string{fmt.Sprintf("key %q", k)}, msgs...))
+		}
+	}
+	return nil
+}
+
+func validationFailure(kind, name, field string, msgs []string) error {
+	return errors.ResourceValidationError(kind, name, field, strings.Join(msgs, "; "), nil)
+}
+
+// Validator is the strict counterpart of the package-level builders. Its
+// methods check their input against the same constraints as ValidateObject
+// and return a ResourceError before creating or changing anything, instead
+// of producing an invalid manifest. The package-level helpers never
+// validate. The zero value is ready to use:
+//
+//	var v kubernetes.Validator
+//	dep, err := v.CreateDeployment("web", "default")
+type Validator struct{}
+
+// CreateDeployment returns CreateDeployment(name, namespace) if it passes
+// ValidateObject.
+func (Validator) CreateDeployment(name, namespace string) (*appsv1.Deployment, error) {
+	return validated(CreateDeployment(name, namespace))
+}
+
+// CreateStatefulSet returns CreateStatefulSet(name, namespace) if it passes
+// ValidateObject.
+func (Validator) CreateStatefulSet(name, namespace string) (*appsv1.StatefulSet, error) {
+	return validated(CreateStatefulSet(name, namespace))
+}
+
+// CreateDaemonSet returns CreateDaemonSet(name, namespace) if it passes
+// ValidateObject.
+func (Validator) CreateDaemonSet(name, namespace string) (*appsv1.DaemonSet, error) {
+	return validated(CreateDaemonSet(name, namespace))
+}
+
+// CreateJob returns CreateJob(name, namespace) if it passes ValidateObject.
+func (Validator) CreateJob(name, namespace string) (*batchv1.Job, error) {
+	return validated(CreateJob(name, namespace))
+}
+
+// CreateCronJob returns CreateCronJob(name, namespace, schedule) if it
+// passes ValidateObject, which includes the schedule.
+func (Validator) CreateCronJob(name, namespace, schedule string) (*batchv1.CronJob, error) {
+	return validated(CreateCronJob(name, namespace, schedule))
+}
+
+// CreateService returns CreateService(name, namespace) if it passes
+// ValidateObject.
+func (Validator) CreateService(name, namespace string) (*corev1.Service, error) {
+	return validated(CreateService(name, namespace))
+}
+
+// SetCronJobSchedule checks schedule with ValidateCronSchedule and sets it.
+func (Validator) SetCronJobSchedule(cron *batchv1.CronJob, schedule string) error {
+	if cron == nil {
+		return errors.ErrNilCronJob
+	}
+	if err := ValidateCronSchedule(schedule); err != nil {
+		return errors.ResourceValidationError("CronJob", cron.Name, "spec.schedule", err.Error(), nil)
+	}
+	SetCronJobSchedule(cron, schedule)
+	return nil
+}
+
+// AddServicePort checks the port number and name and appends the port.
+func (Validator) AddServicePort(service *corev1.Service, port corev1.ServicePort) error {
+	if service == nil {
+		return errors.ErrNilService
+	}
+	if msgs := validation.IsValidPortNum(int(port.Port)); len(msgs) > 0 {
+		return validationFailure("Service", service.Name, "spec.ports.port", msgs)
+	}
+	if port.Name != "" {
+		if msgs := validation.IsDNS1123Label(port.Name); len(msgs) > 0 {
+			return validationFailure("Service", service.Name, "spec.ports.name", msgs)
+		}
+	}
+	AddServicePort(service, port)
+	return nil
+}
+
+// AddPodSpecContainer validates container and calls AddPodSpecContainer.
+func (Validator) AddPodSpecContainer(spec *corev1.PodSpec, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddPodSpecContainer(spec, container)
+}
+
+// AddPodSpecInitContainer validates container and calls
+// AddPodSpecInitContainer.
+func (Validator) AddPodSpecInitContainer(spec *corev1.PodSpec, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddPodSpecInitContainer(spec, container)
+}
+
+// AddDeploymentContainer validates container and calls
+// AddDeploymentContainer.
+func (Validator) AddDeploymentContainer(deployment *appsv1.Deployment, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddDeploymentContainer(deployment, container)
+}
+
+// AddDeploymentInitContainer validates container and calls
+// AddDeploymentInitContainer.
+func (Validator) AddDeploymentInitContainer(deployment *appsv1.Deployment, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddDeploymentInitContainer(deployment, container)
+}
+
+// AddStatefulSetContainer validates c and calls AddStatefulSetContainer.
+func (Validator) AddStatefulSetContainer(sts *appsv1.StatefulSet, c *corev1.Container) error {
+	if err := checkContainer(c); err != nil {
+		return err
+	}
+	return AddStatefulSetContainer(sts, c)
+}
+
+// AddStatefulSetInitContainer validates c and calls
+// AddStatefulSetInitContainer.
+func (Validator) AddStatefulSetInitContainer(sts *appsv1.StatefulSet, c *corev1.Container) error {
+	if err := checkContainer(c); err != nil {
+		return err
+	}
+	return AddStatefulSetInitContainer(sts, c)
+}
+
+// AddDaemonSetContainer validates c and calls AddDaemonSetContainer.
+func (Validator) AddDaemonSetContainer(ds *appsv1.DaemonSet, c *corev1.Container) error {
+	if err := checkContainer(c); err != nil {
+		return err
+	}
+	return AddDaemonSetContainer(ds, c)
+}
+
+// AddDaemonSetInitContainer validates c and calls AddDaemonSetInitContainer.
+func (Validator) AddDaemonSetInitContainer(ds *appsv1.DaemonSet, c *corev1.Container) error {
+	if err := checkContainer(c); err != nil {
+		return err
+	}
+	return AddDaemonSetInitContainer(ds, c)
+}
+
+// AddJobContainer validates container and calls AddJobContainer.
+func (Validator) AddJobContainer(job *batchv1.Job, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddJobContainer(job, container)
+}
+
+// AddJobInitContainer validates container and calls AddJobInitContainer.
+func (Validator) AddJobInitContainer(job *batchv1.Job, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddJobInitContainer(job, container)
+}
+
+// AddCronJobContainer validates container and calls AddCronJobContainer.
+func (Validator) AddCronJobContainer(cron *batchv1.CronJob, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddCronJobContainer(cron, container)
+}
+
+// AddCronJobInitContainer validates container and calls
+// AddCronJobInitContainer.
+func (Validator) AddCronJobInitContainer(cron *batchv1.CronJob, container *corev1.Container) error {
+	if err := checkContainer(container); err != nil {
+		return err
+	}
+	return AddCronJobInitContainer(cron, container)
+}
+
+// SetWorkloadLabels checks label keys and values and calls
+// SetWorkloadLabels.
+func (Validator) SetWorkloadLabels(obj client.Object, labels map[string]string, propagateToPodTemplate bool) error {
+	if _, err := workloadPodTemplate(obj); err != nil {
+		return err
+	}
+	if err := validateLabels(obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), "metadata.labels", labels); err != nil {
+		return err
+	}
+	return SetWorkloadLabels(obj, labels, propagateToPodTemplate)
+}
+
+// SetWorkloadAnnotations checks annotation keys and calls
+// SetWorkloadAnnotations.
+func (Validator) SetWorkloadAnnotations(obj client.Object, annotations map[string]string, propagateToPodTemplate bool) error {
+	if _, err := workloadPodTemplate(obj); err != nil {
+		return err
+	}
+	if err := validateAnnotations(obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), "metadata.annotations", annotations); err != nil {
+		return err
+	}
+	return SetWorkloadAnnotations(obj, annotations, propagateToPodTemplate)
+}
+
+// validated returns obj if it passes ValidateObject.
+func validated[T client.Object](obj T) (T, error) {
+	if err := ValidateObject(obj); err != nil {
+		var zero T
+		return zero, err
+	}
+	return obj, nil
+}
+
+// checkContainer validates c, leaving nil to the builder it guards.
+func checkContainer(c *corev1.Container) error {
+	if c == nil {
+		return nil
+	}
+	if err := validateContainer(c); err != nil {
+		return errors.ResourceValidationError("Container", c.Name, "container", err.Error(), nil)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestValidateObject(t *testing.T) {
+	valid := CreateDeployment("web", "default")
+	if err := AddDeploymentContainer(valid, &corev1.Container{
+		Name:  "app",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateObject(valid); err != nil {
+		t.Errorf("expected valid Deployment, got %v", err)
+	}
+	if err := ValidateObject(CreateCronJob("backup", "default", "*/15 2-4 * JAN-MAR mon")); err != nil {
+		t.Errorf("expected valid CronJob, got %v", err)
+	}
+
+	badPort := CreateService("web", "default")
+	AddServicePort(badPort, corev1.ServicePort{Name: "http", Port: 70000})
+	badContainer := CreateStatefulSet("db", "default")
+	badContainer.Spec.Template.Spec.Containers = []corev1.Container{{Name: "DB_Main"}}
+	badLabel := CreateConfigMap("cfg", "default")
+	badLabel.Labels["bad key"] = "x"
+	longValue := CreateConfigMap("cfg", "default")
+	longValue.Labels["k"] = strings.Repeat("v", 64)
+	badAnnotation := CreateConfigMap("cfg", "default")
+	badAnnotation.Annotations["/x"] = "y"
+
+	tests := map[string]client.Object{
+		"upper case name":        CreateConfigMap("MyConfig", "default"),
+		"namespace with dots":    CreateConfigMap("cfg", "team.payments"),
+		"service port range":     badPort,
+		"container name":         badContainer,
+		"cron schedule":          CreateCronJob("backup", "default", "0 25 * * *"),
+		"label key":              badLabel,
+		"label value too long":   longValue,
+		"annotation key invalid": badAnnotation,
+	}
+	for name, obj := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateObject(obj); err == nil {
+				t.Errorf("expected validation error for %s", obj.GetName())
+			}
+		})
+	}
+
+	if err := ValidateObject(nil); err == nil {
+		t.Error("expected error for a nil object")
+	}
+}
+
+func TestValidateCronSchedule(t *testing.T) {
+	for _, schedule := range []string{"@daily", "0 0 * * *", "*/5 * * * *", "0 9-17 * * 1-5", "30 2 1,15 * ?", "0 0 * * SUN"} {
+		if err := ValidateCronSchedule(schedule); err != nil {
+			t.Errorf("ValidateCronSchedule(%q): %v", schedule, err)
+		}
+	}
+	for _, schedule := range []string{"", "@often", "* * * *", "60 * * * *", "0 0 0 * *", "*/0 * * * *", "0 0 * * 8", "CRON_TZ=UTC 0 0 * * *", "0 0 * * FOO"} {
+		if err := ValidateCronSchedule(schedule); err == nil {
+			t.Errorf("ValidateCronSchedule(%q): expected error", schedule)
+		}
+	}
+}
+
+func TestValidator(t *testing.T) {
+	var v Validator
+	bad := &corev1.Container{Name: "Bad_Name"}
+
+	dep := CreateDeployment("web", "default")
+	if err := AddDeploymentContainer(dep, bad); err != nil {
+		t.Fatalf("package-level helpers must accept any container, got %v", err)
+	}
+
+	dep, err := v.CreateDeployment("web", "default")
+	if err != nil {
+		t.Fatalf("CreateDeployment: %v", err)
+	}
+	if err := v.AddDeploymentContainer(dep, bad); err == nil {
+		t.Error("AddDeploymentContainer: expected error")
+	}
+	if len(dep.Spec.Template.Spec.Containers) != 0 {
+		t.Error("invalid container must not be added")
+	}
+	if err := v.AddDeploymentInitContainer(dep, &corev1.Container{
+		Name:  "init",
+		Ports: []corev1.ContainerPort{{ContainerPort: 0}},
+	}); err == nil {
+		t.Error("AddDeploymentInitContainer: expected port error")
+	}
+	if err := v.AddDeploymentContainer(dep, &corev1.Container{Name: "app"}); err != nil {
+		t.Errorf("valid container rejected: %v", err)
+	}
+	if err := v.AddDeploymentContainer(nil, &corev1.Container{Name: "app"}); err == nil {
+		t.Error("expected error for a nil Deployment")
+	}
+
+	adders := map[string]func(*corev1.Container) error{
+		"PodSpec":     func(c *corev1.Container) error { return v.AddPodSpecContainer(CreatePodSpec(), c) },
+		"PodSpecInit": func(c *corev1.Container) error { return v.AddPodSpecInitContainer(CreatePodSpec(), c) },
+		"StatefulSet": func(c *corev1.Container) error {
+			return v.AddStatefulSetContainer(CreateStatefulSet("db", "default"), c)
+		},
+		"StatefulSetInit": func(c *corev1.Container) error {
+			return v.AddStatefulSetInitContainer(CreateStatefulSet("db", "default"), c)
+		},
+		"DaemonSet": func(c *corev1.Container) error {
+			return v.AddDaemonSetContainer(CreateDaemonSet("agent", "default"), c)
+		},
+		"DaemonSetInit": func(c *corev1.Container) error {
+			return v.AddDaemonSetInitContainer(CreateDaemonSet("agent", "default"), c)
+		},
+		"Job":     func(c *corev1.Container) error { return v.AddJobContainer(CreateJob("migrate", "default"), c) },
+		"JobInit": func(c *corev1.Container) error { return v.AddJobInitContainer(CreateJob("migrate", "default"), c) },
+		"CronJob": func(c *corev1.Container) error {
+			return v.AddCronJobContainer(CreateCronJob("job", "default", "@daily"), c)
+		},
+		"CronJobInit": func(c *corev1.Container) error {
+			return v.AddCronJobInitContainer(CreateCronJob("job", "default", "@daily"), c)
+		},
+		"DeploymentInit": func(c *corev1.Container) error {
+			return v.AddDeploymentInitContainer(CreateDeployment("web", "default"), c)
+		},
+	}
+	for name, add := range adders {
+		t.Run(name, func(t *testing.T) {
+			if err := add(bad); err == nil {
+				t.Error("expected error for an invalid container name")
+			}
+			if err := add(&corev1.Container{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}}); err != nil {
+				t.Errorf("valid container rejected: %v", err)
+			}
+		})
+	}
+
+	if err := v.SetWorkloadLabels(dep, map[string]string{"bad key": "x"}, true); err == nil {
+		t.Error("SetWorkloadLabels: expected error")
+	}
+	if _, ok := dep.Labels["bad key"]; ok {
+		t.Error("invalid label must not be set")
+	}
+	if err := v.SetWorkloadLabels(dep, map[string]string{"tier": "web"}, true); err != nil || dep.Spec.Template.Labels["tier"] != "web" {
+		t.Errorf("SetWorkloadLabels: valid labels not applied, err %v", err)
+	}
+	if err := v.SetWorkloadAnnotations(dep, map[string]string{"/x": "y"}, false); err == nil {
+		t.Error("SetWorkloadAnnotations: expected error")
+	}
+	if err := v.SetWorkloadLabels(nil, map[string]string{"tier": "web"}, false); err == nil {
+		t.Error("SetWorkloadLabels: expected error for a nil object")
+	}
+}
+
+func TestValidatorCreate(t *testing.T) {
+	var v Validator
+	if _, err := v.CreateDeployment("Web", "default"); err == nil {
+		t.Error("CreateDeployment: expected error for an upper case name")
+	}
+	if _, err := v.CreateStatefulSet("db", "team.payments"); err == nil {
+		t.Error("CreateStatefulSet: expected error for an invalid namespace")
+	}
+	if _, err := v.CreateDaemonSet(strings.Repeat("a", 64), "default"); err == nil {
+		t.Error("CreateDaemonSet: expected error for a name too long for the app label")
+	}
+	if _, err := v.CreateJob("migrate_db", "default"); err == nil {
+		t.Error("CreateJob: expected error for an invalid name")
+	}
+	if _, err := v.CreateService("web", "default"); err != nil {
+		t.Errorf("CreateService: %v", err)
+	}
+
+	cron, err := v.CreateCronJob("backup", "default", "0 2 * * *")
+	if err != nil {
+		t.Fatalf("CreateCronJob: %v", err)
+	}
+	if _, err := v.CreateCronJob("backup", "default", "0 25 * * *"); err == nil {
+		t.Error("CreateCronJob: expected error for an invalid schedule")
+	}
+	if err := v.SetCronJobSchedule(cron, "every day"); err == nil {
+		t.Error("SetCronJobSchedule: expected error")
+	}
+	if cron.Spec.Schedule != "0 2 * * *" {
+		t.Errorf("invalid schedule must not be set, got %q", cron.Spec.Schedule)
+	}
+	if err := v.SetCronJobSchedule(cron, "@hourly"); err != nil || cron.Spec.Schedule != "@hourly" {
+		t.Errorf("SetCronJobSchedule: valid schedule not set, err %v", err)
+	}
+
+	svc := CreateService("web", "default")
+	if err := v.AddServicePort(svc, corev1.ServicePort{Name: "http", Port: 70000}); err == nil {
+		t.Error("AddServicePort: expected error for a port out of range")
+	}
+	if err := v.AddServicePort(svc, corev1.ServicePort{Name: "HTTP", Port: 80}); err == nil {
+		t.Error("AddServicePort: expected error for an invalid port name")
+	}
+	if err := v.AddServicePort(svc, corev1.ServicePort{Name: "http", Port: 80}); err != nil || len(svc.Spec.Ports) != 1 {
+		t.Errorf("AddServicePort: valid port not added, err %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package kubernetes
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+)
+
+// CreateVerticalPodAutoscaler creates a new VerticalPodAutoscaler with the
+// given name and namespace. The returned object has TypeMeta, labels, and
+// annotations pre-populated so it can be serialized to YAML immediately.
+func CreateVerticalPodAutoscaler(name, namespace string) *vpav1.VerticalPodAutoscaler {
+	return &vpav1.VerticalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "VerticalPodAutoscaler",
+			APIVersion: vpav1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": name,
+			},
+			Annotations: map[string]string{
+				"app": name,
+			},
+		},
+	}
+}
+
+// SetVPATargetRef sets the workload (e.g. Deployment) whose pods the VPA
+// manages.
+func SetVPATargetRef(vpa *vpav1.VerticalPodAutoscaler, apiVersion, kind, name string) {
+	if vpa == nil {
+		panic("SetVPATargetRef: vpa must not be nil")
+	}
+	vpa.Spec.TargetRef = &autoscalingv1.CrossVersionObjectReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}
+}
+
+// SetVPAUpdateMode sets how recommendations are applied, e.g. "Off" to only
+// compute them or "Recreate" to evict pods with outdated requests.
+func SetVPAUpdateMode(vpa *vpav1.VerticalPodAutoscaler, mode vpav1.UpdateMode) {
+	if vpa == nil {
+		panic("SetVPAUpdateMode: vpa must not be nil")
+	}
+	if vpa.Spec.UpdatePolicy == nil {
+		vpa.Spec.UpdatePolicy = &vpav1.PodUpdatePolicy{}
+	}
+	vpa.Spec.UpdatePolicy.UpdateMode = &mode
+}
+
+// SetVPAMinReplicas sets the minimum number of live replicas required before
+// the updater evicts a pod.
+func SetVPAMinReplicas(vpa *vpav1.VerticalPodAutoscaler, minReplicas int32) {
+	if vpa == nil {
+		panic("SetVPAMinReplicas: vpa must not be nil")
+	}
+	if vpa.Spec.UpdatePolicy == nil {
+		vpa.Spec.UpdatePolicy = &vpav1.PodUpdatePolicy{}
+	}
+	vpa.Spec.UpdatePolicy.MinReplicas = &minReplicas
+}
+
+// CreateVPAContainerPolicy returns a container resource policy bounding the
+// recommendations for containerName ("*" for all containers). Nil bounds
+// leave the recommendation unbounded.
+func CreateVPAContainerPolicy(containerName string, minAllowed, maxAllowed corev1.ResourceList) vpav1.ContainerResourcePolicy {
+	return vpav1.ContainerResourcePolicy{
+		ContainerName: containerName,
+		MinAllowed:    minAllowed,
+		MaxAllowed:    maxAllowed,
+	}
+}
+
+// SetVPAContainerPolicyMode enables or disables autoscaling of a container.
+func SetVPAContainerPolicyMode(policy *vpav1.ContainerResourcePolicy, mode vpav1.ContainerScalingMode) {
+	policy.Mode = &mode
+}
+
+// SetVPAContainerPolicyControlledResources limits which resources are
+// autoscaled for a container.
+func SetVPAContainerPolicyControlledResources(policy *vpav1.ContainerResourcePolicy, resources ...corev1.ResourceName) {
+	policy.ControlledResources = &resources
+}
+
+// SetVPAContainerPolicyControlledValues selects whether only requests or
+// requests and limits are updated for a container.
+func SetVPAContainerPolicyControlledValues(policy *vpav1.ContainerResourcePolicy, values vpav1.ContainerControlledValues) {
+	policy.ControlledValues = &values
+}
+
+// AddVPAContainerPolicy appends a container resource policy to the VPA.
+func AddVPAContainerPolicy(vpa *vpav1.VerticalPodAutoscaler, policy vpav1.ContainerResourcePolicy) {
+	if vpa == nil {
+		panic("AddVPAContainerPolicy: vpa must not be nil")
+	}
+	if vpa.Spec.ResourcePolicy == nil {
+		vpa.Spec.ResourcePolicy = &vpav1.PodResourcePolicy{}
+	}
+	vpa.Spec.ResourcePolicy.ContainerPolicies = append(vpa.Spec.ResourcePolicy.ContainerPolicies, policy)
+}
+
+// SetVPALabels sets the labels on the VPA.
+func SetVPALabels(vpa *vpav1.VerticalPodAutoscaler, labels map[string]string) {
+	if vpa == nil {
+		panic("SetVPALabels: vpa must not be nil")
+	}
+	vpa.Labels = labels
+}
+
+// SetVPAAnnotations sets the annotations on the VPA.
+func SetVPAAnnotations(vpa *vpav1.VerticalPodAutoscaler, annotations map[string]string) {
+	if vpa == nil {
+		panic("SetVPAAnnotations: vpa must not be nil")
+	}
+	vpa.Annotations = annotations
+}
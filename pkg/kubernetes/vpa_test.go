@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+)
+
+func TestCreateVerticalPodAutoscaler(t *testing.T) {
+	vpa := CreateVerticalPodAutoscaler("my-vpa", "default")
+	if vpa.Name != "my-vpa" || vpa.Namespace != "default" {
+		t.Fatalf("metadata mismatch: %s/%s", vpa.Namespace, vpa.Name)
+	}
+	if vpa.Kind != "VerticalPodAutoscaler" || vpa.APIVersion != "autoscaling.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", vpa.APIVersion, vpa.Kind)
+	}
+	if vpa.Labels["app"] != "my-vpa" {
+		t.Errorf("expected label app=my-vpa, got %v", vpa.Labels)
+	}
+}
+
+func TestVPANilErrors(t *testing.T) {
+	assertPanics(t, func() { SetVPATargetRef(nil, "apps/v1", "Deployment", "web") })
+	assertPanics(t, func() { SetVPAUpdateMode(nil, vpav1.UpdateModeOff) })
+	assertPanics(t, func() { SetVPAMinReplicas(nil, 2) })
+	assertPanics(t, func() { AddVPAContainerPolicy(nil, vpav1.ContainerResourcePolicy{}) })
+	assertPanics(t, func() { SetVPALabels(nil, map[string]string{}) })
+	assertPanics(t, func() { SetVPAAnnotations(nil, map[string]string{}) })
+}
+
+func TestVPAFunctions(t *testing.T) {
+	vpa := CreateVerticalPodAutoscaler("web", "default")
+
+	SetVPATargetRef(vpa, "apps/v1", "Deployment", "web")
+	if ref := vpa.Spec.TargetRef; ref == nil || ref.Kind != "Deployment" || ref.Name != "web" {
+		t.Errorf("target ref mismatch: %+v", ref)
+	}
+
+	SetVPAUpdateMode(vpa, vpav1.UpdateModeRecreate)
+	SetVPAMinReplicas(vpa, 2)
+	up := vpa.Spec.UpdatePolicy
+	if up == nil || up.UpdateMode == nil || *up.UpdateMode != vpav1.UpdateModeRecreate {
+		t.Errorf("update mode not set: %+v", up)
+	}
+	if up.MinReplicas == nil || *up.MinReplicas != 2 {
+		t.Errorf("min replicas not set: %+v", up)
+	}
+
+	policy := CreateVPAContainerPolicy("app",
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")})
+	SetVPAContainerPolicyControlledResources(&policy, corev1.ResourceCPU, corev1.ResourceMemory)
+	SetVPAContainerPolicyControlledValues(&policy, vpav1.ContainerControlledValuesRequestsOnly)
+	AddVPAContainerPolicy(vpa, policy)
+
+	sidecar := CreateVPAContainerPolicy("istio-proxy", nil, nil)
+	SetVPAContainerPolicyMode(&sidecar, vpav1.ContainerScalingModeOff)
+	AddVPAContainerPolicy(vpa, sidecar)
+
+	rp := vpa.Spec.ResourcePolicy
+	if rp == nil || len(rp.ContainerPolicies) != 2 {
+		t.Fatalf("container policies not added: %+v", rp)
+	}
+	app := rp.ContainerPolicies[0]
+	if app.ContainerName != "app" || app.MinAllowed.Cpu().String() != "100m" || app.MaxAllowed.Memory().String() != "1Gi" {
+		t.Errorf("unexpected container policy %+v", app)
+	}
+	if app.ControlledResources == nil || len(*app.ControlledResources) != 2 {
+		t.Errorf("controlled resources not set")
+	}
+	if app.ControlledValues == nil || *app.ControlledValues != vpav1.ContainerControlledValuesRequestsOnly {
+		t.Errorf("controlled values not set")
+	}
+	if m := rp.ContainerPolicies[1].Mode; m == nil || *m != vpav1.ContainerScalingModeOff {
+		t.Errorf("container scaling mode not set")
+	}
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// SetWorkloadLabels merges labels into the metadata of a Deployment,
+// StatefulSet, DaemonSet, Job or CronJob. With propagateToPodTemplate the
+// labels are also merged into the pod template, so the pods carry them.
+// Existing keys are overwritten; other keys, including those used by the
+// workload's selector, are kept.
+func SetWorkloadLabels(obj client.Object, labels map[string]string, propagateToPodTemplate bool) error {
+	tmpl, err := workloadPodTemplate(obj)
+	if err != nil {
+		return err
+	}
+	obj.SetLabels(mergeStringMap(obj.GetLabels(), labels))
+	if propagateToPodTemplate {
+		tmpl.Labels = mergeStringMap(tmpl.Labels, labels)
+	}
+	return nil
+}
+
+// SetWorkloadAnnotations merges annotations into the metadata of a
+// Deployment, StatefulSet, DaemonSet, Job or CronJob and, with
+// propagateToPodTemplate, into its pod template.
+func SetWorkloadAnnotations(obj client.Object, annotations map[string]string, propagateToPodTemplate bool) error {
+	tmpl, err := workloadPodTemplate(obj)
+	if err != nil {
+		return err
+	}
+	obj.SetAnnotations(mergeStringMap(obj.GetAnnotations(), annotations))
+	if propagateToPodTemplate {
+		tmpl.Annotations = mergeStringMap(tmpl.Annotations, annotations)
+	}
+	return nil
+}
+
+// workloadPodTemplate returns the pod template of a supported workload.
+func workloadPodTemplate(obj runtime.Object) (*corev1.PodTemplateSpec, error) {
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		if w != nil {
+			return &w.Spec.Template, nil
+		}
+	case *appsv1.StatefulSet:
+		if w != nil {
+			return &w.Spec.Template, nil
+		}
+	case *appsv1.DaemonSet:
+		if w != nil {
+			return &w.Spec.Template, nil
+		}
+	case *batchv1.Job:
+		if w != nil {
+			return &w.Spec.Template, nil
+		}
+	case *batchv1.CronJob:
+		if w != nil {
+			return &w.Spec.JobTemplate.Spec.Template, nil
+		}
+	case nil:
+	default:
+		return nil, errors.Errorf("unsupported workload type %T", obj)
+	}
+	return nil, errors.ErrNilObject
+}
+
+// mergeStringMap copies src into dst, allocating dst when needed.
+func mergeStringMap(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	maps.Copy(dst, src)
+	return dst
+}
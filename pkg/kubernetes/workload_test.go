@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kerrors "github.com/go-kure/kure/pkg/errors"
+)
+
+func TestSetWorkloadLabels(t *testing.T) {
+	labels := map[string]string{"team": "payments", "env": "prod"}
+	dep := CreateDeployment("web", "default")
+	sts := CreateStatefulSet("db", "default")
+	ds := CreateDaemonSet("agent", "default")
+	job := CreateJob("once", "default")
+	cj := CreateCronJob("nightly", "default", "@daily")
+	workloads := map[string]struct {
+		obj  client.Object
+		tmpl *corev1.PodTemplateSpec
+	}{
+		"Deployment":  {dep, &dep.Spec.Template},
+		"StatefulSet": {sts, &sts.Spec.Template},
+		"DaemonSet":   {ds, &ds.Spec.Template},
+		"Job":         {job, &job.Spec.Template},
+		"CronJob":     {cj, &cj.Spec.JobTemplate.Spec.Template},
+	}
+
+	for kind, w := range workloads {
+		t.Run(kind, func(t *testing.T) {
+			appLabel := w.obj.GetLabels()["app"]
+			if err := SetWorkloadLabels(w.obj, labels, true); err != nil {
+				t.Fatalf("SetWorkloadLabels: %v", err)
+			}
+			got := w.obj.GetLabels()
+			if got["team"] != "payments" || got["env"] != "prod" {
+				t.Errorf("labels not merged: %v", got)
+			}
+			if got["app"] != appLabel {
+				t.Errorf("existing label app=%q lost: %v", appLabel, got)
+			}
+			if tmpl := w.tmpl; tmpl.Labels["team"] != "payments" {
+				t.Errorf("labels not propagated to pod template: %v", tmpl.Labels)
+			}
+		})
+	}
+}
+
+func TestSetWorkloadLabelsWithoutPropagation(t *testing.T) {
+	dep := CreateDeployment("web", "default")
+	before := len(dep.Spec.Template.Labels)
+	if err := SetWorkloadLabels(dep, map[string]string{"team": "payments"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if dep.Labels["team"] != "payments" {
+		t.Errorf("label not set: %v", dep.Labels)
+	}
+	if _, ok := dep.Spec.Template.Labels["team"]; ok || len(dep.Spec.Template.Labels) != before {
+		t.Errorf("pod template labels changed: %v", dep.Spec.Template.Labels)
+	}
+}
+
+func TestSetWorkloadAnnotations(t *testing.T) {
+	sts := CreateStatefulSet("db", "default")
+	annotations := map[string]string{"backup.example.com/enabled": "true"}
+	if err := SetWorkloadAnnotations(sts, annotations, true); err != nil {
+		t.Fatal(err)
+	}
+	if sts.Annotations["backup.example.com/enabled"] != "true" {
+		t.Errorf("annotation not set: %v", sts.Annotations)
+	}
+	if sts.Spec.Template.Annotations["backup.example.com/enabled"] != "true" {
+		t.Errorf("annotation not propagated: %v", sts.Spec.Template.Annotations)
+	}
+}
+
+func TestSetWorkloadLabelsErrors(t *testing.T) {
+	var dep *appsv1.Deployment
+	if err := SetWorkloadLabels(dep, map[string]string{"a": "b"}, true); !errors.Is(err, kerrors.ErrNilObject) {
+		t.Errorf("expected ErrNilObject, got %v", err)
+	}
+	if err := SetWorkloadAnnotations(CreateConfigMap("cfg", "default"), nil, false); err == nil {
+		t.Error("expected error for an unsupported kind")
+	}
+}
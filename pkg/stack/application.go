@@ -11,6 +11,17 @@ type Application struct {
 	Name      string
 	Namespace string
 	Config    ApplicationConfig
+	// Frozen excludes the application from regeneration: layout writers
+	// keep the files produced by a previous run instead of overwriting them.
+	// The AnnotationFreezeKey and AnnotationSkipKey annotations have the
+	// same effect.
+	Frozen bool
+	// Annotations carries kure directives for the application. They are not
+	// copied to the generated resources.
+	Annotations map[string]string
+	// Cache, when set, memoises the output of Config.Generate by a content
+	// hash of the configuration. See GenerateCache.
+	Cache *GenerateCache
 }
 
 // ApplicationConfig describes the behaviour of specific application types.
@@ -40,9 +51,28 @@ func (a *Application) SetNamespace(ns string) { a.Namespace = ns }
 // SetConfig replaces the application configuration.
 func (a *Application) SetConfig(cfg ApplicationConfig) { a.Config = cfg }
 
+// SetFrozen marks the application as frozen or unfrozen.
+func (a *Application) SetFrozen(frozen bool) { a.Frozen = frozen }
+
+// SetAnnotations replaces the application annotations.
+func (a *Application) SetAnnotations(annotations map[string]string) { a.Annotations = annotations }
+
+// IsFrozen reports whether the application is frozen, either through the
+// Frozen field or the AnnotationFreezeKey or AnnotationSkipKey annotation.
+func (a *Application) IsFrozen() bool {
+	if a == nil {
+		return false
+	}
+	return a.Frozen || frozenByAnnotation(a.Annotations)
+}
+
+// SetCache sets the cache used by Generate. Pass nil to disable caching.
+func (a *Application) SetCache(cache *GenerateCache) { a.Cache = cache }
+
 // Generate returns the resources for this application.
 // If the Config implements the Validator interface, Validate() is called
-// before Generate(). A validation error stops generation immediately.
+// before Generate(). A validation error stops generation immediately. When a
+// Cache is set, unchanged configs are served from it.
 func (a *Application) Generate() ([]*client.Object, error) {
 	if a.Config == nil {
 		return nil, errors.NewValidationError("application.config", "nil", "Required", []string{"non-nil application config"})
@@ -56,5 +86,8 @@ func (a *Application) Generate() ([]*client.Object, error) {
 		}
 	}
 
+	if a.Cache != nil {
+		return a.Cache.generate(a)
+	}
 	return a.Config.Generate(a)
 }
@@ -141,3 +141,29 @@ func TestApplicationValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestApplicationIsFrozen(t *testing.T) {
+	var nilApp *Application
+	if nilApp.IsFrozen() {
+		t.Error("nil application should not be frozen")
+	}
+	app := NewApplication("web", "apps", nil)
+	if app.IsFrozen() {
+		t.Error("new application should not be frozen")
+	}
+	app.SetFrozen(true)
+	if !app.IsFrozen() {
+		t.Error("expected Frozen field to freeze the application")
+	}
+	app.SetFrozen(false)
+	for _, key := range []string{AnnotationFreezeKey, AnnotationSkipKey} {
+		app.SetAnnotations(map[string]string{key: "true"})
+		if !app.IsFrozen() {
+			t.Errorf("expected %s annotation to freeze the application", key)
+		}
+	}
+	app.SetAnnotations(map[string]string{AnnotationSkipKey: "false"})
+	if app.IsFrozen() {
+		t.Error("expected annotation value false to be ignored")
+	}
+}
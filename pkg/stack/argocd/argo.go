@@ -2,6 +2,7 @@ package argocd
 
 import (
 	"path/filepath"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +28,11 @@ type WorkflowEngine struct {
 	RepoURL string
 	// DefaultNamespace is the default namespace for ArgoCD Applications
 	DefaultNamespace string
+	// PathPrefix is prepended to the layout paths used as spec.source.path
+	// by IntegrateWithLayout and must match the layout.Config ManifestsDir
+	// the layout is written with. Empty means "clusters", the ManifestsDir
+	// default; use "." for a layout written at the repository root.
+	PathPrefix string
 }
 
 // Engine creates an ArgoCD workflow engine.
@@ -82,6 +88,16 @@ func (w *WorkflowEngine) GenerateFromBundle(b *stack.Bundle) ([]client.Object, e
 		return nil, nil
 	}
 
+	app, err := w.createApplication(b, w.bundlePath(b))
+	if err != nil {
+		return nil, err
+	}
+	return []client.Object{app}, nil
+}
+
+// createApplication creates an ArgoCD Application for b whose source points
+// at path.
+func (w *WorkflowEngine) createApplication(b *stack.Bundle, path string) (client.Object, error) {
 	app := &unstructured.Unstructured{}
 	app.SetAPIVersion("argoproj.io/v1alpha1")
 	app.SetKind("Application")
@@ -96,7 +112,7 @@ func (w *WorkflowEngine) GenerateFromBundle(b *stack.Bundle) ([]client.Object, e
 	// Configure source
 	source := map[string]any{
 		"repoURL": w.RepoURL,
-		"path":    w.bundlePath(b),
+		"path":    path,
 	}
 
 	// Configure destination
@@ -124,20 +140,139 @@ func (w *WorkflowEngine) GenerateFromBundle(b *stack.Bundle) ([]client.Object, e
 		}
 	}
 
-	var obj client.Object = app
-	return []client.Object{obj}, nil
+	return app, nil
 }
 
 // LayoutIntegrator interface implementation
 
-// IntegrateWithLayout adds ArgoCD Applications to an existing manifest layout.
-// For ArgoCD, this is typically not needed as Applications reference external repos.
+// IntegrateWithLayout adds ArgoCD Applications to an existing manifest layout
+// according to rules.ArgoPlacement. Every bundle gets one Application whose
+// spec.source.path is the repository path of the directory holding the
+// bundle's manifests, without the `./` prefix Flux uses. ArgoUnset leaves the
+// layout unchanged, so Flux-only layouts are unaffected.
+//
+// ArgoSeparate collects the Applications in an argocd child layout of ml.
+// ArgoIntegrated places each Application in the parent layout of the
+// directory it targets, so no Application syncs the directory it lives in.
+// A bundle whose directory has no parent layout, such as the root bundle of
+// a walk without ClusterName, is reported as an error in that mode.
 func (w *WorkflowEngine) IntegrateWithLayout(ml *layout.ManifestLayout, c *stack.Cluster, rules layout.LayoutRules) error {
-	// ArgoCD Applications typically don't need layout integration
-	// as they reference external repositories
+	if ml == nil || c == nil || c.Node == nil || rules.ArgoPlacement == layout.ArgoUnset {
+		return nil
+	}
+
+	switch rules.ArgoPlacement {
+	case layout.ArgoSeparate:
+		var apps []client.Object
+		err := w.walkBundleLayouts(ml, c.Node, func(b *stack.Bundle, target, _ *layout.ManifestLayout) error {
+			app, err := w.createApplication(b, w.sourcePath(target))
+			if err != nil {
+				return err
+			}
+			apps = append(apps, app)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(apps) > 0 {
+			ml.Children = append(ml.Children, &layout.ManifestLayout{
+				Name:       "argocd",
+				Namespace:  filepath.Join(ml.Namespace, "argocd"),
+				FilePer:    layout.FilePerResource,
+				FileNaming: ml.FileNaming,
+				Mode:       layout.KustomizationExplicit,
+				Resources:  apps,
+			})
+		}
+		return nil
+	case layout.ArgoIntegrated:
+		return w.walkBundleLayouts(ml, c.Node, func(b *stack.Bundle, target, parent *layout.ManifestLayout) error {
+			app, err := w.createApplication(b, w.sourcePath(target))
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return errors.ResourceValidationError("Bundle", b.Name, "argoPlacement",
+					"ArgoIntegrated needs a parent directory for the bundle's Application; set LayoutRules.ClusterName or use ArgoSeparate", nil)
+			}
+			parent.Resources = append(parent.Resources, app)
+			return nil
+		})
+	default:
+		return errors.NewValidationError("argoPlacement", string(rules.ArgoPlacement), "LayoutRules",
+			[]string{string(layout.ArgoSeparate), string(layout.ArgoIntegrated)})
+	}
+}
+
+// walkBundleLayouts calls fn for every bundle of n and its descendants with
+// the layout holding the bundle's manifests and that layout's parent (nil at
+// the root). Bundles with their own directory (BundleGrouping GroupByName)
+// target that directory; flat bundles target their node's directory.
+func (w *WorkflowEngine) walkBundleLayouts(root *layout.ManifestLayout, n *stack.Node, fn func(b *stack.Bundle, target, parent *layout.ManifestLayout) error) error {
+	if n.Bundle != nil {
+		target, parent := findLayoutByPath(root, nil, n.GetPath(), "")
+		if target == nil {
+			target = layout.FindByNodeAlias(root, n.GetPath())
+		}
+		if target == nil {
+			return errors.ResourceValidationError("Node", n.Name, "layout",
+				"corresponding layout node not found", nil)
+		}
+		for _, child := range target.Children {
+			if child.Name == n.Bundle.Name && !child.UmbrellaChild {
+				target, parent = child, target
+				break
+			}
+		}
+		if err := fn(n.Bundle, target, parent); err != nil {
+			return err
+		}
+	}
+	for _, child := range n.Children {
+		if err := w.walkBundleLayouts(root, child, fn); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// findLayoutByPath returns the layout whose accumulated name path equals
+// targetPath, together with its parent layout.
+func findLayoutByPath(ml, parent *layout.ManifestLayout, targetPath, parentPath string) (*layout.ManifestLayout, *layout.ManifestLayout) {
+	currentPath := ml.Name
+	if parentPath != "" && ml.Name != "" {
+		currentPath = parentPath + "/" + ml.Name
+	} else if parentPath != "" {
+		currentPath = parentPath
+	}
+	if currentPath == targetPath {
+		return ml, parent
+	}
+	for _, child := range ml.Children {
+		if found, p := findLayoutByPath(child, ml, targetPath, currentPath); found != nil {
+			return found, p
+		}
+	}
+	return nil, nil
+}
+
+// sourcePath returns the ArgoCD spec.source.path for ml: its repository path
+// joined to PathPrefix, without a leading `./`. It mirrors the directory
+// layout.WriteManifest writes ml to.
+func (w *WorkflowEngine) sourcePath(ml *layout.ManifestLayout) string {
+	prefix := w.PathPrefix
+	if prefix == "" {
+		prefix = "clusters"
+	}
+	dir := ml.FullRepoPath()
+	if ml.ApplicationFileMode == layout.AppFileSingle {
+		dir = ml.Namespace
+	}
+	p := filepath.ToSlash(filepath.Join(prefix, dir))
+	return strings.TrimPrefix(p, "./")
+}
+
 // CreateLayoutWithResources creates a new layout that includes ArgoCD Applications.
 func (w *WorkflowEngine) CreateLayoutWithResources(c *stack.Cluster, rulesInterface stack.LayoutRulesProvider) (stack.ManifestLayoutResult, error) {
 	rules, ok := rulesInterface.(layout.LayoutRules)
@@ -150,6 +285,13 @@ func (w *WorkflowEngine) CreateLayoutWithResources(c *stack.Cluster, rulesInterf
 		return nil, err
 	}
 
+	if rules.ArgoPlacement != layout.ArgoUnset {
+		if err := w.IntegrateWithLayout(ml, c, rules); err != nil {
+			return nil, err
+		}
+		return ml, nil
+	}
+
 	// For ArgoCD, we typically create a separate argocd directory for Applications
 	apps, err := w.GenerateFromCluster(c)
 	if err != nil {
@@ -210,6 +352,12 @@ func (w *WorkflowEngine) SetDefaultNamespace(namespace string) {
 	w.DefaultNamespace = namespace
 }
 
+// SetPathPrefix configures the prefix prepended to layout paths in
+// spec.source.path.
+func (w *WorkflowEngine) SetPathPrefix(prefix string) {
+	w.PathPrefix = prefix
+}
+
 // bundlePath builds a repository path for the bundle based on its ancestry.
 func (w *WorkflowEngine) bundlePath(b *stack.Bundle) string {
 	var parts []string
@@ -569,3 +569,154 @@ func TestCreateLayoutWithResources_InvalidCluster(t *testing.T) {
 type badArgoRules struct{}
 
 func (badArgoRules) Validate() error { return nil }
+
+func newArgoPlacementCluster() *stack.Cluster {
+	root := &stack.Node{Name: "root", Bundle: &stack.Bundle{Name: "infra"}}
+	apps := &stack.Node{Name: "apps", Bundle: &stack.Bundle{Name: "web"}}
+	apps.SetParent(root)
+	root.Children = []*stack.Node{apps}
+	return &stack.Cluster{Name: "prod", Node: root}
+}
+
+func sourcePathOf(t *testing.T, obj any) string {
+	t.Helper()
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		t.Fatalf("expected unstructured Application, got %T", obj)
+	}
+	path, _, _ := unstructured.NestedString(u.Object, "spec", "source", "path")
+	return path
+}
+
+func TestIntegrateWithLayout_ArgoSeparate(t *testing.T) {
+	engine := Engine()
+	engine.SetPathPrefix("clusters")
+	cluster := newArgoPlacementCluster()
+	rules := layout.LayoutRules{ClusterName: "prod", ArgoPlacement: layout.ArgoSeparate}
+
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if err := engine.IntegrateWithLayout(ml, cluster, rules); err != nil {
+		t.Fatalf("IntegrateWithLayout: %v", err)
+	}
+
+	var argoLayout *layout.ManifestLayout
+	for _, child := range ml.Children {
+		if child.Name == "argocd" {
+			argoLayout = child
+		}
+	}
+	if argoLayout == nil {
+		t.Fatal("expected argocd child layout")
+	}
+	if len(argoLayout.Resources) != 2 {
+		t.Fatalf("expected 2 Applications, got %d", len(argoLayout.Resources))
+	}
+	want := map[string]string{"infra": "clusters/prod/root", "web": "clusters/prod/root/apps"}
+	for _, obj := range argoLayout.Resources {
+		if got := sourcePathOf(t, obj); got != want[obj.GetName()] {
+			t.Errorf("%s: spec.source.path = %q, want %q", obj.GetName(), got, want[obj.GetName()])
+		}
+	}
+}
+
+func TestIntegrateWithLayout_ArgoIntegrated(t *testing.T) {
+	engine := Engine()
+	cluster := newArgoPlacementCluster()
+	rules := layout.LayoutRules{ClusterName: "prod", ArgoPlacement: layout.ArgoIntegrated}
+
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if err := engine.IntegrateWithLayout(ml, cluster, rules); err != nil {
+		t.Fatalf("IntegrateWithLayout: %v", err)
+	}
+
+	// cluster layout -> root -> apps
+	if len(ml.Resources) != 1 || ml.Resources[0].GetName() != "infra" {
+		t.Fatalf("expected the root bundle Application in the cluster layout, got %v", ml.Resources)
+	}
+	rootLayout := ml.Children[0]
+	var web any
+	for _, obj := range rootLayout.Resources {
+		if obj.GetName() == "web" {
+			web = obj
+		}
+	}
+	if web == nil {
+		t.Fatal("expected the child bundle Application in its parent layout")
+	}
+	if got := sourcePathOf(t, web); got != "clusters/prod/root/apps" {
+		t.Errorf("spec.source.path = %q, want %q", got, "clusters/prod/root/apps")
+	}
+}
+
+func TestIntegrateWithLayout_ArgoIntegratedRootWithoutParent(t *testing.T) {
+	engine := Engine()
+	cluster := newArgoPlacementCluster()
+	rules := layout.LayoutRules{ArgoPlacement: layout.ArgoIntegrated}
+
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	// Without ClusterName the root bundle's directory is the top of the
+	// layout; its Application would sync the directory it lives in.
+	if err := engine.IntegrateWithLayout(ml, cluster, rules); err == nil {
+		t.Fatal("expected an error for the root bundle without a parent directory")
+	}
+}
+
+func TestIntegrateWithLayout_PathPrefix(t *testing.T) {
+	cluster := newArgoPlacementCluster()
+	rules := layout.LayoutRules{ClusterName: "prod", ArgoPlacement: layout.ArgoSeparate}
+
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"", "clusters/prod/root/apps"},
+		{"gitops", "gitops/prod/root/apps"},
+		{".", "prod/root/apps"},
+	}
+	for _, tt := range tests {
+		engine := Engine()
+		engine.SetPathPrefix(tt.prefix)
+		ml, err := layout.WalkCluster(cluster, rules)
+		if err != nil {
+			t.Fatalf("walk cluster: %v", err)
+		}
+		if err := engine.IntegrateWithLayout(ml, cluster, rules); err != nil {
+			t.Fatalf("IntegrateWithLayout: %v", err)
+		}
+		argoLayout := ml.Children[len(ml.Children)-1]
+		for _, obj := range argoLayout.Resources {
+			if obj.GetName() == "web" {
+				if got := sourcePathOf(t, obj); got != tt.want {
+					t.Errorf("prefix %q: spec.source.path = %q, want %q", tt.prefix, got, tt.want)
+				}
+			}
+		}
+	}
+}
+
+func TestIntegrateWithLayout_ArgoUnsetIsNoOp(t *testing.T) {
+	engine := Engine()
+	cluster := newArgoPlacementCluster()
+	rules := layout.LayoutRules{ClusterName: "prod"}
+
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	children := len(ml.Children)
+	if err := engine.IntegrateWithLayout(ml, cluster, rules); err != nil {
+		t.Fatalf("IntegrateWithLayout: %v", err)
+	}
+	if len(ml.Children) != children || len(ml.Resources) != 0 {
+		t.Error("expected layout to be unchanged without ArgoPlacement")
+	}
+}
@@ -77,8 +77,10 @@ func deepCopyCluster(c *Cluster) *Cluster {
 		return nil
 	}
 	newCluster := &Cluster{
-		Name:   c.Name,
-		GitOps: c.GitOps, // shared; GitOps is set-once, not mutated
+		Name:       c.Name,
+		GitOps:     c.GitOps,     // shared; GitOps is set-once, not mutated
+		Decryption: c.Decryption, // shared; set-once like GitOps
+		PostBuild:  c.PostBuild,  // shared; set-once like GitOps
 	}
 	if c.Node != nil {
 		newCluster.Node = deepCopyNode(c.Node)
@@ -95,6 +97,8 @@ func deepCopyNode(n *Node) *Node {
 		Name:       n.Name,
 		ParentPath: n.ParentPath,
 		PackageRef: n.PackageRef, // GVK is effectively immutable
+		Decryption: n.Decryption, // shared; set-once
+		Tenancy:    n.Tenancy,    // shared; set-once
 	}
 	if n.Bundle != nil {
 		newNode.Bundle = deepCopyBundle(n.Bundle)
@@ -141,6 +145,7 @@ func deepCopyBundle(b *Bundle) *Bundle {
 		Wait:          b.Wait,
 		Timeout:       b.Timeout,
 		RetryInterval: b.RetryInterval,
+		Frozen:        b.Frozen,
 	}
 	// Shallow copy: allocate a new slice so appends in the copy do not affect
 	// the original, but the *Application pointers themselves are shared.
@@ -16,6 +16,11 @@ const (
 	// AnnotationFluxPruneDisabled is the value that prevents a resource from
 	// being pruned during Flux garbage collection.
 	AnnotationFluxPruneDisabled = "disabled"
+	// AnnotationFreezeKey marks a Bundle or Application as frozen when set
+	// to "true" in its Annotations. See Bundle.Frozen.
+	AnnotationFreezeKey = "kure.dev/freeze"
+	// AnnotationSkipKey has the same effect as AnnotationFreezeKey.
+	AnnotationSkipKey = "kure.dev/skip"
 )
 
 // Bundle represents a unit of deployment, typically the resources that
@@ -72,6 +77,12 @@ type Bundle struct {
 	Patches []Patch
 	// PostBuild configures variable substitution performed after kustomize build.
 	PostBuild *PostBuild
+	// Frozen excludes every application in the bundle from regeneration:
+	// layout writers keep the files produced by a previous run instead of
+	// overwriting them, so manual hotfixes in the repository survive. The
+	// AnnotationFreezeKey and AnnotationSkipKey annotations have the same
+	// effect.
+	Frozen bool
 
 	// Internal fields for runtime hierarchy navigation (not serialized)
 	parent  *Bundle            `yaml:"-"` // Runtime parent reference for efficient traversal
@@ -289,14 +300,55 @@ func (a *Bundle) InitializeUmbrella() {
 	}
 }
 
+// Generate returns the resources of every application in the bundle with the
+// bundle's labels and annotations propagated onto them. It is equivalent to
+// GenerateWithWarnings(nil).
 func (a *Bundle) Generate() ([]*client.Object, error) {
+	return a.GenerateWithWarnings(nil)
+}
+
+// GenerateWithWarnings behaves like Generate and reports non-fatal findings
+// to warnings: nil applications and nil objects that were skipped, and
+// bundle labels or annotations that were not applied because the
+// application already set a different value. A nil collector discards them.
+func (a *Bundle) GenerateWithWarnings(warnings *errors.Warnings) ([]*client.Object, error) {
+	return a.generate(warnings, nil)
+}
+
+// GenerateWithIndex behaves like GenerateWithWarnings and additionally
+// returns a ManifestIndex mapping each application to the objects it
+// produced.
+func (a *Bundle) GenerateWithIndex(warnings *errors.Warnings) ([]*client.Object, *ManifestIndex, error) {
+	index := &ManifestIndex{Bundle: a.Name, Applications: []ApplicationIndex{}}
+	resources, err := a.generate(warnings, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resources, index, nil
+}
+
+// generate renders the bundle's applications, recording each application's
+// objects in index when it is non-nil.
+func (a *Bundle) generate(warnings *errors.Warnings, index *ManifestIndex) ([]*client.Object, error) {
 	var resources []*client.Object
-	for _, app := range a.Applications {
+	for i, app := range a.Applications {
+		if app == nil {
+			warnings.Addf("Bundle", a.Name, "skipped nil application at index %d", i)
+			continue
+		}
 		addresources, err := app.Generate()
 		if err != nil {
 			return nil, err
 		}
-		resources = append(resources, addresources...)
+		index.Add(app)
+		for j, r := range addresources {
+			if r == nil || *r == nil {
+				warnings.Addf("Bundle", a.Name, "skipped nil object at index %d returned by application %q", j, app.Name)
+				continue
+			}
+			resources = append(resources, r)
+			index.Add(app, *r)
+		}
 	}
 
 	// Propagate bundle labels to all generated resources.
@@ -310,8 +362,10 @@ func (a *Bundle) Generate() ([]*client.Object, error) {
 				labels = make(map[string]string, len(a.Labels))
 			}
 			for k, v := range a.Labels {
-				if _, exists := labels[k]; !exists {
+				if existing, exists := labels[k]; !exists {
 					labels[k] = v
+				} else if existing != v {
+					warnings.Addf("Bundle", a.Name, "label %q not applied to %s: resource value %q takes precedence", k, objectRef(*r), existing)
 				}
 			}
 			(*r).SetLabels(labels)
@@ -327,8 +381,10 @@ func (a *Bundle) Generate() ([]*client.Object, error) {
 				annotations = make(map[string]string, len(a.Annotations))
 			}
 			for k, v := range a.Annotations {
-				if _, exists := annotations[k]; !exists {
+				if existing, exists := annotations[k]; !exists {
 					annotations[k] = v
+				} else if existing != v {
+					warnings.Addf("Bundle", a.Name, "annotation %q not applied to %s: resource value %q takes precedence", k, objectRef(*r), existing)
 				}
 			}
 			(*r).SetAnnotations(annotations)
@@ -338,6 +394,26 @@ func (a *Bundle) Generate() ([]*client.Object, error) {
 	return resources, nil
 }
 
+// objectRef formats obj as kind/name for use in warnings.
+func objectRef(obj client.Object) string {
+	return fmt.Sprintf("%s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+}
+
+// IsFrozen reports whether the bundle is frozen, either through the Frozen
+// field or the AnnotationFreezeKey or AnnotationSkipKey annotation.
+func (b *Bundle) IsFrozen() bool {
+	if b == nil {
+		return false
+	}
+	return b.Frozen || frozenByAnnotation(b.Annotations)
+}
+
+// frozenByAnnotation reports whether annotations set AnnotationFreezeKey or
+// AnnotationSkipKey to "true".
+func frozenByAnnotation(annotations map[string]string) bool {
+	return annotations[AnnotationFreezeKey] == "true" || annotations[AnnotationSkipKey] == "true"
+}
+
 // GetParent returns the runtime parent reference (may be nil).
 func (b *Bundle) GetParent() *Bundle {
 	return b.parent
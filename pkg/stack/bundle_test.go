@@ -2,11 +2,14 @@ package stack
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kerrors "github.com/go-kure/kure/pkg/errors"
 )
 
 // TestBundleValidate exercises the Bundle validation logic against
@@ -71,6 +74,56 @@ func TestBundleGenerate(t *testing.T) {
 	}
 }
 
+func TestBundleGenerateWithWarnings(t *testing.T) {
+	obj := client.Object(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        "pod1",
+		Labels:      map[string]string{"env": "staging"},
+		Annotations: map[string]string{"owner": "team-a"},
+	}})
+	app := NewApplication("app1", "ns1", &fakeConfig{objs: []*client.Object{&obj, nil}})
+	b := &Bundle{
+		Name:         "test",
+		Applications: []*Application{nil, app},
+		Labels:       map[string]string{"env": "prod", "tier": "web"},
+		Annotations:  map[string]string{"owner": "team-b"},
+	}
+
+	warnings := &kerrors.Warnings{}
+	resources, err := b.GenerateWithWarnings(warnings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+
+	items := warnings.Items()
+	if len(items) != 4 {
+		t.Fatalf("expected 4 warnings, got %d: %v", len(items), items)
+	}
+	for _, want := range []string{"nil application", "nil object", `label "env"`, `annotation "owner"`} {
+		found := false
+		for _, w := range items {
+			if w.Component == "Bundle" && w.Path == "test" && strings.Contains(w.Message, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected a warning containing %q, got %v", want, items)
+		}
+	}
+
+	// Generate discards warnings but still skips nil entries.
+	resources, err = b.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource from Generate, got %d", len(resources))
+	}
+}
+
 func TestBundleGenerateLabelPropagation(t *testing.T) {
 	t.Run("labels merged into resources with no labels", func(t *testing.T) {
 		obj := client.Object(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}})
@@ -773,3 +826,28 @@ func TestNewBundle_ValidationError(t *testing.T) {
 		t.Fatal("expected error for empty name")
 	}
 }
+
+func TestBundleIsFrozen(t *testing.T) {
+	var nilBundle *Bundle
+	if nilBundle.IsFrozen() {
+		t.Error("nil bundle should not be frozen")
+	}
+	if (&Bundle{}).IsFrozen() {
+		t.Error("zero bundle should not be frozen")
+	}
+	if !(&Bundle{Frozen: true}).IsFrozen() {
+		t.Error("expected Frozen field to freeze the bundle")
+	}
+	annotated := &Bundle{Annotations: map[string]string{AnnotationFreezeKey: "true"}}
+	if !annotated.IsFrozen() {
+		t.Error("expected freeze annotation to freeze the bundle")
+	}
+	annotated.Annotations[AnnotationFreezeKey] = "false"
+	if annotated.IsFrozen() {
+		t.Error("expected freeze annotation value false to be ignored")
+	}
+	skipped := &Bundle{Annotations: map[string]string{AnnotationSkipKey: "true"}}
+	if !skipped.IsFrozen() {
+		t.Error("expected skip annotation to freeze the bundle")
+	}
+}
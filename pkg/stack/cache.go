@@ -0,0 +1,141 @@
+package stack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GenerateCache memoises ApplicationConfig output keyed by a content hash of
+// the application name, namespace, config type and the JSON encoding of the
+// config. Repeated generation of an unchanged application (common when a
+// cluster is rebuilt in watch mode) returns deep copies of the cached objects
+// instead of calling Generate again.
+//
+// Only state visible to encoding/json contributes to the key. Configs whose
+// output depends on unexported fields or external inputs should not be
+// cached. Configs that cannot be encoded are generated without caching.
+// GenerateCache is safe for concurrent use.
+type GenerateCache struct {
+	mu      sync.Mutex
+	entries map[string][]client.Object
+	hits    int
+	misses  int
+}
+
+// NewGenerateCache returns an empty GenerateCache.
+func NewGenerateCache() *GenerateCache {
+	return &GenerateCache{entries: map[string][]client.Object{}}
+}
+
+// Len returns the number of cached entries.
+func (c *GenerateCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats returns the number of cache hits and misses since the cache was
+// created or last reset.
+func (c *GenerateCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Reset drops all cached entries and statistics.
+func (c *GenerateCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string][]client.Object{}
+	c.hits, c.misses = 0, 0
+}
+
+// generate returns the cached output for app or calls app.Config.Generate
+// and stores a copy of the result.
+func (c *GenerateCache) generate(app *Application) ([]*client.Object, error) {
+	key, ok := cacheKey(app)
+	if !ok {
+		return app.Config.Generate(app)
+	}
+
+	c.mu.Lock()
+	cached, hit := c.entries[key]
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+	if hit {
+		return copyObjects(cached), nil
+	}
+
+	objs, err := app.Config.Generate(app)
+	if err != nil {
+		return nil, err
+	}
+	stored := make([]client.Object, 0, len(objs))
+	for _, o := range objs {
+		if o == nil || *o == nil {
+			stored = append(stored, nil)
+			continue
+		}
+		stored = append(stored, (*o).DeepCopyObject().(client.Object))
+	}
+
+	c.mu.Lock()
+	c.entries[key] = stored
+	c.mu.Unlock()
+	return objs, nil
+}
+
+// cacheKey hashes the identity and configuration of app. It reports false
+// when the config cannot be encoded.
+func cacheKey(app *Application) (string, bool) {
+	data, err := json.Marshal(app.Config)
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00%T\x00", app.Name, app.Namespace, app.Config)
+	_, _ = h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// ConfigHash returns the hex-encoded SHA-256 of the config type and its JSON
+// encoding. It identifies the configuration an application was generated
+// from and is empty when the application has no config or the config cannot
+// be encoded.
+func (a *Application) ConfigHash() string {
+	if a == nil || a.Config == nil {
+		return ""
+	}
+	data, err := json.Marshal(a.Config)
+	if err != nil {
+		return ""
+	}
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%T\x00", a.Config)
+	_, _ = h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// copyObjects returns deep copies of objs as a fresh pointer slice. Nil
+// entries are preserved.
+func copyObjects(objs []client.Object) []*client.Object {
+	out := make([]*client.Object, 0, len(objs))
+	for _, o := range objs {
+		if o == nil {
+			out = append(out, nil)
+			continue
+		}
+		cp := o.DeepCopyObject().(client.Object)
+		out = append(out, &cp)
+	}
+	return out
+}
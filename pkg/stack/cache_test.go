@@ -0,0 +1,131 @@
+package stack
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// countingConfig generates a single ConfigMap and counts Generate calls. The
+// counter is unexported so it does not contribute to the cache key.
+type countingConfig struct {
+	Data  string
+	err   error
+	calls int
+}
+
+func (c *countingConfig) Generate(app *Application) ([]*client.Object, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	var obj client.Object = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: app.Name, Namespace: app.Namespace},
+		Data:       map[string]string{"value": c.Data},
+	}
+	return []*client.Object{&obj}, nil
+}
+
+func TestGenerateCache(t *testing.T) {
+	cache := NewGenerateCache()
+	cfg := &countingConfig{Data: "a"}
+	app := NewApplication("web", "prod", cfg)
+	app.SetCache(cache)
+
+	first, err := app.Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	// Mutating returned objects must not leak into the cache.
+	(*first[0]).SetLabels(map[string]string{"mutated": "true"})
+
+	second, err := app.Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if cfg.calls != 1 {
+		t.Errorf("expected config to be generated once, got %d calls", cfg.calls)
+	}
+	if *first[0] == *second[0] {
+		t.Error("expected cache hit to return a copy")
+	}
+	if len((*second[0]).GetLabels()) != 0 {
+		t.Errorf("expected cached object to be unaffected by caller mutation, got labels %v", (*second[0]).GetLabels())
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %d hits and %d misses", hits, misses)
+	}
+
+	// A changed config produces a new key.
+	cfg.Data = "b"
+	third, err := app.Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if cfg.calls != 2 {
+		t.Errorf("expected changed config to be regenerated, got %d calls", cfg.calls)
+	}
+	if got := (*third[0]).(*corev1.ConfigMap).Data["value"]; got != "b" {
+		t.Errorf("expected regenerated value b, got %q", got)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 cache entries, got %d", cache.Len())
+	}
+
+	// The application identity is part of the key.
+	app.SetNamespace("staging")
+	if _, err := app.Generate(); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if cfg.calls != 3 {
+		t.Errorf("expected new namespace to be regenerated, got %d calls", cfg.calls)
+	}
+
+	cache.Reset()
+	if cache.Len() != 0 {
+		t.Errorf("expected empty cache after Reset, got %d entries", cache.Len())
+	}
+}
+
+func TestGenerateCache_ErrorsNotCached(t *testing.T) {
+	cache := NewGenerateCache()
+	cfg := &countingConfig{err: errors.New("boom")}
+	app := NewApplication("web", "prod", cfg)
+	app.SetCache(cache)
+
+	for range 2 {
+		if _, err := app.Generate(); err == nil {
+			t.Fatal("expected error from Generate")
+		}
+	}
+	if cfg.calls != 2 {
+		t.Errorf("expected failed generation to be retried, got %d calls", cfg.calls)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected no cache entries after errors, got %d", cache.Len())
+	}
+}
+
+func TestApplicationConfigHash(t *testing.T) {
+	cfg := &countingConfig{Data: "a"}
+	app := NewApplication("web", "prod", cfg)
+
+	first := app.ConfigHash()
+	if len(first) != 64 {
+		t.Fatalf("expected hex SHA-256, got %q", first)
+	}
+	// Identity is not part of the hash.
+	if other := NewApplication("api", "dev", &countingConfig{Data: "a"}); other.ConfigHash() != first {
+		t.Error("expected equal configs to hash equally regardless of name and namespace")
+	}
+	cfg.Data = "b"
+	if app.ConfigHash() == first {
+		t.Error("expected changed config to change the hash")
+	}
+	if h := NewApplication("empty", "prod", nil).ConfigHash(); h != "" {
+		t.Errorf("expected empty hash without config, got %q", h)
+	}
+}
@@ -43,6 +43,13 @@ type Cluster struct {
 	Name   string        `yaml:"name"`
 	Node   *Node         `yaml:"node,omitempty"`
 	GitOps *GitOpsConfig `yaml:"gitops,omitempty"`
+	// Decryption configures decryption for every Flux Kustomization
+	// generated for the cluster. Nodes can override it.
+	Decryption *Decryption `yaml:"decryption,omitempty"`
+	// PostBuild declares cluster-wide substitution variables merged into
+	// spec.postBuild of every generated Flux Kustomization. Values set on a
+	// bundle take precedence.
+	PostBuild *PostBuild `yaml:"postBuild,omitempty"`
 }
 
 // GitOpsConfig defines the GitOps tool configuration for the cluster
@@ -62,6 +69,7 @@ type BootstrapConfig struct {
 	Components      []string `yaml:"components,omitempty"`
 	Registry        string   `yaml:"registry,omitempty"`
 	ImagePullSecret string   `yaml:"imagePullSecret,omitempty"`
+	ClusterDomain   string   `yaml:"clusterDomain,omitempty"` // FluxInstance cluster domain, e.g. "cluster.local"
 
 	// Source configuration
 	SourceKind string `yaml:"sourceKind,omitempty"` // "GitRepository" or "OCIRepository"
@@ -73,6 +81,29 @@ type BootstrapConfig struct {
 	ArgoCDNamespace string `yaml:"argoCDNamespace,omitempty"`
 }
 
+// Decryption configures how Flux decrypts secrets in the manifests of a
+// Kustomization (spec.decryption).
+type Decryption struct {
+	// Provider is the decryption provider. Defaults to "sops".
+	Provider string `yaml:"provider,omitempty"`
+	// SecretRef names the Secret in the Kustomization's namespace holding
+	// the decryption keys. Empty relies on the controller's own credentials,
+	// e.g. a cloud KMS identity.
+	SecretRef string `yaml:"secretRef,omitempty"`
+}
+
+// Tenancy locks the Flux Kustomizations of a node down to a tenant, following
+// the Flux multi-tenancy pattern: the manifests are applied by the tenant's
+// service account (spec.serviceAccountName) and namespaced resources default
+// to the tenant's namespace (spec.targetNamespace).
+type Tenancy struct {
+	// ServiceAccountName is the service account impersonated when applying
+	// the manifests.
+	ServiceAccountName string `yaml:"serviceAccountName,omitempty"`
+	// TargetNamespace is the namespace set on the namespaced resources.
+	TargetNamespace string `yaml:"targetNamespace,omitempty"`
+}
+
 // Node represents a hierarchic structure holding all deployment bundles
 // each tree has a list of children, which can be a deployment, or a subtree
 // It could match a kubernetes cluster's full configuration, or it could be just
@@ -91,6 +122,12 @@ type Node struct {
 	PackageRef *schema.GroupVersionKind `yaml:"packageref,omitempty"`
 	// Bundle holds the applications that get deployed on this level
 	Bundle *Bundle `yaml:"bundle,omitempty"`
+	// Decryption overrides the decryption configuration inherited from
+	// the parent node or the cluster for this node and its descendants.
+	Decryption *Decryption `yaml:"decryption,omitempty"`
+	// Tenancy overrides the tenancy configuration inherited from the parent
+	// node for this node and its descendants.
+	Tenancy *Tenancy `yaml:"tenancy,omitempty"`
 
 	// Internal fields for runtime hierarchy navigation (not serialized)
 	parent  *Node            `yaml:"-"` // Runtime parent reference for efficient traversal
@@ -16,6 +16,7 @@ import (
 	kio "github.com/go-kure/kure/pkg/io"
 	pubfluxcd "github.com/go-kure/kure/pkg/kubernetes/fluxcd"
 	"github.com/go-kure/kure/pkg/stack"
+	"github.com/go-kure/kure/pkg/stack/layout"
 )
 
 // BootstrapGenerator implements the workflow.BootstrapGenerator interface for Flux.
@@ -254,6 +255,35 @@ func (bg *BootstrapGenerator) GenerateFluxInstance(config *stack.BootstrapConfig
 	return fi, nil
 }
 
+// AddFluxInstanceToLayout places the FluxInstance configured by the
+// cluster's bootstrap settings in the root layout ml, so that a fresh cluster
+// is bootstrapped from the generated tree alone once the Flux Operator is
+// installed. The sync path points at the directory layout.WriteManifest
+// writes ml to with cfg (see layout.ManifestDir), which makes the instance
+// reconcile itself together with the rest of the cluster.
+//
+// It does nothing when the cluster has no enabled bootstrap configuration and
+// fails for the gotk mode, which does not use a FluxInstance.
+func (bg *BootstrapGenerator) AddFluxInstanceToLayout(ml *layout.ManifestLayout, c *stack.Cluster, cfg layout.Config) error {
+	if ml == nil || c == nil || c.GitOps == nil || c.GitOps.Bootstrap == nil || !c.GitOps.Bootstrap.Enabled {
+		return nil
+	}
+	config := c.GitOps.Bootstrap
+	if config.FluxMode != "" && config.FluxMode != "flux-operator" {
+		return errors.ResourceValidationError("Cluster", c.Name, "gitops.bootstrap.fluxMode",
+			fmt.Sprintf("a FluxInstance requires the flux-operator mode, got %q", config.FluxMode), nil)
+	}
+	fi, err := bg.GenerateFluxInstance(config, c.Node)
+	if err != nil {
+		return err
+	}
+	if fi.Spec.Sync != nil {
+		fi.Spec.Sync.Path = "./" + layout.ManifestDir(cfg, ml)
+	}
+	ml.Resources = append(ml.Resources, fi)
+	return nil
+}
+
 // generateFluxInstance creates a FluxInstance for flux-operator mode.
 func (bg *BootstrapGenerator) generateFluxInstance(config *stack.BootstrapConfig, rootNode *stack.Node) client.Object {
 	spec := fluxv1.FluxInstanceSpec{
@@ -268,6 +298,10 @@ func (bg *BootstrapGenerator) generateFluxInstance(config *stack.BootstrapConfig
 		spec.Components = append(spec.Components, fluxv1.Component(comp))
 	}
 
+	if config.ClusterDomain != "" {
+		spec.Cluster = &fluxv1.Cluster{Domain: config.ClusterDomain}
+	}
+
 	// Add sync configuration if source is provided
 	if config.SourceURL != "" {
 		path := "./"
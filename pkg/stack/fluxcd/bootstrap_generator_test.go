@@ -1,6 +1,9 @@
 package fluxcd_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 
 	"github.com/go-kure/kure/pkg/stack"
 	fluxstack "github.com/go-kure/kure/pkg/stack/fluxcd"
+	"github.com/go-kure/kure/pkg/stack/layout"
 )
 
 // findFluxInstance locates the FluxInstance in a flux-operator bootstrap
@@ -622,3 +626,131 @@ func TestFluxOperatorBootstrapIncludesInstallBundle(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateFluxInstanceClusterDomain(t *testing.T) {
+	bg := fluxstack.NewBootstrapGenerator()
+
+	fi, err := bg.GenerateFluxInstance(&stack.BootstrapConfig{Enabled: true, ClusterDomain: "prod.local"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateFluxInstance() error = %v", err)
+	}
+	if fi.Spec.Cluster == nil || fi.Spec.Cluster.Domain != "prod.local" {
+		t.Errorf("Cluster = %+v, want domain prod.local", fi.Spec.Cluster)
+	}
+}
+
+func TestAddFluxInstanceToLayout(t *testing.T) {
+	bg := fluxstack.NewBootstrapGenerator()
+	cluster := &stack.Cluster{
+		Name: "prod",
+		Node: &stack.Node{Name: "root"},
+		GitOps: &stack.GitOpsConfig{Type: "flux", Bootstrap: &stack.BootstrapConfig{
+			Enabled:     true,
+			FluxVersion: "2.x",
+			Components:  []string{"source-controller", "kustomize-controller"},
+			SourceKind:  "GitRepository",
+			SourceURL:   "https://github.com/example/fleet.git",
+			SourceRef:   "main",
+		}},
+	}
+	ml := &layout.ManifestLayout{Name: "", Namespace: "prod"}
+
+	if err := bg.AddFluxInstanceToLayout(ml, cluster, layout.DefaultLayoutConfig()); err != nil {
+		t.Fatalf("AddFluxInstanceToLayout() error = %v", err)
+	}
+	if len(ml.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(ml.Resources))
+	}
+	fi, ok := ml.Resources[0].(*fluxv1.FluxInstance)
+	if !ok {
+		t.Fatalf("expected FluxInstance, got %T", ml.Resources[0])
+	}
+	if fi.Spec.Distribution.Version != "2.x" || len(fi.Spec.Components) != 2 {
+		t.Errorf("unexpected distribution or components: %+v", fi.Spec)
+	}
+	if fi.Spec.Sync == nil || fi.Spec.Sync.Path != "./clusters/prod" {
+		t.Errorf("Sync = %+v, want path ./clusters/prod", fi.Spec.Sync)
+	}
+
+	// The path follows the manifests directory and the synthetic cluster
+	// directory of a root layout without a namespace.
+	for _, tc := range []struct {
+		ml   *layout.ManifestLayout
+		cfg  layout.Config
+		want string
+	}{
+		{&layout.ManifestLayout{Namespace: "prod"}, layout.Config{ManifestsDir: "fleet"}, "./fleet/prod"},
+		{&layout.ManifestLayout{Namespace: "prod"}, layout.Config{}, "./clusters/prod"},
+		{&layout.ManifestLayout{}, layout.DefaultLayoutConfig(), "./clusters/cluster"},
+	} {
+		if err := bg.AddFluxInstanceToLayout(tc.ml, cluster, tc.cfg); err != nil {
+			t.Fatalf("AddFluxInstanceToLayout() error = %v", err)
+		}
+		if got := tc.ml.Resources[0].(*fluxv1.FluxInstance).Spec.Sync.Path; got != tc.want {
+			t.Errorf("ManifestsDir %q, namespace %q: sync path = %q, want %q", tc.cfg.ManifestsDir, tc.ml.Namespace, got, tc.want)
+		}
+	}
+}
+
+func TestAddFluxInstanceToLayout_Skipped(t *testing.T) {
+	bg := fluxstack.NewBootstrapGenerator()
+	ml := &layout.ManifestLayout{Namespace: "prod"}
+
+	disabled := &stack.Cluster{Name: "prod", GitOps: &stack.GitOpsConfig{Bootstrap: &stack.BootstrapConfig{}}}
+	if err := bg.AddFluxInstanceToLayout(ml, disabled, layout.DefaultLayoutConfig()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ml.Resources) != 0 {
+		t.Errorf("expected no resources for a disabled bootstrap, got %d", len(ml.Resources))
+	}
+
+	gotk := &stack.Cluster{Name: "prod", GitOps: &stack.GitOpsConfig{Bootstrap: &stack.BootstrapConfig{Enabled: true, FluxMode: "gotk"}}}
+	if err := bg.AddFluxInstanceToLayout(ml, gotk, layout.DefaultLayoutConfig()); err == nil {
+		t.Error("expected error for gotk mode")
+	}
+}
+
+func TestWorkflowEngine_CreateLayoutWithResources_FluxInstance(t *testing.T) {
+	we := fluxstack.NewWorkflowEngine()
+	we.SetIncludeFluxInstance(true)
+	cluster := &stack.Cluster{
+		Name: "prod",
+		Node: &stack.Node{Name: "apps", Bundle: &stack.Bundle{Name: "apps"}},
+		GitOps: &stack.GitOpsConfig{Type: "flux", Bootstrap: &stack.BootstrapConfig{
+			Enabled:       true,
+			ClusterDomain: "cluster.local",
+			SourceKind:    "GitRepository",
+			SourceURL:     "https://github.com/example/fleet.git",
+		}},
+	}
+
+	result, err := we.CreateLayoutWithResources(cluster, layout.DefaultLayoutRules())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ml := result.(*layout.ManifestLayout)
+	var fi *fluxv1.FluxInstance
+	for _, obj := range ml.Resources {
+		if f, ok := obj.(*fluxv1.FluxInstance); ok {
+			fi = f
+		}
+	}
+	if fi == nil {
+		t.Fatal("expected a FluxInstance in the root layout")
+	}
+	if fi.Spec.Cluster == nil || fi.Spec.Cluster.Domain != "cluster.local" {
+		t.Errorf("Cluster = %+v, want domain cluster.local", fi.Spec.Cluster)
+	}
+
+	// The sync path is the directory the root layout is written to.
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+	if fi.Spec.Sync == nil || !strings.HasPrefix(fi.Spec.Sync.Path, "./clusters/") {
+		t.Fatalf("Sync = %+v, want a path below ./clusters", fi.Spec.Sync)
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(fi.Spec.Sync.Path), "kustomization.yaml")); err != nil {
+		t.Errorf("sync path %s does not hold the root kustomization.yaml: %v", fi.Spec.Sync.Path, err)
+	}
+}
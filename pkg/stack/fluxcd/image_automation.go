@@ -0,0 +1,148 @@
+package fluxcd
+
+import (
+	"time"
+
+	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+	pubfluxcd "github.com/go-kure/kure/pkg/kubernetes/fluxcd"
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+// imagePolicyLabel labels the ImagePolicy of an application so that its
+// ImageUpdateAutomation selects only that policy.
+const imagePolicyLabel = "app.kubernetes.io/name"
+
+// ImageAutomationConfig configures the image automation generated by
+// GenerateImageAutomation.
+type ImageAutomationConfig struct {
+	// Image is the image repository to scan, without a tag. Required.
+	Image string
+	// Namespace of the generated objects. Defaults to the application's
+	// namespace, then to the generator's DefaultNamespace.
+	Namespace string
+	// Interval between registry scans and automation runs. Defaults to the
+	// generator's DefaultInterval.
+	Interval time.Duration
+	// SecretRef names the Secret with the registry credentials.
+	SecretRef string
+
+	// Policy selects the tag ordering: "semver" (default), "alphabetical"
+	// or "numerical".
+	Policy string
+	// Range is the semver range of the "semver" policy. Required with it.
+	Range string
+	// Order is "asc" or "desc" for the "alphabetical" and "numerical"
+	// policies.
+	Order string
+	// FilterPattern and FilterExtract filter the tags before the policy
+	// is applied.
+	FilterPattern string
+	FilterExtract string
+
+	// SourceRef is the GitRepository the updates are committed to. Required.
+	SourceRef *stack.SourceRef
+	// Branch is checked out and pushed to. Empty uses the repository's
+	// reference.
+	Branch string
+	// Path is the directory in which image setters are updated. Defaults to
+	// "./".
+	Path string
+	// AuthorName and AuthorEmail identify the commit author. AuthorEmail is
+	// required.
+	AuthorName  string
+	AuthorEmail string
+}
+
+// GenerateImageAutomation creates the ImageRepository, ImagePolicy and
+// ImageUpdateAutomation that keep the image of app up to date. All three are
+// named after the application; the automation selects only the
+// application's policy and updates the image setters below cfg.Path.
+func (g *ResourceGenerator) GenerateImageAutomation(app *stack.Application, cfg ImageAutomationConfig) ([]client.Object, error) {
+	if app == nil {
+		return nil, errors.ResourceValidationError("ImageAutomationConfig", "", "application",
+			"an application is required", nil)
+	}
+	if cfg.Image == "" {
+		return nil, errors.ResourceValidationError("ImageAutomationConfig", app.Name, "image",
+			"an image repository is required", nil)
+	}
+	if cfg.SourceRef == nil || cfg.SourceRef.Name == "" {
+		return nil, errors.ResourceValidationError("ImageAutomationConfig", app.Name, "sourceRef",
+			"a GitRepository to commit updates to is required", nil)
+	}
+	if cfg.SourceRef.Kind != "" && cfg.SourceRef.Kind != "GitRepository" {
+		return nil, errors.NewValidationError("sourceRef.kind", cfg.SourceRef.Kind, "ImageAutomationConfig",
+			[]string{"GitRepository"})
+	}
+	if cfg.AuthorEmail == "" {
+		return nil, errors.ResourceValidationError("ImageAutomationConfig", app.Name, "authorEmail",
+			"a commit author email is required", nil)
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = app.Namespace
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = g.DefaultNamespace
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = g.DefaultInterval
+	}
+	if cfg.Path == "" {
+		cfg.Path = "./"
+	}
+	interval := metav1.Duration{Duration: cfg.Interval}
+
+	repo := pubfluxcd.CreateImageRepository(app.Name, cfg.Namespace)
+	pubfluxcd.SetImageRepositoryImage(repo, cfg.Image)
+	pubfluxcd.SetImageRepositoryInterval(repo, interval)
+	if cfg.SecretRef != "" {
+		pubfluxcd.SetImageRepositorySecretRef(repo, &meta.LocalObjectReference{Name: cfg.SecretRef})
+	}
+
+	policy := pubfluxcd.CreateImagePolicy(app.Name, cfg.Namespace)
+	policy.Labels = map[string]string{imagePolicyLabel: app.Name}
+	pubfluxcd.SetImagePolicyImageRepositoryRef(policy, meta.NamespacedObjectReference{Name: app.Name})
+	switch cfg.Policy {
+	case "", "semver":
+		if cfg.Range == "" {
+			return nil, errors.ResourceValidationError("ImageAutomationConfig", app.Name, "range",
+				"the semver policy requires a version range", nil)
+		}
+		pubfluxcd.SetImagePolicySemVer(policy, cfg.Range)
+	case "alphabetical":
+		pubfluxcd.SetImagePolicyAlphabetical(policy, cfg.Order)
+	case "numerical":
+		pubfluxcd.SetImagePolicyNumerical(policy, cfg.Order)
+	default:
+		return nil, errors.NewValidationError("policy", cfg.Policy, "ImageAutomationConfig",
+			[]string{"semver", "alphabetical", "numerical"})
+	}
+	if cfg.FilterPattern != "" {
+		pubfluxcd.SetImagePolicyFilterTags(policy, cfg.FilterPattern, cfg.FilterExtract)
+	}
+
+	auto := pubfluxcd.CreateImageUpdateAutomation(app.Name, cfg.Namespace)
+	pubfluxcd.SetImageUpdateAutomationInterval(auto, interval)
+	pubfluxcd.SetImageUpdateAutomationSourceRef(auto, pubfluxcd.CreateCrossNamespaceSourceReference(
+		sourcev1.GroupVersion.String(), "GitRepository", cfg.SourceRef.Name, cfg.SourceRef.Namespace))
+	commit := pubfluxcd.CreateCommitSpec(pubfluxcd.CreateCommitUser(cfg.AuthorName, cfg.AuthorEmail))
+	var checkout *imagev1.GitCheckoutSpec
+	var push *imagev1.PushSpec
+	if cfg.Branch != "" {
+		checkout = pubfluxcd.CreateGitCheckoutSpec(sourcev1.GitRepositoryRef{Branch: cfg.Branch})
+		push = pubfluxcd.CreatePushSpec(cfg.Branch, "", nil)
+	}
+	pubfluxcd.SetImageUpdateAutomationGitSpec(auto, pubfluxcd.CreateGitSpec(commit, checkout, push))
+	pubfluxcd.SetImageUpdateAutomationPolicySelector(auto, &metav1.LabelSelector{
+		MatchLabels: map[string]string{imagePolicyLabel: app.Name},
+	})
+	pubfluxcd.SetImageUpdateAutomationUpdateStrategy(auto, pubfluxcd.CreateUpdateStrategy(imagev1.UpdateStrategySetters, cfg.Path))
+
+	return []client.Object{repo, policy, auto}, nil
+}
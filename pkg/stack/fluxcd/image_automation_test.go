@@ -0,0 +1,112 @@
+package fluxcd_test
+
+import (
+	"testing"
+
+	imagev1 "github.com/fluxcd/image-automation-controller/api/v1"
+	imagereflectv1 "github.com/fluxcd/image-reflector-controller/api/v1"
+
+	"github.com/go-kure/kure/pkg/stack"
+	fluxstack "github.com/go-kure/kure/pkg/stack/fluxcd"
+)
+
+func TestImageAutomation(t *testing.T) {
+	app := stack.NewApplication("podinfo", "apps", &fakeAppConfig{})
+	objs, err := fluxstack.Engine().ImageAutomation(app, fluxstack.ImageAutomationConfig{
+		Image:       "ghcr.io/stefanprodan/podinfo",
+		Range:       "6.x",
+		SourceRef:   &stack.SourceRef{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"},
+		Branch:      "main",
+		Path:        "./clusters/prod",
+		AuthorName:  "fluxbot",
+		AuthorEmail: "fluxbot@example.com",
+	})
+	if err != nil {
+		t.Fatalf("ImageAutomation: %v", err)
+	}
+	if len(objs) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(objs))
+	}
+
+	repo, ok := objs[0].(*imagereflectv1.ImageRepository)
+	if !ok {
+		t.Fatalf("expected ImageRepository, got %T", objs[0])
+	}
+	if repo.Name != "podinfo" || repo.Namespace != "apps" || repo.Spec.Image != "ghcr.io/stefanprodan/podinfo" {
+		t.Errorf("unexpected repository %s/%s %+v", repo.Namespace, repo.Name, repo.Spec)
+	}
+
+	policy, ok := objs[1].(*imagereflectv1.ImagePolicy)
+	if !ok {
+		t.Fatalf("expected ImagePolicy, got %T", objs[1])
+	}
+	if policy.Spec.ImageRepositoryRef.Name != "podinfo" {
+		t.Errorf("policy references %q, want podinfo", policy.Spec.ImageRepositoryRef.Name)
+	}
+	if policy.Spec.Policy.SemVer == nil || policy.Spec.Policy.SemVer.Range != "6.x" {
+		t.Errorf("unexpected policy choice %+v", policy.Spec.Policy)
+	}
+
+	auto, ok := objs[2].(*imagev1.ImageUpdateAutomation)
+	if !ok {
+		t.Fatalf("expected ImageUpdateAutomation, got %T", objs[2])
+	}
+	if auto.Spec.SourceRef.Kind != "GitRepository" || auto.Spec.SourceRef.Name != "fleet" {
+		t.Errorf("unexpected sourceRef %+v", auto.Spec.SourceRef)
+	}
+	if auto.Spec.GitSpec == nil || auto.Spec.GitSpec.Push == nil || auto.Spec.GitSpec.Push.Branch != "main" {
+		t.Errorf("unexpected git spec %+v", auto.Spec.GitSpec)
+	}
+	if auto.Spec.Update == nil || auto.Spec.Update.Path != "./clusters/prod" {
+		t.Errorf("unexpected update strategy %+v", auto.Spec.Update)
+	}
+	selector := auto.Spec.PolicySelector
+	if selector == nil {
+		t.Fatal("expected a policy selector")
+	}
+	for key, value := range selector.MatchLabels {
+		if policy.Labels[key] != value {
+			t.Errorf("policy selector %v does not match policy labels %v", selector.MatchLabels, policy.Labels)
+		}
+	}
+}
+
+func TestImageAutomation_Policies(t *testing.T) {
+	app := stack.NewApplication("podinfo", "apps", &fakeAppConfig{})
+	base := fluxstack.ImageAutomationConfig{
+		Image:       "ghcr.io/stefanprodan/podinfo",
+		SourceRef:   &stack.SourceRef{Name: "fleet"},
+		AuthorEmail: "fluxbot@example.com",
+	}
+
+	numerical := base
+	numerical.Policy = "numerical"
+	numerical.Order = "asc"
+	objs, err := fluxstack.Engine().ImageAutomation(app, numerical)
+	if err != nil {
+		t.Fatalf("ImageAutomation: %v", err)
+	}
+	if choice := objs[1].(*imagereflectv1.ImagePolicy).Spec.Policy; choice.Numerical == nil || choice.Numerical.Order != "asc" {
+		t.Errorf("unexpected policy choice %+v", choice)
+	}
+
+	invalid := map[string]func(*fluxstack.ImageAutomationConfig){
+		"missing image":  func(c *fluxstack.ImageAutomationConfig) { c.Image = "" },
+		"missing source": func(c *fluxstack.ImageAutomationConfig) { c.SourceRef = nil },
+		"oci source": func(c *fluxstack.ImageAutomationConfig) {
+			c.SourceRef = &stack.SourceRef{Kind: "OCIRepository", Name: "fleet"}
+		},
+		"missing author email": func(c *fluxstack.ImageAutomationConfig) { c.AuthorEmail = "" },
+		"semver without range": func(c *fluxstack.ImageAutomationConfig) { c.Policy = "semver" },
+		"unknown policy":       func(c *fluxstack.ImageAutomationConfig) { c.Policy = "latest" },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			cfg := base
+			mutate(&cfg)
+			if _, err := fluxstack.Engine().ImageAutomation(app, cfg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -42,7 +42,10 @@ func NewLayoutIntegrator(generator *ResourceGenerator) *LayoutIntegrator {
 // flattenInfo on the absorbing layouts), this method consults nodeAliases
 // during integrated placement (see findLayoutNode) and rewrites Flux
 // Kustomization Spec.Path values via layout.ApplyFlattenPathRewrites before
-// returning, regardless of placement mode.
+// returning, regardless of placement mode. With integrated placement,
+// rules.MaxDepth is applied here through layout.LimitDepth after the
+// Kustomizations are placed. Finally the Kustomization paths are checked
+// with layout.ValidateFluxPaths; see LayoutRules.StrictFluxPaths.
 func (li *LayoutIntegrator) IntegrateWithLayout(ml *layout.ManifestLayout, c *stack.Cluster, rules layout.LayoutRules) error {
 	if ml == nil || c == nil {
 		return nil
@@ -58,6 +61,11 @@ func (li *LayoutIntegrator) IntegrateWithLayout(ml *layout.ManifestLayout, c *st
 		// layout node (incl. augmenter-added child layouts); PerBundle stops at
 		// bundle/node boundaries and lets kustomize include child directories.
 		err = li.addIntegratedFluxToLayout(ml, c, rules)
+		if err == nil {
+			// WalkCluster defers MaxDepth for inline placement so that the
+			// Kustomizations placed above move with their layouts.
+			err = layout.LimitDepth(ml, rules.MaxDepth)
+		}
 	case layout.FluxSeparate:
 		err = li.addSeparateFluxToLayout(ml, c, rules)
 	default:
@@ -69,6 +77,24 @@ func (li *LayoutIntegrator) IntegrateWithLayout(ml *layout.ManifestLayout, c *st
 	}
 
 	layout.ApplyFlattenPathRewrites(ml)
+	return checkFluxPaths(ml, rules)
+}
+
+// checkFluxPaths runs layout.ValidateFluxPaths on the integrated layout.
+// Broken paths fail the integration with rules.StrictFluxPaths and are
+// reported to rules.Warnings otherwise.
+func checkFluxPaths(ml *layout.ManifestLayout, rules layout.LayoutRules) error {
+	err := layout.ValidateFluxPaths(ml)
+	if err == nil || rules.StrictFluxPaths {
+		return err
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		rules.Warnings.Add("fluxcd", "", e.Error())
+	}
 	return nil
 }
 
@@ -124,15 +150,39 @@ func (li *LayoutIntegrator) CreateLayoutWithResources(c *stack.Cluster, rules la
 // (GenerateFromBundle + umbrella bundle children); non-bundle layout children
 // get no CR and the writer references them as directories — a single kustomize
 // build per bundle.
+//
+// With ResourceGenerator.IncludeClusterSource the cluster source is added to
+// the root layout.
 func (li *LayoutIntegrator) addIntegratedFluxToLayout(ml *layout.ManifestLayout, c *stack.Cluster, rules layout.LayoutRules) error {
 	emitPerChildCRs := rules.FluxPlacement == layout.FluxIntegratedPerLayout
-	return li.processNodeForIntegratedFlux(ml, c.Node, c.Name, emitPerChildCRs)
+	// Kustomizations already in the layout were generated by applications;
+	// decryption, tenancy and the cluster variables only apply to the ones
+	// placed here.
+	appKusts := map[*kustv1.Kustomization]bool{}
+	collectAppKustomizations(ml, appKusts)
+	if err := li.processNodeForIntegratedFlux(ml, c.Node, "", emitPerChildCRs); err != nil {
+		return err
+	}
+	li.applyNodeDecryption(ml, c.Node, c.Decryption, appKusts)
+	li.applyNodeTenancy(ml, c.Node, nil, appKusts)
+	mergeLayoutPostBuild(ml, c.PostBuild, appKusts)
+	if li.Generator.IncludeClusterSource {
+		source, err := li.Generator.GenerateClusterSource(c)
+		if err != nil {
+			return err
+		}
+		if source != nil {
+			ml.Resources = append(ml.Resources, source)
+		}
+	}
+	return nil
 }
 
 // processNodeForIntegratedFlux recursively processes nodes to add integrated Flux resources.
 // The root parameter is always the top-level layout so that path-based lookups
-// resolve against the full tree (node paths are absolute).
-func (li *LayoutIntegrator) processNodeForIntegratedFlux(root *layout.ManifestLayout, node *stack.Node, clusterName string, emitPerChildCRs bool) error {
+// resolve against the full tree (node paths are absolute). parentKust names
+// the Kustomization of the nearest ancestor node with a bundle.
+func (li *LayoutIntegrator) processNodeForIntegratedFlux(root *layout.ManifestLayout, node *stack.Node, parentKust string, emitPerChildCRs bool) error {
 	// Find the corresponding layout node
 	layoutNode := li.findLayoutNode(root, node)
 	if layoutNode == nil {
@@ -147,6 +197,7 @@ func (li *LayoutIntegrator) processNodeForIntegratedFlux(root *layout.ManifestLa
 			return errors.ResourceValidationError("Node", node.Name, "flux-resources",
 				fmt.Sprintf("failed to generate Flux resources: %v", err), err)
 		}
+		li.Generator.addParentNodeDependency(fluxResources, parentKust)
 
 		// Add Flux resources to the layout node
 		layoutNode.Resources = append(layoutNode.Resources, fluxResources...)
@@ -254,8 +305,12 @@ func (li *LayoutIntegrator) processNodeForIntegratedFlux(root *layout.ManifestLa
 	}
 
 	// Process child nodes — always search from root for path-based matching
+	childParent := parentKust
+	if node.Bundle != nil {
+		childParent = node.Bundle.Name
+	}
 	for _, child := range node.Children {
-		if err := li.processNodeForIntegratedFlux(root, child, clusterName, emitPerChildCRs); err != nil {
+		if err := li.processNodeForIntegratedFlux(root, child, childParent, emitPerChildCRs); err != nil {
 			return err
 		}
 	}
@@ -263,6 +318,117 @@ func (li *LayoutIntegrator) processNodeForIntegratedFlux(root *layout.ManifestLa
 	return nil
 }
 
+// applyNodeDecryption sets spec.decryption on the Kustomizations placed in
+// the layout of node and its descendants. Descendants are handled first so
+// that a node's own Decryption takes precedence over its ancestors'.
+func (li *LayoutIntegrator) applyNodeDecryption(root *layout.ManifestLayout, node *stack.Node, inherited *stack.Decryption, skip map[*kustv1.Kustomization]bool) {
+	dec := inherited
+	if node.Decryption != nil {
+		dec = node.Decryption
+	}
+	for _, child := range node.Children {
+		if child != nil {
+			li.applyNodeDecryption(root, child, dec, skip)
+		}
+	}
+	if dec == nil {
+		return
+	}
+	if layoutNode := li.findLayoutNode(root, node); layoutNode != nil {
+		setLayoutDecryption(layoutNode, dec, skip)
+	}
+}
+
+// setLayoutDecryption applies setDecryption to the Kustomizations of ml and
+// its sub-layouts that are not in skip.
+func setLayoutDecryption(ml *layout.ManifestLayout, dec *stack.Decryption, skip map[*kustv1.Kustomization]bool) {
+	setDecryption(integratedObjects(ml.Resources, skip), dec)
+	for _, child := range ml.Children {
+		setLayoutDecryption(child, dec, skip)
+	}
+}
+
+// collectAppKustomizations records the Kustomizations of ml and its
+// sub-layouts in out.
+func collectAppKustomizations(ml *layout.ManifestLayout, out map[*kustv1.Kustomization]bool) {
+	for _, obj := range ml.Resources {
+		if k, ok := obj.(*kustv1.Kustomization); ok {
+			out[k] = true
+		}
+	}
+	for _, child := range ml.Children {
+		collectAppKustomizations(child, out)
+	}
+}
+
+// integratedObjects returns the objects of objs that are not Kustomizations
+// in skip.
+func integratedObjects(objs []client.Object, skip map[*kustv1.Kustomization]bool) []client.Object {
+	if len(skip) == 0 {
+		return objs
+	}
+	out := make([]client.Object, 0, len(objs))
+	for _, obj := range objs {
+		if k, ok := obj.(*kustv1.Kustomization); ok && skip[k] {
+			continue
+		}
+		out = append(out, obj)
+	}
+	return out
+}
+
+// applyNodeTenancy sets spec.serviceAccountName and spec.targetNamespace on
+// the Kustomizations placed in the layout of node and its descendants. The
+// effective Tenancy is resolved once per node, the node's own or else the
+// inherited one, and both fields are taken from it; the layouts of child
+// nodes are left to the child, so a descendant's own Tenancy replaces the
+// inherited one as a whole.
+func (li *LayoutIntegrator) applyNodeTenancy(root *layout.ManifestLayout, node *stack.Node, inherited *stack.Tenancy, skip map[*kustv1.Kustomization]bool) {
+	tenancy := inherited
+	if node.Tenancy != nil {
+		tenancy = node.Tenancy
+	}
+	if tenancy != nil {
+		if layoutNode := li.findLayoutNode(root, node); layoutNode != nil {
+			childLayouts := map[*layout.ManifestLayout]bool{}
+			for _, child := range node.Children {
+				if child == nil {
+					continue
+				}
+				if cl := li.findLayoutNode(root, child); cl != nil && cl != layoutNode {
+					childLayouts[cl] = true
+				}
+			}
+			setLayoutTenancy(layoutNode, tenancy, childLayouts, skip)
+		}
+	}
+	for _, child := range node.Children {
+		if child != nil {
+			li.applyNodeTenancy(root, child, tenancy, skip)
+		}
+	}
+}
+
+// setLayoutTenancy applies setTenancy to the Kustomizations of ml and its
+// sub-layouts that are not in skip, without descending into nodeLayouts.
+func setLayoutTenancy(ml *layout.ManifestLayout, t *stack.Tenancy, nodeLayouts map[*layout.ManifestLayout]bool, skip map[*kustv1.Kustomization]bool) {
+	setTenancy(integratedObjects(ml.Resources, skip), t)
+	for _, child := range ml.Children {
+		if !nodeLayouts[child] {
+			setLayoutTenancy(child, t, nodeLayouts, skip)
+		}
+	}
+}
+
+// mergeLayoutPostBuild applies mergeClusterPostBuild to the Kustomizations
+// of ml and its sub-layouts that are not in skip.
+func mergeLayoutPostBuild(ml *layout.ManifestLayout, pb *stack.PostBuild, skip map[*kustv1.Kustomization]bool) {
+	mergeClusterPostBuild(integratedObjects(ml.Resources, skip), pb)
+	for _, child := range ml.Children {
+		mergeLayoutPostBuild(child, pb, skip)
+	}
+}
+
 // isStackNodeChild returns true when name matches a direct child stack.Node of
 // node. Used to skip ManifestLayout.Children that correspond to child nodes
 // already processed by the recursive processNodeForIntegratedFlux call.
@@ -11,8 +11,10 @@ import (
 
 	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/go-kure/kure/pkg/errors"
 	"github.com/go-kure/kure/pkg/stack"
 	fluxstack "github.com/go-kure/kure/pkg/stack/fluxcd"
 	"github.com/go-kure/kure/pkg/stack/layout"
@@ -302,6 +304,250 @@ func TestLayoutIntegrator_IntegrateWithNestedNodes(t *testing.T) {
 	}
 }
 
+func TestLayoutIntegrator_IntegratedDependOnParentNode(t *testing.T) {
+	generator := fluxstack.NewResourceGenerator()
+	generator.DependOnParentNode = true
+	integrator := fluxstack.NewLayoutIntegrator(generator)
+
+	ref := &stack.SourceRef{Kind: "GitRepository", Name: "test-source", Namespace: "flux-system"}
+	child := &stack.Node{Name: "child", ParentPath: "root", Bundle: &stack.Bundle{Name: "child-bundle", SourceRef: ref}}
+	root := &stack.Node{Name: "root", Bundle: &stack.Bundle{Name: "root-bundle", SourceRef: ref}, Children: []*stack.Node{child}}
+
+	childLayout := &layout.ManifestLayout{Name: "child"}
+	rootLayout := &layout.ManifestLayout{Name: "root", Children: []*layout.ManifestLayout{childLayout}}
+
+	rules := layout.DefaultLayoutRules()
+	rules.FluxPlacement = layout.FluxIntegratedPerLayout
+	if err := integrator.IntegrateWithLayout(rootLayout, &stack.Cluster{Name: "test-cluster", Node: root}, rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, obj := range childLayout.Resources {
+		k, ok := obj.(*kustv1.Kustomization)
+		if !ok || k.Name != "child-bundle" {
+			continue
+		}
+		if len(k.Spec.DependsOn) != 1 || k.Spec.DependsOn[0].Name != "root-bundle" {
+			t.Errorf("child-bundle dependsOn = %v, want [root-bundle]", k.Spec.DependsOn)
+		}
+		return
+	}
+	t.Fatal("child-bundle Kustomization not found in child layout")
+}
+
+func TestLayoutIntegrator_IntegratedDecryption(t *testing.T) {
+	integrator := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator())
+
+	ref := &stack.SourceRef{Kind: "GitRepository", Name: "test-source", Namespace: "flux-system"}
+	child := &stack.Node{
+		Name:       "child",
+		ParentPath: "root",
+		Bundle:     &stack.Bundle{Name: "child-bundle", SourceRef: ref},
+		Decryption: &stack.Decryption{SecretRef: "child-keys"},
+	}
+	root := &stack.Node{Name: "root", Bundle: &stack.Bundle{Name: "root-bundle", SourceRef: ref}, Children: []*stack.Node{child}}
+	cluster := &stack.Cluster{Name: "test-cluster", Node: root, Decryption: &stack.Decryption{SecretRef: "cluster-keys"}}
+
+	childLayout := &layout.ManifestLayout{Name: "child"}
+	rootLayout := &layout.ManifestLayout{Name: "root", Children: []*layout.ManifestLayout{childLayout}}
+
+	rules := layout.DefaultLayoutRules()
+	rules.FluxPlacement = layout.FluxIntegratedPerLayout
+	if err := integrator.IntegrateWithLayout(rootLayout, cluster, rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"root-bundle": "cluster-keys", "child-bundle": "child-keys"}
+	found := 0
+	for _, ml := range []*layout.ManifestLayout{rootLayout, childLayout} {
+		for _, obj := range ml.Resources {
+			k, ok := obj.(*kustv1.Kustomization)
+			if !ok {
+				continue
+			}
+			found++
+			if k.Spec.Decryption == nil || k.Spec.Decryption.SecretRef == nil || k.Spec.Decryption.SecretRef.Name != want[k.Name] {
+				t.Errorf("%s: decryption = %+v, want secret %q", k.Name, k.Spec.Decryption, want[k.Name])
+			}
+		}
+	}
+	if found != len(want) {
+		t.Errorf("expected %d Kustomizations, found %d", len(want), found)
+	}
+}
+
+// TestLayoutIntegrator_IntegratedTenancyReplacesInherited verifies that a
+// node's own Tenancy replaces the inherited one as a whole: fields it leaves
+// empty are not filled from an ancestor.
+func TestLayoutIntegrator_IntegratedTenancyReplacesInherited(t *testing.T) {
+	integrator := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator())
+
+	ref := &stack.SourceRef{Kind: "GitRepository", Name: "test-source", Namespace: "flux-system"}
+	db := &stack.Node{
+		Name:       "db",
+		ParentPath: "team-a",
+		Bundle:     &stack.Bundle{Name: "team-a-db", SourceRef: ref},
+		Tenancy:    &stack.Tenancy{TargetNamespace: "team-a-db"},
+	}
+	tenant := &stack.Node{
+		Name:     "team-a",
+		Bundle:   &stack.Bundle{Name: "team-a", SourceRef: ref},
+		Tenancy:  &stack.Tenancy{ServiceAccountName: "team-a", TargetNamespace: "team-a"},
+		Children: []*stack.Node{db},
+	}
+	cluster := &stack.Cluster{Name: "prod", Node: tenant}
+
+	dbLayout := &layout.ManifestLayout{Name: "db"}
+	tenantLayout := &layout.ManifestLayout{Name: "team-a", Children: []*layout.ManifestLayout{dbLayout}}
+
+	rules := layout.DefaultLayoutRules()
+	rules.FluxPlacement = layout.FluxIntegratedPerLayout
+	if err := integrator.IntegrateWithLayout(tenantLayout, cluster, rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][2]string{
+		"team-a":    {"team-a", "team-a"},
+		"team-a-db": {"", "team-a-db"},
+	}
+	for name, exp := range want {
+		k := findKustomization(tenantLayout, name)
+		if k == nil {
+			t.Fatalf("missing Kustomization %s", name)
+		}
+		if got := [2]string{k.Spec.ServiceAccountName, k.Spec.TargetNamespace}; got != exp {
+			t.Errorf("%s: serviceAccountName/targetNamespace = %v, want %v", name, got, exp)
+		}
+	}
+}
+
+// kustomizationApp returns an application that generates the Flux
+// Kustomization k itself.
+func kustomizationApp(name string, k *kustv1.Kustomization) *stack.Application {
+	var o client.Object = k
+	return stack.NewApplication(name, "default", &fakeAppConfig{objs: []*client.Object{&o}})
+}
+
+// integratedAppKustomizationCluster returns a cluster whose bundle apps
+// holds an application generating the Kustomization vendor.
+func integratedAppKustomizationCluster(vendor *kustv1.Kustomization) *stack.Cluster {
+	bundle := &stack.Bundle{Name: "apps", SourceRef: testSR(), Applications: []*stack.Application{kustomizationApp("vendor", vendor)}}
+	return &stack.Cluster{Name: "prod", Node: &stack.Node{Name: "root", Bundle: bundle}}
+}
+
+// findKustomization returns the Kustomization named name in ml and its
+// sub-layouts.
+func findKustomization(ml *layout.ManifestLayout, name string) *kustv1.Kustomization {
+	for _, obj := range ml.Resources {
+		if k, ok := obj.(*kustv1.Kustomization); ok && k.Name == name {
+			return k
+		}
+	}
+	for _, child := range ml.Children {
+		if k := findKustomization(child, name); k != nil {
+			return k
+		}
+	}
+	return nil
+}
+
+func TestLayoutIntegrator_IntegratedDecryptionSkipsAppKustomizations(t *testing.T) {
+	vendor := &kustv1.Kustomization{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kustv1.GroupVersion.String(), Kind: kustv1.KustomizationKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "vendor-sync", Namespace: "flux-system"},
+	}
+	cluster := integratedAppKustomizationCluster(vendor)
+	cluster.Decryption = &stack.Decryption{SecretRef: "cluster-keys"}
+
+	rules := layout.DefaultLayoutRules()
+	rules.FluxPlacement = layout.FluxIntegratedPerLayout
+	ml, err := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator()).CreateLayoutWithResources(cluster, rules)
+	if err != nil {
+		t.Fatalf("CreateLayoutWithResources: %v", err)
+	}
+
+	if k := findKustomization(ml, "apps"); k == nil || k.Spec.Decryption == nil {
+		t.Fatalf("expected decryption on the bundle Kustomization, got %+v", k)
+	}
+	if findKustomization(ml, "vendor-sync") != vendor {
+		t.Fatal("expected the application's Kustomization in the layout")
+	}
+	if vendor.Spec.Decryption != nil {
+		t.Errorf("expected the application's Kustomization to be left alone, got decryption %+v", vendor.Spec.Decryption)
+	}
+}
+
+func TestLayoutIntegrator_IntegratedPostBuildSkipsAppKustomizations(t *testing.T) {
+	vendor := &kustv1.Kustomization{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kustv1.GroupVersion.String(), Kind: kustv1.KustomizationKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "vendor-sync", Namespace: "flux-system"},
+	}
+	cluster := integratedAppKustomizationCluster(vendor)
+	cluster.PostBuild = &stack.PostBuild{
+		Substitute:     map[string]string{"CLUSTER_NAME": "prod"},
+		SubstituteFrom: []stack.SubstituteRef{{Kind: "ConfigMap", Name: "cluster-vars"}},
+	}
+
+	rules := layout.DefaultLayoutRules()
+	rules.FluxPlacement = layout.FluxIntegratedPerLayout
+	ml, err := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator()).CreateLayoutWithResources(cluster, rules)
+	if err != nil {
+		t.Fatalf("CreateLayoutWithResources: %v", err)
+	}
+
+	k := findKustomization(ml, "apps")
+	if k == nil || k.Spec.PostBuild == nil || k.Spec.PostBuild.Substitute["CLUSTER_NAME"] != "prod" || len(k.Spec.PostBuild.SubstituteFrom) != 1 {
+		t.Fatalf("expected the cluster variables on the bundle Kustomization, got %+v", k)
+	}
+	if vendor.Spec.PostBuild != nil {
+		t.Errorf("expected the application's Kustomization to be left alone, got postBuild %+v", vendor.Spec.PostBuild)
+	}
+}
+
+func TestLayoutIntegrator_IntegratedTenancy(t *testing.T) {
+	integrator := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator())
+
+	ref := &stack.SourceRef{Kind: "GitRepository", Name: "test-source", Namespace: "flux-system"}
+	child := &stack.Node{
+		Name:       "child",
+		ParentPath: "root",
+		Bundle:     &stack.Bundle{Name: "child-bundle", SourceRef: ref},
+		Tenancy:    &stack.Tenancy{ServiceAccountName: "tenant", TargetNamespace: "tenant-ns"},
+	}
+	root := &stack.Node{Name: "root", Bundle: &stack.Bundle{Name: "root-bundle", SourceRef: ref}, Children: []*stack.Node{child}}
+	cluster := &stack.Cluster{Name: "test-cluster", Node: root}
+
+	childLayout := &layout.ManifestLayout{Name: "child"}
+	rootLayout := &layout.ManifestLayout{Name: "root", Children: []*layout.ManifestLayout{childLayout}}
+
+	rules := layout.DefaultLayoutRules()
+	rules.FluxPlacement = layout.FluxIntegratedPerLayout
+	if err := integrator.IntegrateWithLayout(rootLayout, cluster, rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"root-bundle": "", "child-bundle": "tenant"}
+	found := 0
+	for _, ml := range []*layout.ManifestLayout{rootLayout, childLayout} {
+		for _, obj := range ml.Resources {
+			k, ok := obj.(*kustv1.Kustomization)
+			if !ok {
+				continue
+			}
+			found++
+			if k.Spec.ServiceAccountName != want[k.Name] {
+				t.Errorf("%s: serviceAccountName = %q, want %q", k.Name, k.Spec.ServiceAccountName, want[k.Name])
+			}
+			if want[k.Name] != "" && k.Spec.TargetNamespace != "tenant-ns" {
+				t.Errorf("%s: targetNamespace = %q, want tenant-ns", k.Name, k.Spec.TargetNamespace)
+			}
+		}
+	}
+	if found != len(want) {
+		t.Errorf("expected %d Kustomizations, found %d", len(want), found)
+	}
+}
+
 func TestLayoutIntegrator_SeparateMode_EmptyCluster(t *testing.T) {
 	generator := fluxstack.NewResourceGenerator()
 	integrator := fluxstack.NewLayoutIntegrator(generator)
@@ -1494,3 +1740,180 @@ func TestCreateLayoutWithResources_ClusterNameEqualsNodeName_SpecPath(t *testing
 		}
 	}
 }
+
+// TestCreateLayoutWithResources_MaxDepthIntegrated verifies that MaxDepth
+// moves the Kustomization placed next to a flattened layout to its new
+// parent, renamed after the flattened directory, and that layouts left
+// empty lose their Kustomization.
+func TestCreateLayoutWithResources_MaxDepthIntegrated(t *testing.T) {
+	bundle := &stack.Bundle{
+		Name:         "apps",
+		SourceRef:    testSR(),
+		Applications: []*stack.Application{fakeUmbrellaApp("web", "web-cm")},
+	}
+	cluster := &stack.Cluster{Name: "prod", Node: &stack.Node{Name: "prod", Bundle: bundle}}
+
+	integrator := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator())
+	ml, err := integrator.CreateLayoutWithResources(cluster, layout.LayoutRules{
+		BundleGrouping:      layout.GroupByName,
+		ApplicationGrouping: layout.GroupByName,
+		FluxPlacement:       layout.FluxIntegratedPerLayout,
+		MaxDepth:            1,
+	})
+	if err != nil {
+		t.Fatalf("CreateLayoutWithResources: %v", err)
+	}
+
+	if len(ml.Children) != 1 || ml.Children[0].Name != "apps-web" {
+		t.Fatalf("expected the single child apps-web, got %v", ml.Children)
+	}
+	paths := map[string]string{}
+	collectKustPaths(ml, paths)
+	if got := paths["apps-web"]; got != "prod/apps-web" {
+		t.Errorf("Kustomization apps-web spec.path = %q, want %q (all: %v)", got, "prod/apps-web", paths)
+	}
+	for _, stale := range []string{"web", "apps"} {
+		if _, ok := paths[stale]; ok {
+			t.Errorf("unexpected Kustomization %q left after flattening (all: %v)", stale, paths)
+		}
+	}
+	if !augHasCR(ml.Resources, "apps-web") {
+		t.Error("expected the apps-web Kustomization in the root layout")
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+	root := filepath.Join(dir, "clusters", "prod")
+	if _, err := os.Stat(filepath.Join(root, "flux-system-kustomization-apps-web.yaml")); err != nil {
+		t.Errorf("expected the renamed Kustomization file: %v", err)
+	}
+	augAssertOnce(t, filepath.Join(dir, "clusters"), "prod", "flux-system-kustomization-apps-web.yaml")
+	if _, err := os.Stat(filepath.Join(root, "apps-web", "default-configmap-web-cm.yaml")); err != nil {
+		t.Errorf("expected the application in the flattened directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "apps")); !os.IsNotExist(err) {
+		t.Error("expected no nested apps directory")
+	}
+}
+
+// mergeFluxCluster returns the integrated layout of a cluster whose root
+// bundle holds an infra application shared by every cluster and a web
+// application specific to name.
+func mergeFluxCluster(t *testing.T, name string) *layout.ManifestLayout {
+	t.Helper()
+	bundle := &stack.Bundle{
+		Name:         "apps",
+		SourceRef:    testSR(),
+		Applications: []*stack.Application{fakeUmbrellaApp("infra", "infra"), fakeUmbrellaApp("web", "web-"+name)},
+	}
+	cluster := &stack.Cluster{Name: name, Node: &stack.Node{Name: "root", Bundle: bundle}}
+	ml, err := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator()).CreateLayoutWithResources(cluster, layout.LayoutRules{
+		ClusterName:                 name,
+		FluxPlacement:               layout.FluxIntegratedPerLayout,
+		KustomizationPerApplication: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateLayoutWithResources %s: %v", name, err)
+	}
+	return ml
+}
+
+// TestMergeClusters_IntegratedKustomizationFollowsSharedLayout verifies that
+// the Kustomization of a layout moved to the shared directory points at the
+// shared copy and stays the parent's only reference to it.
+func TestMergeClusters_IntegratedKustomizationFollowsSharedLayout(t *testing.T) {
+	merged, err := layout.MergeClusters([]*layout.ManifestLayout{
+		mergeFluxCluster(t, "prod"),
+		mergeFluxCluster(t, "staging"),
+	}, layout.LayoutRules{})
+	if err != nil {
+		t.Fatalf("MergeClusters: %v", err)
+	}
+
+	for _, cluster := range []*layout.ManifestLayout{merged.Children[0], merged.Children[1]} {
+		name := cluster.FullRepoPath()
+		paths := map[string]string{}
+		collectKustPaths(cluster, paths)
+		if got := paths["infra"]; got != "infrastructure/root/infra" {
+			t.Errorf("%s: Kustomization infra spec.path = %q, want %q", name, got, "infrastructure/root/infra")
+		}
+		if got, want := paths["web"], name+"/root/web"; got != want {
+			t.Errorf("%s: Kustomization web spec.path = %q, want %q", name, got, want)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), merged); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+	for _, cluster := range []string{"prod", "staging"} {
+		kust, err := os.ReadFile(filepath.Join(dir, "clusters", cluster, "root", "kustomization.yaml"))
+		if err != nil {
+			t.Fatalf("read %s kustomization.yaml: %v", cluster, err)
+		}
+		if strings.Contains(string(kust), "infrastructure") {
+			t.Errorf("%s: expected the shared layout to be applied only through its Kustomization, got:\n%s", cluster, kust)
+		}
+		if strings.Count(string(kust), "flux-system-kustomization-infra.yaml") != 1 {
+			t.Errorf("%s: expected one reference to the infra Kustomization, got:\n%s", cluster, kust)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "clusters", "infrastructure", "root", "infra", "kustomization.yaml")); err != nil {
+		t.Errorf("expected the shared layout to be written: %v", err)
+	}
+}
+
+// TestIntegrateWithLayout_ValidatesFluxPaths runs the Flux path check on
+// integrator output: resolvable paths pass in strict mode, and a bundle
+// Kustomization whose path is not a layout directory is reported as a
+// warning or, with StrictFluxPaths, fails the integration.
+func TestIntegrateWithLayout_ValidatesFluxPaths(t *testing.T) {
+	integrator := fluxstack.NewLayoutIntegrator(fluxstack.NewResourceGenerator())
+
+	umbrella := &stack.Bundle{
+		Name:      "platform",
+		SourceRef: testSR(),
+		Children: []*stack.Bundle{
+			{Name: "platform-services", SourceRef: testSR(), Applications: []*stack.Application{fakeUmbrellaApp("svc", "svc-cm")}},
+			{Name: "platform-apps", SourceRef: testSR(), Applications: []*stack.Application{fakeUmbrellaApp("web", "web-cm")}},
+		},
+	}
+	valid := &stack.Cluster{Name: "platform", Node: &stack.Node{Name: "platform", Bundle: umbrella}}
+	if _, err := integrator.CreateLayoutWithResources(valid, layout.LayoutRules{
+		ClusterName:         "platform",
+		BundleGrouping:      layout.GroupByName,
+		ApplicationGrouping: layout.GroupByName,
+		FluxPlacement:       layout.FluxIntegratedPerLayout,
+		StrictFluxPaths:     true,
+	}); err != nil {
+		t.Fatalf("expected resolvable Flux paths, got: %v", err)
+	}
+
+	broken := func() *stack.Cluster {
+		bundle := &stack.Bundle{Name: "apps", SourceRef: testSR(), Applications: []*stack.Application{fakeUmbrellaApp("web", "web-cm")}}
+		return &stack.Cluster{Name: "prod", Node: &stack.Node{Name: "root", Bundle: bundle}}
+	}
+	warnings := &errors.Warnings{}
+	if _, err := integrator.CreateLayoutWithResources(broken(), layout.LayoutRules{
+		ClusterName:   "prod",
+		FluxPlacement: layout.FluxIntegratedPerLayout,
+		Warnings:      warnings,
+	}); err != nil {
+		t.Fatalf("CreateLayoutWithResources: %v", err)
+	}
+	items := warnings.Items()
+	if len(items) != 1 || !strings.Contains(items[0].Message, `path "apps" referenced from layout "prod/root" does not exist`) {
+		t.Errorf("expected a warning for the apps Kustomization, got %v", items)
+	}
+
+	_, err := integrator.CreateLayoutWithResources(broken(), layout.LayoutRules{
+		ClusterName:     "prod",
+		FluxPlacement:   layout.FluxIntegratedPerLayout,
+		StrictFluxPaths: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), `path "apps" referenced from layout "prod/root" does not exist`) {
+		t.Errorf("expected StrictFluxPaths to reject the apps Kustomization, got: %v", err)
+	}
+}
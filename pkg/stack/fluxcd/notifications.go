@@ -0,0 +1,165 @@
+package fluxcd
+
+import (
+	kustv1 "github.com/fluxcd/kustomize-controller/api/v1"
+	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+	pubfluxcd "github.com/go-kure/kure/pkg/kubernetes/fluxcd"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+// NotificationConfig configures the notification stack generated by
+// GenerateNotificationStack.
+type NotificationConfig struct {
+	// Name of the generated Provider, Alert and Receiver. Defaults to "kure".
+	Name string
+	// Namespace of the generated objects. Defaults to the generator's
+	// DefaultNamespace.
+	Namespace string
+
+	// ProviderType is the notification Provider type, e.g. "slack" or
+	// "msteams". Required.
+	ProviderType string
+	// Channel, Address and SecretRef configure the Provider. SecretRef
+	// names a Secret holding the address or token.
+	Channel   string
+	Address   string
+	SecretRef string
+	// EventSeverity filters the alerted events: "info" (default) or "error".
+	EventSeverity string
+
+	// ReceiverType enables a webhook Receiver of this type, e.g. "github"
+	// or "generic", that triggers the sources used by the Kustomizations.
+	ReceiverType string
+	// ReceiverSecretRef names the Secret with the webhook token. Required
+	// with ReceiverType.
+	ReceiverSecretRef string
+	// ReceiverEvents filters the webhook events, e.g. "push".
+	ReceiverEvents []string
+}
+
+// GenerateNotificationStack creates a notification Provider and an Alert
+// whose event sources are every Kustomization and HelmRelease in ml, the
+// layout generated for a cluster by LayoutIntegrator.CreateLayoutWithResources
+// or the workflow. The objects already generated are used, so applications
+// are not generated again. With cfg.ReceiverType it also creates a Receiver
+// for the sources those Kustomizations reference.
+func (g *ResourceGenerator) GenerateNotificationStack(ml *layout.ManifestLayout, cfg NotificationConfig) ([]client.Object, error) {
+	if cfg.ProviderType == "" {
+		return nil, errors.ResourceValidationError("NotificationConfig", cfg.Name, "providerType",
+			"a provider type is required", nil)
+	}
+	switch cfg.EventSeverity {
+	case "":
+		cfg.EventSeverity = "info"
+	case "info", "error":
+	default:
+		return nil, errors.NewValidationError("eventSeverity", cfg.EventSeverity, "NotificationConfig",
+			[]string{"info", "error"})
+	}
+	if cfg.ReceiverType != "" && cfg.ReceiverSecretRef == "" {
+		return nil, errors.ResourceValidationError("NotificationConfig", cfg.Name, "receiverSecretRef",
+			"a receiver needs a Secret with the webhook token", nil)
+	}
+	if cfg.Name == "" {
+		cfg.Name = "kure"
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = g.DefaultNamespace
+	}
+
+	provider := pubfluxcd.CreateProvider(cfg.Name, cfg.Namespace)
+	pubfluxcd.SetProviderType(provider, cfg.ProviderType)
+	if cfg.Channel != "" {
+		pubfluxcd.SetProviderChannel(provider, cfg.Channel)
+	}
+	if cfg.Address != "" {
+		pubfluxcd.SetProviderAddress(provider, cfg.Address)
+	}
+	if cfg.SecretRef != "" {
+		pubfluxcd.SetProviderSecretRef(provider, &meta.LocalObjectReference{Name: cfg.SecretRef})
+	}
+
+	alert := pubfluxcd.CreateAlert(cfg.Name, cfg.Namespace)
+	pubfluxcd.SetAlertProviderRef(alert, meta.LocalObjectReference{Name: cfg.Name})
+	pubfluxcd.SetAlertEventSeverity(alert, cfg.EventSeverity)
+
+	seen := map[string]bool{}
+	var sources []notificationv1.CrossNamespaceObjectReference
+	sourceSeen := map[string]bool{}
+	var visit func(ml *layout.ManifestLayout)
+	visit = func(ml *layout.ManifestLayout) {
+		if ml == nil {
+			return
+		}
+		for _, obj := range ml.Resources {
+			ref, ok := eventSource(obj)
+			if !ok {
+				continue
+			}
+			if key := objectRefKey(ref); !seen[key] {
+				seen[key] = true
+				pubfluxcd.AddAlertEventSource(alert, ref)
+			}
+
+			kust, ok := obj.(*kustv1.Kustomization)
+			if !ok || kust.Spec.SourceRef.Kind == "" || kust.Spec.SourceRef.Name == "" {
+				continue
+			}
+			src := notificationv1.CrossNamespaceObjectReference{
+				Kind:      kust.Spec.SourceRef.Kind,
+				Name:      kust.Spec.SourceRef.Name,
+				Namespace: kust.Spec.SourceRef.Namespace,
+			}
+			if src.Namespace == "" {
+				src.Namespace = kust.Namespace
+			}
+			if key := objectRefKey(src); !sourceSeen[key] {
+				sourceSeen[key] = true
+				sources = append(sources, src)
+			}
+		}
+		for _, child := range ml.Children {
+			visit(child)
+		}
+	}
+	visit(ml)
+
+	objs := []client.Object{provider, alert}
+	if cfg.ReceiverType != "" {
+		receiver := pubfluxcd.CreateReceiver(cfg.Name, cfg.Namespace)
+		pubfluxcd.SetReceiverType(receiver, cfg.ReceiverType)
+		pubfluxcd.SetReceiverSecretRef(receiver, meta.LocalObjectReference{Name: cfg.ReceiverSecretRef})
+		for _, event := range cfg.ReceiverEvents {
+			pubfluxcd.AddReceiverEvent(receiver, event)
+		}
+		for _, src := range sources {
+			pubfluxcd.AddReceiverResource(receiver, src)
+		}
+		objs = append(objs, receiver)
+	}
+	return objs, nil
+}
+
+// eventSource returns the alert event source for obj when it is a Flux
+// Kustomization or HelmRelease, typed or unstructured.
+func eventSource(obj client.Object) (notificationv1.CrossNamespaceObjectReference, bool) {
+	if obj == nil {
+		return notificationv1.CrossNamespaceObjectReference{}, false
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	switch {
+	case gvk.Group == kustv1.GroupVersion.Group && gvk.Kind == kustv1.KustomizationKind:
+	case gvk.Group == "helm.toolkit.fluxcd.io" && gvk.Kind == "HelmRelease":
+	default:
+		return notificationv1.CrossNamespaceObjectReference{}, false
+	}
+	return notificationv1.CrossNamespaceObjectReference{Kind: gvk.Kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}, true
+}
+
+func objectRefKey(ref notificationv1.CrossNamespaceObjectReference) string {
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
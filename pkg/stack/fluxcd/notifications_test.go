@@ -0,0 +1,139 @@
+package fluxcd_test
+
+import (
+	"testing"
+
+	notificationv1 "github.com/fluxcd/notification-controller/api/v1"
+	notificationv1beta3 "github.com/fluxcd/notification-controller/api/v1beta3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pubfluxcd "github.com/go-kure/kure/pkg/kubernetes/fluxcd"
+	"github.com/go-kure/kure/pkg/stack"
+	fluxstack "github.com/go-kure/kure/pkg/stack/fluxcd"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+// countingAppConfig returns objs and counts how often it is generated.
+type countingAppConfig struct {
+	objs  []*client.Object
+	calls int
+}
+
+func (c *countingAppConfig) Generate(*stack.Application) ([]*client.Object, error) {
+	c.calls++
+	return c.objs, nil
+}
+
+// notificationTestLayout returns the layout of a cluster with the bundles
+// infra and apps, the latter holding an application that generates the
+// HelmRelease podinfo, together with that application's config.
+func notificationTestLayout(t *testing.T) (*layout.ManifestLayout, *countingAppConfig) {
+	t.Helper()
+	var hr client.Object = pubfluxcd.CreateHelmRelease("podinfo", "apps")
+	cfg := &countingAppConfig{objs: []*client.Object{&hr}}
+	app := stack.NewApplication("podinfo", "apps", cfg)
+	ref := &stack.SourceRef{Kind: "GitRepository", Name: "fleet", Namespace: "flux-system"}
+	cluster := &stack.Cluster{
+		Name: "prod",
+		Node: &stack.Node{
+			Name:   "root",
+			Bundle: &stack.Bundle{Name: "infra", SourceRef: ref},
+			Children: []*stack.Node{{
+				Name:       "apps",
+				ParentPath: "root",
+				Bundle:     &stack.Bundle{Name: "apps", SourceRef: ref, Applications: []*stack.Application{app}},
+			}},
+		},
+	}
+	ml, err := fluxstack.Engine().LayoutInteg.CreateLayoutWithResources(cluster, layout.DefaultLayoutRules())
+	if err != nil {
+		t.Fatalf("CreateLayoutWithResources: %v", err)
+	}
+	return ml, cfg
+}
+
+func TestNotificationStack(t *testing.T) {
+	ml, app := notificationTestLayout(t)
+	objs, err := fluxstack.Engine().NotificationStack(ml, fluxstack.NotificationConfig{
+		ProviderType:      "slack",
+		Channel:           "alerts",
+		SecretRef:         "slack-webhook",
+		ReceiverType:      "github",
+		ReceiverSecretRef: "webhook-token",
+		ReceiverEvents:    []string{"push"},
+	})
+	if err != nil {
+		t.Fatalf("NotificationStack: %v", err)
+	}
+	if len(objs) != 3 {
+		t.Fatalf("expected Provider, Alert and Receiver, got %d objects", len(objs))
+	}
+	if app.calls != 1 {
+		t.Errorf("expected the application to be generated once, got %d", app.calls)
+	}
+
+	provider, ok := objs[0].(*notificationv1beta3.Provider)
+	if !ok {
+		t.Fatalf("expected Provider, got %T", objs[0])
+	}
+	if provider.Name != "kure" || provider.Namespace != "flux-system" || provider.Spec.Type != "slack" || provider.Spec.Channel != "alerts" {
+		t.Errorf("unexpected provider %s/%s %+v", provider.Namespace, provider.Name, provider.Spec)
+	}
+
+	alert, ok := objs[1].(*notificationv1beta3.Alert)
+	if !ok {
+		t.Fatalf("expected Alert, got %T", objs[1])
+	}
+	if alert.Spec.ProviderRef.Name != "kure" || alert.Spec.EventSeverity != "info" {
+		t.Errorf("unexpected alert spec %+v", alert.Spec)
+	}
+	got := map[string]bool{}
+	for _, src := range alert.Spec.EventSources {
+		got[src.Kind+"/"+src.Namespace+"/"+src.Name] = true
+	}
+	for _, want := range []string{"Kustomization/flux-system/infra", "Kustomization/flux-system/apps", "HelmRelease/apps/podinfo"} {
+		if !got[want] {
+			t.Errorf("missing event source %s in %v", want, alert.Spec.EventSources)
+		}
+	}
+	if len(alert.Spec.EventSources) != 3 {
+		t.Errorf("expected 3 event sources, got %d", len(alert.Spec.EventSources))
+	}
+
+	receiver, ok := objs[2].(*notificationv1.Receiver)
+	if !ok {
+		t.Fatalf("expected Receiver, got %T", objs[2])
+	}
+	if receiver.Spec.Type != "github" || receiver.Spec.SecretRef == nil || receiver.Spec.SecretRef.Name != "webhook-token" {
+		t.Errorf("unexpected receiver spec %+v", receiver.Spec)
+	}
+	if len(receiver.Spec.Resources) != 1 || receiver.Spec.Resources[0].Kind != "GitRepository" || receiver.Spec.Resources[0].Name != "fleet" {
+		t.Errorf("expected the shared GitRepository as the only receiver resource, got %+v", receiver.Spec.Resources)
+	}
+}
+
+func TestNotificationStack_WithoutReceiver(t *testing.T) {
+	ml, _ := notificationTestLayout(t)
+	objs, err := fluxstack.Engine().NotificationStack(ml, fluxstack.NotificationConfig{ProviderType: "msteams"})
+	if err != nil {
+		t.Fatalf("NotificationStack: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Errorf("expected Provider and Alert, got %d objects", len(objs))
+	}
+}
+
+func TestNotificationStack_InvalidConfig(t *testing.T) {
+	ml, _ := notificationTestLayout(t)
+	for name, cfg := range map[string]fluxstack.NotificationConfig{
+		"missing provider type":   {},
+		"unknown severity":        {ProviderType: "slack", EventSeverity: "debug"},
+		"receiver without secret": {ProviderType: "slack", ReceiverType: "github"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := fluxstack.Engine().NotificationStack(ml, cfg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -27,6 +27,14 @@ type ResourceGenerator struct {
 	DefaultInterval time.Duration
 	// DefaultNamespace is the default namespace for generated Flux resources
 	DefaultNamespace string
+	// IncludeClusterSource emits the source object described by the
+	// cluster's bootstrap configuration alongside the Kustomizations, so the
+	// output is a complete Flux tree. See GenerateClusterSource.
+	IncludeClusterSource bool
+	// DependOnParentNode adds the Kustomization of the nearest ancestor node
+	// with a bundle to spec.dependsOn of every node's Kustomization, so Flux
+	// applies the node tree top-down.
+	DependOnParentNode bool
 }
 
 // NewResourceGenerator creates a FluxCD resource generator with sensible defaults.
@@ -48,18 +56,125 @@ func (g *ResourceGenerator) GenerateFromCluster(c *stack.Cluster) ([]client.Obje
 	if err := stack.ValidateCluster(c); err != nil {
 		return nil, err
 	}
-	return g.GenerateFromNode(c.Node)
+	resources, err := g.generateFromNode(c.Node, "", c.Decryption, nil)
+	if err != nil {
+		return nil, err
+	}
+	mergeClusterPostBuild(resources, c.PostBuild)
+	if g.IncludeClusterSource {
+		source, err := g.GenerateClusterSource(c)
+		if err != nil {
+			return nil, err
+		}
+		if source != nil {
+			resources = append([]client.Object{source}, resources...)
+		}
+	}
+	return resources, nil
+}
+
+// GenerateClusterSource creates the GitRepository or OCIRepository described
+// by c.GitOps.Bootstrap (SourceKind, SourceURL and SourceRef, which is the
+// branch for Git and the tag for OCI sources). An empty SourceKind selects
+// OCIRepository, as in the bootstrap generator. It returns nil when the
+// cluster has no bootstrap SourceURL.
+//
+// The source takes the name and namespace of the first bundle SourceRef of
+// the same kind that has no URL of its own, i.e. the source the generated
+// Kustomizations expect to exist, and falls back to "flux-system" in
+// DefaultNamespace. The interval is DefaultInterval.
+func (g *ResourceGenerator) GenerateClusterSource(c *stack.Cluster) (client.Object, error) {
+	if c == nil || c.GitOps == nil || c.GitOps.Bootstrap == nil || c.GitOps.Bootstrap.SourceURL == "" {
+		return nil, nil
+	}
+	config := c.GitOps.Bootstrap
+
+	ref := &stack.SourceRef{Kind: config.SourceKind, Name: "flux-system", URL: config.SourceURL}
+	if ref.Kind == "" {
+		ref.Kind = "OCIRepository"
+	}
+	if ref.Kind == "GitRepository" {
+		ref.Branch = config.SourceRef
+	} else {
+		ref.Tag = config.SourceRef
+	}
+	if referenced := findReferencedSource(c.Node, ref.Kind); referenced != nil {
+		ref.Name = referenced.Name
+		ref.Namespace = referenced.Namespace
+	}
+
+	source, err := g.createSource(ref, ref.Name)
+	if err != nil {
+		return nil, errors.ResourceValidationError("Cluster", c.Name, "gitops.bootstrap.sourceKind",
+			fmt.Sprintf("failed to create cluster source: %v", err), err)
+	}
+	return source, nil
+}
+
+// findReferencedSource returns the first SourceRef of the given kind without
+// a URL in the bundles below n, including umbrella children, in tree order.
+func findReferencedSource(n *stack.Node, kind string) *stack.SourceRef {
+	if n == nil {
+		return nil
+	}
+	if ref := findReferencedBundleSource(n.Bundle, kind); ref != nil {
+		return ref
+	}
+	for _, child := range n.Children {
+		if ref := findReferencedSource(child, kind); ref != nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+func findReferencedBundleSource(b *stack.Bundle, kind string) *stack.SourceRef {
+	if b == nil {
+		return nil
+	}
+	if ref := b.SourceRef; ref != nil && ref.Kind == kind && ref.Name != "" && ref.URL == "" {
+		return ref
+	}
+	for _, child := range b.Children {
+		if ref := findReferencedBundleSource(child, kind); ref != nil {
+			return ref
+		}
+	}
+	return nil
 }
 
 // GenerateFromNode creates Flux resources from a node and its children.
 // When a node's bundle is an umbrella (len(Bundle.Children) > 0), the umbrella
 // closure is walked and flattened into the returned slice so flat-list
 // consumers (e.g. separate Flux placement) see every child Kustomization CR.
+//
+// Every generated Kustomization gets spec.decryption from the nearest
+// Node.Decryption, or from Cluster.Decryption when called through
+// GenerateFromCluster. Likewise, spec.serviceAccountName and
+// spec.targetNamespace are set from the nearest Node.Tenancy.
+//
+// With DependOnParentNode, and for inherited decryption and tenancy
+// settings, the
+// ancestors of n are found through its runtime parent reference (see
+// stack.Node.SetParent); descendants are found through the tree.
 func (g *ResourceGenerator) GenerateFromNode(n *stack.Node) ([]client.Object, error) {
 	if n == nil {
 		return nil, nil
 	}
+	parent := n.GetParent()
+	return g.generateFromNode(n, parentNodeKustomization(parent), inheritedDecryption(parent), inheritedTenancy(parent))
+}
 
+// generateFromNode implements GenerateFromNode. parentKust is the name of
+// the Kustomization of the nearest ancestor node with a bundle, and dec and
+// tenancy the decryption and tenancy configuration inherited by n.
+func (g *ResourceGenerator) generateFromNode(n *stack.Node, parentKust string, dec *stack.Decryption, tenancy *stack.Tenancy) ([]client.Object, error) {
+	if n.Decryption != nil {
+		dec = n.Decryption
+	}
+	if n.Tenancy != nil {
+		tenancy = n.Tenancy
+	}
 	var resources []client.Object
 
 	// Generate resources for this node's bundle
@@ -69,6 +184,7 @@ func (g *ResourceGenerator) GenerateFromNode(n *stack.Node) ([]client.Object, er
 			return nil, errors.ResourceValidationError("Node", n.Name, "bundle",
 				fmt.Sprintf("failed to generate bundle resources: %v", err), err)
 		}
+		g.addParentNodeDependency(bundleResources, parentKust)
 		resources = append(resources, bundleResources...)
 
 		// Walk umbrella closure so flat-list consumers see descendant CRs.
@@ -82,10 +198,19 @@ func (g *ResourceGenerator) GenerateFromNode(n *stack.Node) ([]client.Object, er
 			resources = append(resources, closure...)
 		}
 	}
+	setDecryption(resources, dec)
+	setTenancy(resources, tenancy)
 
 	// Generate resources for child nodes
+	childParent := parentKust
+	if n.Bundle != nil {
+		childParent = n.Bundle.Name
+	}
 	for _, child := range n.Children {
-		childResources, err := g.GenerateFromNode(child)
+		if child == nil {
+			continue
+		}
+		childResources, err := g.generateFromNode(child, childParent, dec, tenancy)
 		if err != nil {
 			return nil, errors.ResourceValidationError("Node", n.Name, "children",
 				fmt.Sprintf("failed to generate child node resources: %v", err), err)
@@ -96,6 +221,148 @@ func (g *ResourceGenerator) GenerateFromNode(n *stack.Node) ([]client.Object, er
 	return resources, nil
 }
 
+// parentNodeKustomization returns the name of the Kustomization generated for
+// the nearest node with a bundle, starting at n.
+func parentNodeKustomization(n *stack.Node) string {
+	for ; n != nil; n = n.GetParent() {
+		if n.Bundle != nil {
+			return n.Bundle.Name
+		}
+	}
+	return ""
+}
+
+// inheritedDecryption returns the decryption configuration of the nearest
+// node, starting at n, that declares one.
+func inheritedDecryption(n *stack.Node) *stack.Decryption {
+	for ; n != nil; n = n.GetParent() {
+		if n.Decryption != nil {
+			return n.Decryption
+		}
+	}
+	return nil
+}
+
+// setDecryption sets spec.decryption from dec on the Kustomizations in objs
+// that have none yet.
+func setDecryption(objs []client.Object, dec *stack.Decryption) {
+	if dec == nil {
+		return
+	}
+	provider := dec.Provider
+	if provider == "" {
+		provider = "sops"
+	}
+	for _, obj := range objs {
+		kust, ok := obj.(*kustv1.Kustomization)
+		if !ok || kust.Spec.Decryption != nil {
+			continue
+		}
+		kust.Spec.Decryption = &kustv1.Decryption{Provider: provider}
+		if dec.SecretRef != "" {
+			kust.Spec.Decryption.SecretRef = &metaapi.LocalObjectReference{Name: dec.SecretRef}
+		}
+	}
+}
+
+// inheritedTenancy returns the tenancy configuration of the nearest node,
+// starting at n, that declares one.
+func inheritedTenancy(n *stack.Node) *stack.Tenancy {
+	for ; n != nil; n = n.GetParent() {
+		if n.Tenancy != nil {
+			return n.Tenancy
+		}
+	}
+	return nil
+}
+
+// setTenancy sets spec.serviceAccountName and spec.targetNamespace of the
+// Kustomizations in objs from t. Both fields come from the same Tenancy, so
+// a field t leaves empty is cleared rather than kept from another node.
+func setTenancy(objs []client.Object, t *stack.Tenancy) {
+	if t == nil {
+		return
+	}
+	for _, obj := range objs {
+		kust, ok := obj.(*kustv1.Kustomization)
+		if !ok {
+			continue
+		}
+		kust.Spec.ServiceAccountName = t.ServiceAccountName
+		kust.Spec.TargetNamespace = t.TargetNamespace
+	}
+}
+
+// mergeClusterPostBuild merges the cluster-wide substitution variables of pb
+// into spec.postBuild of the Kustomizations in objs. Inline variables are
+// only added when the Kustomization does not set them, and references are
+// placed before the Kustomization's own, so that bundle settings win.
+func mergeClusterPostBuild(objs []client.Object, pb *stack.PostBuild) {
+	if pb == nil || (len(pb.Substitute) == 0 && len(pb.SubstituteFrom) == 0) {
+		return
+	}
+	for _, obj := range objs {
+		kust, ok := obj.(*kustv1.Kustomization)
+		if !ok {
+			continue
+		}
+		if kust.Spec.PostBuild == nil {
+			kust.Spec.PostBuild = &kustv1.PostBuild{}
+		}
+		target := kust.Spec.PostBuild
+		// The Kustomization may share its map with the bundle; copy it
+		// before adding variables.
+		substitute := make(map[string]string, len(target.Substitute)+len(pb.Substitute))
+		for key, value := range pb.Substitute {
+			substitute[key] = value
+		}
+		for key, value := range target.Substitute {
+			substitute[key] = value
+		}
+		if len(substitute) > 0 {
+			target.Substitute = substitute
+		}
+		var refs []kustv1.SubstituteReference
+		for _, ref := range pb.SubstituteFrom {
+			if hasSubstituteReference(target.SubstituteFrom, ref.Kind, ref.Name) {
+				continue
+			}
+			refs = append(refs, kustv1.SubstituteReference{Kind: ref.Kind, Name: ref.Name, Optional: ref.Optional})
+		}
+		if len(refs) > 0 {
+			target.SubstituteFrom = append(refs, target.SubstituteFrom...)
+		}
+	}
+}
+
+func hasSubstituteReference(refs []kustv1.SubstituteReference, kind, name string) bool {
+	for _, ref := range refs {
+		if ref.Kind == kind && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addParentNodeDependency adds parentKust to spec.dependsOn of the node
+// Kustomization, the first object GenerateFromBundle returns, when
+// DependOnParentNode is set.
+func (g *ResourceGenerator) addParentNodeDependency(bundleResources []client.Object, parentKust string) {
+	if !g.DependOnParentNode || parentKust == "" || len(bundleResources) == 0 {
+		return
+	}
+	kust, ok := bundleResources[0].(*kustv1.Kustomization)
+	if !ok {
+		return
+	}
+	for _, dep := range kust.Spec.DependsOn {
+		if dep.Name == parentKust {
+			return
+		}
+	}
+	kust.Spec.DependsOn = append(kust.Spec.DependsOn, kustv1.DependencyReference{Name: parentKust})
+}
+
 // generateUmbrellaClosure walks a bundle's umbrella Children subtree and emits
 // a Kustomization (and, when URL is set, a Source) for every descendant. The
 // parent umbrella itself is NOT emitted here — callers handle it separately
@@ -1,6 +1,7 @@
 package fluxcd_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -147,6 +148,171 @@ func TestGenerateFromNode_Recursive(t *testing.T) {
 	}
 }
 
+func TestGenerateFromNode_DependOnParentNode(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	gen.DependOnParentNode = true
+
+	// The bundle-less "group" node is skipped: "leaf" depends on "root-bundle".
+	leaf := &stack.Node{Name: "leaf", Bundle: &stack.Bundle{
+		Name:           "leaf-bundle",
+		NamedDependsOn: []string{"root-bundle"},
+	}}
+	apps := &stack.Node{Name: "apps", Bundle: &stack.Bundle{Name: "apps-bundle"}}
+	root := &stack.Node{
+		Name:   "root",
+		Bundle: &stack.Bundle{Name: "root-bundle"},
+		Children: []*stack.Node{
+			{Name: "group", Children: []*stack.Node{leaf}},
+			apps,
+		},
+	}
+
+	objs, err := gen.GenerateFromNode(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][]string{
+		"root-bundle": nil,
+		"leaf-bundle": {"root-bundle"},
+		"apps-bundle": {"root-bundle"},
+	}
+	for _, obj := range objs {
+		k := obj.(*kustv1.Kustomization)
+		var got []string
+		for _, dep := range k.Spec.DependsOn {
+			got = append(got, dep.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(want[k.Name], ",") {
+			t.Errorf("%s: dependsOn = %v, want %v", k.Name, got, want[k.Name])
+		}
+	}
+
+	// A subtree generated on its own still finds its parent through the
+	// runtime parent reference.
+	apps.SetParent(root)
+	objs, err = gen.GenerateFromNode(apps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps := objs[0].(*kustv1.Kustomization).Spec.DependsOn; len(deps) != 1 || deps[0].Name != "root-bundle" {
+		t.Errorf("apps-bundle dependsOn = %v, want [root-bundle]", deps)
+	}
+}
+
+func TestGenerateFromNode_NoParentDependencyByDefault(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	root := &stack.Node{
+		Name:     "root",
+		Bundle:   &stack.Bundle{Name: "root-bundle"},
+		Children: []*stack.Node{{Name: "apps", Bundle: &stack.Bundle{Name: "apps-bundle"}}},
+	}
+	objs, err := gen.GenerateFromNode(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, obj := range objs {
+		if deps := obj.(*kustv1.Kustomization).Spec.DependsOn; len(deps) != 0 {
+			t.Errorf("%s: unexpected dependsOn %v", obj.GetName(), deps)
+		}
+	}
+}
+
+func TestGenerateFromCluster_Decryption(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+
+	umbrella := &stack.Bundle{Name: "platform", Children: []*stack.Bundle{{Name: "platform-crds"}}}
+	secure := &stack.Node{
+		Name:       "secure",
+		Bundle:     &stack.Bundle{Name: "secure-bundle"},
+		Decryption: &stack.Decryption{Provider: "sops", SecretRef: "sops-age-secure"},
+		Children:   []*stack.Node{{Name: "nested", Bundle: &stack.Bundle{Name: "nested-bundle"}}},
+	}
+	c := &stack.Cluster{
+		Name:       "prod",
+		Decryption: &stack.Decryption{SecretRef: "sops-age"},
+		Node: &stack.Node{
+			Name:     "root",
+			Bundle:   umbrella,
+			Children: []*stack.Node{secure},
+		},
+	}
+
+	objs, err := gen.GenerateFromCluster(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"platform":      "sops-age",
+		"platform-crds": "sops-age",
+		"secure-bundle": "sops-age-secure",
+		"nested-bundle": "sops-age-secure",
+	}
+	if len(objs) != len(want) {
+		t.Fatalf("expected %d objects, got %d", len(want), len(objs))
+	}
+	for _, obj := range objs {
+		k := obj.(*kustv1.Kustomization)
+		dec := k.Spec.Decryption
+		if dec == nil || dec.Provider != "sops" || dec.SecretRef == nil || dec.SecretRef.Name != want[k.Name] {
+			t.Errorf("%s: decryption = %+v, want sops with secret %q", k.Name, dec, want[k.Name])
+		}
+	}
+}
+
+func TestGenerateFromNode_NoDecryptionByDefault(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	objs, err := gen.GenerateFromNode(&stack.Node{Name: "root", Bundle: &stack.Bundle{Name: "root-bundle"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dec := objs[0].(*kustv1.Kustomization).Spec.Decryption; dec != nil {
+		t.Errorf("unexpected decryption %+v", dec)
+	}
+}
+
+func TestGenerateFromCluster_Tenancy(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+
+	tenant := &stack.Node{
+		Name:     "team-a",
+		Bundle:   &stack.Bundle{Name: "team-a", Children: []*stack.Bundle{{Name: "team-a-apps"}}},
+		Tenancy:  &stack.Tenancy{ServiceAccountName: "team-a", TargetNamespace: "team-a"},
+		Children: []*stack.Node{{Name: "db", Bundle: &stack.Bundle{Name: "team-a-db"}, Tenancy: &stack.Tenancy{TargetNamespace: "team-a-db"}}},
+	}
+	c := &stack.Cluster{
+		Name: "prod",
+		Node: &stack.Node{
+			Name:     "root",
+			Bundle:   &stack.Bundle{Name: "platform"},
+			Children: []*stack.Node{tenant},
+		},
+	}
+
+	objs, err := gen.GenerateFromCluster(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][2]string{
+		"platform":    {"", ""},
+		"team-a":      {"team-a", "team-a"},
+		"team-a-apps": {"team-a", "team-a"},
+		"team-a-db":   {"", "team-a-db"},
+	}
+	if len(objs) != len(want) {
+		t.Fatalf("expected %d objects, got %d", len(want), len(objs))
+	}
+	for _, obj := range objs {
+		k := obj.(*kustv1.Kustomization)
+		got := [2]string{k.Spec.ServiceAccountName, k.Spec.TargetNamespace}
+		if got != want[k.Name] {
+			t.Errorf("%s: serviceAccountName/targetNamespace = %v, want %v", k.Name, got, want[k.Name])
+		}
+	}
+}
+
 func TestGenerateFromBundle_Nil(t *testing.T) {
 	gen := fluxstack.NewResourceGenerator()
 	objs, err := gen.GenerateFromBundle(nil)
@@ -395,6 +561,104 @@ func TestCreateSource_DefaultNamespace(t *testing.T) {
 	}
 }
 
+func TestGenerateFromCluster_IncludeClusterSource(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	gen.IncludeClusterSource = true
+
+	c := &stack.Cluster{
+		Name: "prod",
+		GitOps: &stack.GitOpsConfig{Type: "flux", Bootstrap: &stack.BootstrapConfig{
+			Enabled:    true,
+			SourceKind: "GitRepository",
+			SourceURL:  "https://github.com/example/fleet",
+			SourceRef:  "main",
+		}},
+		Node: &stack.Node{Name: "root", Bundle: &stack.Bundle{
+			Name:      "infra",
+			SourceRef: &stack.SourceRef{Kind: "GitRepository", Name: "fleet", Namespace: "gitops"},
+		}},
+	}
+
+	objs, err := gen.GenerateFromCluster(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("expected source and Kustomization, got %d objects", len(objs))
+	}
+	git, ok := objs[0].(*sourcev1.GitRepository)
+	if !ok {
+		t.Fatalf("expected GitRepository first, got %T", objs[0])
+	}
+	if git.Name != "fleet" || git.Namespace != "gitops" {
+		t.Errorf("source = %s/%s, want gitops/fleet (matching the Kustomization sourceRef)", git.Namespace, git.Name)
+	}
+	if git.Spec.URL != "https://github.com/example/fleet" {
+		t.Errorf("URL = %q", git.Spec.URL)
+	}
+	if git.Spec.Reference == nil || git.Spec.Reference.Branch != "main" {
+		t.Errorf("Reference = %+v, want branch main", git.Spec.Reference)
+	}
+	if git.Spec.Interval.Duration != gen.DefaultInterval {
+		t.Errorf("Interval = %v, want %v", git.Spec.Interval.Duration, gen.DefaultInterval)
+	}
+}
+
+func TestGenerateClusterSource_OCIDefaults(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	c := &stack.Cluster{
+		Name: "prod",
+		GitOps: &stack.GitOpsConfig{Type: "flux", Bootstrap: &stack.BootstrapConfig{
+			SourceURL: "oci://ghcr.io/example/fleet",
+			SourceRef: "v1.2.3",
+		}},
+		Node: &stack.Node{Name: "root"},
+	}
+
+	obj, err := gen.GenerateClusterSource(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	oci, ok := obj.(*sourcev1.OCIRepository)
+	if !ok {
+		t.Fatalf("expected OCIRepository, got %T", obj)
+	}
+	if oci.Name != "flux-system" || oci.Namespace != "flux-system" {
+		t.Errorf("source = %s/%s, want flux-system/flux-system", oci.Namespace, oci.Name)
+	}
+	if oci.Spec.Reference == nil || oci.Spec.Reference.Tag != "v1.2.3" {
+		t.Errorf("Reference = %+v, want tag v1.2.3", oci.Spec.Reference)
+	}
+}
+
+func TestGenerateClusterSource_NoSourceURL(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	for _, c := range []*stack.Cluster{
+		nil,
+		{Name: "prod"},
+		{Name: "prod", GitOps: &stack.GitOpsConfig{Bootstrap: &stack.BootstrapConfig{Enabled: true}}},
+	} {
+		obj, err := gen.GenerateClusterSource(c)
+		if err != nil || obj != nil {
+			t.Errorf("expected (nil, nil), got (%v, %v)", obj, err)
+		}
+	}
+}
+
+func TestGenerateClusterSource_InvalidKind(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+	c := &stack.Cluster{
+		Name: "prod",
+		GitOps: &stack.GitOpsConfig{Bootstrap: &stack.BootstrapConfig{
+			SourceKind: "Bucket",
+			SourceURL:  "s3://bucket",
+		}},
+	}
+	if _, err := gen.GenerateClusterSource(c); err == nil {
+		t.Fatal("expected error for unsupported source kind")
+	}
+}
+
 func TestGeneratePath_Explicit(t *testing.T) {
 	wf := fluxstack.EngineWithMode(layout.KustomizationExplicit)
 
@@ -739,6 +1003,64 @@ func TestGenerateFromBundle_PostBuild(t *testing.T) {
 	})
 }
 
+func TestGenerateFromCluster_ClusterPostBuild(t *testing.T) {
+	gen := fluxstack.NewResourceGenerator()
+
+	bundleVars := map[string]string{"REGION": "us-east-1"}
+	c := &stack.Cluster{
+		Name: "prod",
+		PostBuild: &stack.PostBuild{
+			Substitute: map[string]string{"CLUSTER": "prod", "REGION": "eu-west-1"},
+			SubstituteFrom: []stack.SubstituteRef{
+				{Kind: "ConfigMap", Name: "cluster-vars"},
+				{Kind: "Secret", Name: "cluster-secrets", Optional: true},
+			},
+		},
+		Node: &stack.Node{
+			Name:   "root",
+			Bundle: &stack.Bundle{Name: "plain"},
+			Children: []*stack.Node{{Name: "apps", Bundle: &stack.Bundle{
+				Name: "custom",
+				PostBuild: &stack.PostBuild{
+					Substitute:     bundleVars,
+					SubstituteFrom: []stack.SubstituteRef{{Kind: "ConfigMap", Name: "app-vars"}},
+				},
+			}}},
+		},
+	}
+
+	objs, err := gen.GenerateFromCluster(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objs))
+	}
+
+	plain := objs[0].(*kustv1.Kustomization).Spec.PostBuild
+	if plain == nil || plain.Substitute["CLUSTER"] != "prod" || plain.Substitute["REGION"] != "eu-west-1" {
+		t.Errorf("plain: postBuild = %+v, want cluster variables", plain)
+	}
+	if plain != nil && len(plain.SubstituteFrom) != 2 {
+		t.Errorf("plain: expected 2 substituteFrom entries, got %v", plain.SubstituteFrom)
+	}
+
+	custom := objs[1].(*kustv1.Kustomization).Spec.PostBuild
+	if custom.Substitute["REGION"] != "us-east-1" || custom.Substitute["CLUSTER"] != "prod" {
+		t.Errorf("custom: substitute = %v, want bundle REGION and cluster CLUSTER", custom.Substitute)
+	}
+	var names []string
+	for _, ref := range custom.SubstituteFrom {
+		names = append(names, ref.Name)
+	}
+	if strings.Join(names, ",") != "cluster-vars,cluster-secrets,app-vars" {
+		t.Errorf("custom: substituteFrom = %v, want cluster references before the bundle's", names)
+	}
+	if len(bundleVars) != 1 {
+		t.Errorf("bundle Substitute map was modified: %v", bundleVars)
+	}
+}
+
 func TestResourceGenerator_GetName(t *testing.T) {
 	rg := fluxstack.NewResourceGenerator()
 	if rg.GetName() == "" {
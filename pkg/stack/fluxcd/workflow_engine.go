@@ -21,6 +21,14 @@ type WorkflowEngine struct {
 	LayoutInteg *LayoutIntegrator
 	// BootstrapGen handles bootstrap resource generation
 	BootstrapGen *BootstrapGenerator
+	// IncludeFluxInstance adds the cluster's FluxInstance to the layouts
+	// created by CreateLayoutWithResources.
+	IncludeFluxInstance bool
+	// LayoutConfig is the configuration the layouts are written with. The
+	// sync path of the FluxInstance added with IncludeFluxInstance follows
+	// its ManifestsDir; the zero value writes below "clusters" like
+	// layout.DefaultLayoutConfig.
+	LayoutConfig layout.Config
 }
 
 // NewWorkflowEngine creates a FluxCD workflow engine with default components.
@@ -86,7 +94,29 @@ func (we *WorkflowEngine) CreateLayoutWithResources(c *stack.Cluster, rules stac
 	if !ok {
 		return nil, errors.New("rules must be of type layout.LayoutRules")
 	}
-	return we.LayoutInteg.CreateLayoutWithResources(c, layoutRules)
+	ml, err := we.LayoutInteg.CreateLayoutWithResources(c, layoutRules)
+	if err != nil {
+		return nil, err
+	}
+	if we.IncludeFluxInstance {
+		if err := we.BootstrapGen.AddFluxInstanceToLayout(ml, c, we.LayoutConfig); err != nil {
+			return nil, err
+		}
+	}
+	return ml, nil
+}
+
+// NotificationStack creates the notification Provider, Alert and optional
+// Receiver for the Flux resources in ml, the layout returned by
+// CreateLayoutWithResources. See ResourceGenerator.GenerateNotificationStack.
+func (we *WorkflowEngine) NotificationStack(ml *layout.ManifestLayout, cfg NotificationConfig) ([]client.Object, error) {
+	return we.ResourceGen.GenerateNotificationStack(ml, cfg)
+}
+
+// ImageAutomation creates the ImageRepository, ImagePolicy and
+// ImageUpdateAutomation for app. See ResourceGenerator.GenerateImageAutomation.
+func (we *WorkflowEngine) ImageAutomation(app *stack.Application, cfg ImageAutomationConfig) ([]client.Object, error) {
+	return we.ResourceGen.GenerateImageAutomation(app, cfg)
 }
 
 // BootstrapGenerator interface implementation
@@ -120,6 +150,24 @@ func (we *WorkflowEngine) SetKustomizationMode(mode layout.KustomizationMode) {
 	we.ResourceGen.Mode = mode
 }
 
+// SetIncludeClusterSource configures whether the cluster's bootstrap source
+// object is emitted with the generated Kustomizations.
+func (we *WorkflowEngine) SetIncludeClusterSource(include bool) {
+	we.ResourceGen.IncludeClusterSource = include
+}
+
+// SetIncludeFluxInstance configures whether CreateLayoutWithResources adds
+// the cluster's FluxInstance to the layout.
+func (we *WorkflowEngine) SetIncludeFluxInstance(include bool) {
+	we.IncludeFluxInstance = include
+}
+
+// SetDependOnParentNode configures whether node Kustomizations depend on the
+// Kustomization of their parent node.
+func (we *WorkflowEngine) SetDependOnParentNode(depend bool) {
+	we.ResourceGen.DependOnParentNode = depend
+}
+
 // GetResourceGenerator returns the underlying resource generator for advanced configuration.
 func (we *WorkflowEngine) GetResourceGenerator() *ResourceGenerator {
 	return we.ResourceGen
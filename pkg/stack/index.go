@@ -0,0 +1,132 @@
+package stack
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+	"github.com/go-kure/kure/pkg/gvk"
+)
+
+// ObjectRef identifies a generated Kubernetes object by its API version,
+// kind, namespace and name.
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// NewObjectRef returns the ObjectRef for obj.
+func NewObjectRef(obj client.Object) ObjectRef {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return ObjectRef{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// ApplicationIndex lists the objects produced by a single Application.
+type ApplicationIndex struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Generator is the GVK of the ApplicationConfig that produced the
+	// objects, recorded for configs implementing gvk.VersionedType.
+	Generator string `json:"generator,omitempty"`
+	// ConfigHash is Application.ConfigHash at generation time.
+	ConfigHash string      `json:"configHash,omitempty"`
+	Objects    []ObjectRef `json:"objects"`
+}
+
+// ManifestIndex maps each Application to the object identities it
+// produced. It supports ownership queries, prune calculations and partial
+// regeneration bookkeeping without re-running generation.
+type ManifestIndex struct {
+	// Bundle is the name of the bundle the index was built from. It is empty
+	// when the index spans applications from several bundles.
+	Bundle       string             `json:"bundle,omitempty"`
+	Applications []ApplicationIndex `json:"applications"`
+}
+
+// Add records objs as produced by app. Nil objects are ignored. Calling Add
+// again for the same application appends to its existing entry.
+func (m *ManifestIndex) Add(app *Application, objs ...client.Object) {
+	if m == nil || app == nil {
+		return
+	}
+	var entry *ApplicationIndex
+	for i := range m.Applications {
+		if m.Applications[i].Name == app.Name && m.Applications[i].Namespace == app.Namespace {
+			entry = &m.Applications[i]
+			break
+		}
+	}
+	if entry == nil {
+		m.Applications = append(m.Applications, ApplicationIndex{
+			Name:       app.Name,
+			Namespace:  app.Namespace,
+			Generator:  generatorName(app),
+			ConfigHash: app.ConfigHash(),
+			Objects:    []ObjectRef{},
+		})
+		entry = &m.Applications[len(m.Applications)-1]
+	}
+	for _, obj := range objs {
+		if obj == nil {
+			continue
+		}
+		entry.Objects = append(entry.Objects, NewObjectRef(obj))
+	}
+}
+
+// Owner returns the name of the application that produced ref.
+func (m *ManifestIndex) Owner(ref ObjectRef) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	for _, app := range m.Applications {
+		for _, o := range app.Objects {
+			if o == ref {
+				return app.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Objects returns the objects recorded for the application with the given
+// name and namespace, matching the key used by Add.
+func (m *ManifestIndex) Objects(appName, namespace string) ([]ObjectRef, error) {
+	if m != nil {
+		for _, app := range m.Applications {
+			if app.Name == appName && app.Namespace == namespace {
+				return app.Objects, nil
+			}
+		}
+	}
+	return nil, errors.ResourceNotFoundError("Application", appName, namespace, m.applicationNames())
+}
+
+// applicationNames lists the application names recorded in the index.
+func (m *ManifestIndex) applicationNames() []string {
+	if m == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.Applications))
+	for _, app := range m.Applications {
+		names = append(names, app.Name)
+	}
+	return names
+}
+
+// generatorName returns the GVK of app.Config, such as
+// "generators.gokure.dev/v1alpha1, Kind=AppWorkload", or "" when the config
+// does not implement gvk.VersionedType.
+func generatorName(app *Application) string {
+	versioned, ok := app.Config.(gvk.VersionedType)
+	if !ok {
+		return ""
+	}
+	return gvk.ParseAPIVersion(versioned.GetAPIVersion(), versioned.GetKind()).String()
+}
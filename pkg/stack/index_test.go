@@ -0,0 +1,128 @@
+package stack
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestBundleGenerateWithIndex(t *testing.T) {
+	pod := client.Object(&corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"},
+	})
+	cm := client.Object(&corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "ns2"},
+	})
+	app1 := NewApplication("app1", "ns1", &fakeConfig{objs: []*client.Object{&pod}})
+	app2 := NewApplication("app2", "ns2", &fakeConfig{objs: []*client.Object{&cm}})
+	empty := NewApplication("empty", "ns3", &fakeConfig{})
+	b := &Bundle{Name: "web", Applications: []*Application{app1, app2, empty}}
+
+	resources, index, err := b.GenerateWithIndex(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(resources))
+	}
+	if index.Bundle != "web" {
+		t.Errorf("expected bundle name web, got %q", index.Bundle)
+	}
+	if len(index.Applications) != 3 {
+		t.Fatalf("expected 3 application entries, got %d", len(index.Applications))
+	}
+
+	owner, ok := index.Owner(ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns2", Name: "cm1"})
+	if !ok || owner != "app2" {
+		t.Errorf("expected ConfigMap to be owned by app2, got %q (found=%v)", owner, ok)
+	}
+	if _, ok := index.Owner(ObjectRef{APIVersion: "v1", Kind: "Pod", Name: "missing"}); ok {
+		t.Error("expected no owner for unknown object")
+	}
+
+	objs, err := index.Objects("empty", "ns3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objs) != 0 {
+		t.Errorf("expected no objects for empty app, got %v", objs)
+	}
+	if _, err := index.Objects("nope", "ns3"); err == nil {
+		t.Error("expected error for unknown application")
+	}
+	if _, err := index.Objects("empty", "ns1"); err == nil {
+		t.Error("expected error for application in another namespace")
+	}
+}
+
+func TestManifestIndexObjectsByNamespace(t *testing.T) {
+	pod := func(ns string) client.Object {
+		return &corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ns},
+		}
+	}
+	index := &ManifestIndex{}
+	index.Add(NewApplication("web", "staging", nil), pod("staging"))
+	index.Add(NewApplication("web", "prod", nil), pod("prod"))
+
+	if len(index.Applications) != 2 {
+		t.Fatalf("expected an entry per namespace, got %d entries", len(index.Applications))
+	}
+	for _, ns := range []string{"staging", "prod"} {
+		objs, err := index.Objects("web", ns)
+		if err != nil {
+			t.Fatalf("unexpected error for namespace %s: %v", ns, err)
+		}
+		if len(objs) != 1 || objs[0].Namespace != ns {
+			t.Errorf("expected the %s pod, got %v", ns, objs)
+		}
+	}
+}
+
+func TestManifestIndexAdd(t *testing.T) {
+	var nilIndex *ManifestIndex
+	nilIndex.Add(NewApplication("app", "ns", nil))
+
+	index := &ManifestIndex{}
+	app := NewApplication("app", "ns", nil)
+	obj := client.Object(&corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "a"},
+	})
+	index.Add(app, obj)
+	index.Add(app, nil, obj)
+
+	if len(index.Applications) != 1 {
+		t.Fatalf("expected repeated Add to reuse the entry, got %d entries", len(index.Applications))
+	}
+	if got := len(index.Applications[0].Objects); got != 2 {
+		t.Errorf("expected 2 objects (nil skipped), got %d", got)
+	}
+	if index.Applications[0].Generator != "" || index.Applications[0].ConfigHash != "" {
+		t.Errorf("expected no generator or config hash without a config, got %+v", index.Applications[0])
+	}
+
+	withConfig := NewApplication("cfg", "ns", &versionedConfig{})
+	index.Add(withConfig, obj)
+	entry := index.Applications[1]
+	if want := "generators.gokure.dev/v1alpha1, Kind=Fake"; entry.Generator != want {
+		t.Errorf("expected generator %q, got %q", want, entry.Generator)
+	}
+	if entry.ConfigHash != withConfig.ConfigHash() || entry.ConfigHash == "" {
+		t.Errorf("expected config hash %q, got %q", withConfig.ConfigHash(), entry.ConfigHash)
+	}
+}
+
+// versionedConfig is a fakeConfig that reports a generator GVK.
+type versionedConfig struct {
+	fakeConfig
+}
+
+func (*versionedConfig) GetAPIVersion() string { return "generators.gokure.dev/v1alpha1" }
+
+func (*versionedConfig) GetKind() string { return "Fake" }
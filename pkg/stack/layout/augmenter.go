@@ -1,7 +1,6 @@
 package layout
 
 import (
-	"archive/tar"
 	"fmt"
 	"os"
 	"path"
@@ -56,10 +55,10 @@ func writeExtraFilesToDisk(dir string, files []ExtraFile) error {
 	return nil
 }
 
-// writeExtraFilesToTar writes each ExtraFile as a tar entry under fullPath.
-func writeExtraFilesToTar(tw *tar.Writer, fullPath string, files []ExtraFile) error {
+// writeExtraFilesToSink writes each ExtraFile to s under fullPath.
+func writeExtraFilesToSink(s fileSink, fullPath string, files []ExtraFile) error {
 	for _, ef := range files {
-		if err := writeTarFile(tw, path.Join(fullPath, ef.Name), ef.Content); err != nil {
+		if err := s.writeFile(path.Join(fullPath, ef.Name), ef.Content); err != nil {
 			return err
 		}
 	}
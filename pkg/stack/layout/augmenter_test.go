@@ -32,7 +32,7 @@ func TestProcessFlatBundleApps_NilObjectPointer(t *testing.T) {
 	app := stack.NewApplication("plain", "ns", &flattenFakeConfig{objs: []*client.Object{nilObjPtr}})
 	parent := &ManifestLayout{Name: "parent", Namespace: "ns"}
 
-	err := processFlatBundleApps([]*stack.Application{app}, parent, []string{"ns"}, FluxSeparate, "")
+	err := processFlatBundleApps(&stack.Bundle{Applications: []*stack.Application{app}}, parent, []string{"ns"}, FluxSeparate, "", nil)
 	if err != nil {
 		t.Fatalf("unexpected error with nil object pointer: %v", err)
 	}
@@ -54,7 +54,7 @@ func TestProcessFlatBundleApps_NilApp(t *testing.T) {
 	plainApp := stack.NewApplication("plain", "ns", &flattenFakeConfig{objs: []*client.Object{&o}})
 	parent := &ManifestLayout{Name: "parent", Namespace: "ns"}
 
-	err := processFlatBundleApps([]*stack.Application{nil, plainApp}, parent, []string{"ns"}, FluxSeparate, "")
+	err := processFlatBundleApps(&stack.Bundle{Applications: []*stack.Application{nil, plainApp}}, parent, []string{"ns"}, FluxSeparate, "", nil)
 	if err != nil {
 		t.Fatalf("unexpected error with nil app entry: %v", err)
 	}
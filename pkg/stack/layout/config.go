@@ -3,11 +3,61 @@ package layout
 import (
 	"fmt"
 	"strings"
+
+	kio "github.com/go-kure/kure/pkg/io"
 )
 
 // ManifestFileNameFunc returns a file name for the given namespace, kind and resource name.
 type ManifestFileNameFunc func(namespace, kind, name string, mode FileExportMode) string
 
+// FileName implements FileNamer.
+func (f ManifestFileNameFunc) FileName(namespace, kind, name string, mode FileExportMode) string {
+	return f(namespace, kind, name, mode)
+}
+
+// FileNamer chooses the file name of a resource manifest. kind is passed in
+// lower case; namespace is "cluster" for cluster-scoped resources. With
+// FilePerKind every resource that maps to the same name shares a file.
+type FileNamer interface {
+	FileName(namespace, kind, name string, mode FileExportMode) string
+}
+
+// NameFileNamer names files {name}.yaml, or {kind}.yaml with FilePerKind.
+// Resources of different kinds with the same name share a file.
+var NameFileNamer FileNamer = ManifestFileNameFunc(func(_, kind, name string, mode FileExportMode) string {
+	if mode == FilePerKind {
+		return fmt.Sprintf("%s.yaml", kind)
+	}
+	return fmt.Sprintf("%s.yaml", name)
+})
+
+// PluralKindFileNamer names files after the lower-case plural of the kind,
+// e.g. deployments.yaml with FilePerKind and deployments-web.yaml otherwise.
+var PluralKindFileNamer FileNamer = ManifestFileNameFunc(func(_, kind, name string, mode FileExportMode) string {
+	plural := pluralizeKind(kind)
+	if mode == FilePerKind {
+		return fmt.Sprintf("%s.yaml", plural)
+	}
+	return fmt.Sprintf("%s-%s.yaml", plural, name)
+})
+
+// pluralizeKind returns the lower-case plural of a Kubernetes kind using the
+// English rules the API server applies to built-in resources.
+func pluralizeKind(kind string) string {
+	kind = strings.ToLower(kind)
+	switch {
+	case kind == "" || kind == "endpoints":
+		return kind
+	case strings.HasSuffix(kind, "s"), strings.HasSuffix(kind, "x"),
+		strings.HasSuffix(kind, "ch"), strings.HasSuffix(kind, "sh"):
+		return kind + "es"
+	case strings.HasSuffix(kind, "y") && len(kind) > 1 && !strings.ContainsRune("aeiou", rune(kind[len(kind)-2])):
+		return kind[:len(kind)-1] + "ies"
+	default:
+		return kind + "s"
+	}
+}
+
 // KustomizationFileNameFunc returns the file name for a Flux Kustomization manifest.
 type KustomizationFileNameFunc func(name string) string
 
@@ -38,8 +88,29 @@ type Config struct {
 	// ManifestFileName formats the file name for a resource manifest.
 	// Takes precedence over FileNaming when set.
 	ManifestFileName ManifestFileNameFunc
+	// FileNamer chooses the file name for a resource manifest. Takes
+	// precedence over ManifestFileName and FileNaming when set, so it can be
+	// combined with DefaultLayoutConfig.
+	FileNamer FileNamer
 	// KustomizationFileName formats the file name for a Flux Kustomization.
 	KustomizationFileName KustomizationFileNameFunc
+	// EncodeOptions controls how WriteManifest serializes resources
+	// (field order, indentation, line wrapping and quoting). The zero value
+	// matches kio.EncodeObjectsToYAML.
+	EncodeOptions kio.EncodeOptions
+	// PostProcessors run once on the complete layout before WriteManifest
+	// writes anything. See AddPostProcessor.
+	PostProcessors []PostProcessor
+	// Provenance prefixes every resource file and kustomization.yaml written
+	// by WriteManifest with a comment block marking it as generated. Resource
+	// files also name the applications that produced them together with
+	// their generator GVK and config hash, taken from the layout's
+	// ManifestIndex. YAML ExtraFiles get the same header, naming their owner;
+	// other ExtraFiles are written unchanged.
+	Provenance bool
+
+	// provenance is collected from the layout when Provenance is set.
+	provenance provenance
 }
 
 // DefaultLayoutConfig returns a configuration that matches the directory layout
@@ -75,10 +146,13 @@ func DefaultKustomizationFileName(name string) string {
 }
 
 // ResolveManifestFileName returns the effective ManifestFileNameFunc for this
-// Config. If ManifestFileName is set it is returned directly. Otherwise,
-// FileNaming is used to select the function. If neither is set,
+// Config. FileNamer is used when set, then ManifestFileName. Otherwise,
+// FileNaming is used to select the function. If none is set,
 // DefaultManifestFileName is returned.
 func (c Config) ResolveManifestFileName() ManifestFileNameFunc {
+	if c.FileNamer != nil {
+		return c.FileNamer.FileName
+	}
 	if c.ManifestFileName != nil {
 		return c.ManifestFileName
 	}
@@ -39,6 +39,38 @@ func TestResolveManifestFileName_ExplicitFuncOverridesFileNaming(t *testing.T) {
 	}
 }
 
+func TestResolveManifestFileName_FileNamerOverridesFunc(t *testing.T) {
+	cfg := layout.DefaultLayoutConfig()
+	cfg.FileNamer = layout.NameFileNamer
+	fn := cfg.ResolveManifestFileName()
+	if got := fn("ns", "deployment", "myapp", layout.FilePerResource); got != "myapp.yaml" {
+		t.Errorf("FileNamer should override ManifestFileName, got %s", got)
+	}
+	if got := fn("ns", "deployment", "myapp", layout.FilePerKind); got != "deployment.yaml" {
+		t.Errorf("expected deployment.yaml for FilePerKind, got %s", got)
+	}
+}
+
+func TestPluralKindFileNamer(t *testing.T) {
+	tests := []struct {
+		kind string
+		mode layout.FileExportMode
+		want string
+	}{
+		{"deployment", layout.FilePerKind, "deployments.yaml"},
+		{"ingress", layout.FilePerKind, "ingresses.yaml"},
+		{"networkpolicy", layout.FilePerKind, "networkpolicies.yaml"},
+		{"gateway", layout.FilePerKind, "gateways.yaml"},
+		{"endpoints", layout.FilePerKind, "endpoints.yaml"},
+		{"service", layout.FilePerResource, "services-web.yaml"},
+	}
+	for _, tt := range tests {
+		if got := layout.PluralKindFileNamer.FileName("ns", tt.kind, "web", tt.mode); got != tt.want {
+			t.Errorf("FileName(%q, %q) = %q, want %q", tt.kind, tt.mode, got, tt.want)
+		}
+	}
+}
+
 func TestResolveKustomizationMode_Default(t *testing.T) {
 	cfg := layout.Config{}
 	mode := cfg.ResolveKustomizationMode(layout.FluxSeparate)
@@ -0,0 +1,268 @@
+package layout
+
+import (
+	"fmt"
+	"path/filepath"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// limitDepth enforces LayoutRules.MaxDepth on the tree below root. Every
+// directory layout nested deeper than maxDepth levels below root is moved
+// up to the deepest allowed level and named after the layouts it was
+// nested in, joined with "-": with maxDepth 1, root/tier/app becomes
+// root/tier-app. The moved layout keeps its resources and extra files and
+// is referenced from the kustomization.yaml of its new parent. Layouts left
+// without any content are dropped.
+//
+// Umbrella children and layouts written as a single file are not directory
+// levels of their own and stay in place. Each move is recorded on root's
+// flattenInfo, like flattenSingleTier does, so the Flux integrator finds the
+// moved layouts by node path and rewrites the Kustomization paths. A
+// flattened name that clashes with an existing sibling is an error.
+//
+// Flux Kustomizations placed next to a moved layout by integrated placement
+// move to its new parent and take its flattened name; dependsOn entries
+// naming them are updated, and those of dropped layouts are removed.
+func limitDepth(root *ManifestLayout, maxDepth int) error {
+	if root == nil || maxDepth <= 0 {
+		return nil
+	}
+	d := &depthLimiter{root: root, maxDepth: maxDepth, renames: map[string]string{}}
+	if err := d.limitAt(root, root.Name, 0); err != nil {
+		return err
+	}
+	renameDependsOn(root, d.renames)
+	return nil
+}
+
+// LimitDepth applies LayoutRules.MaxDepth to a layout that already holds
+// integrated Flux Kustomizations. WalkCluster defers MaxDepth for the
+// integrated placements so that the LayoutIntegrator can place the
+// Kustomizations first; it calls LimitDepth before rewriting their paths
+// with ApplyFlattenPathRewrites.
+func LimitDepth(root *ManifestLayout, maxDepth int) error {
+	return limitDepth(root, maxDepth)
+}
+
+// depthLimiter carries the state of one limitDepth pass. renames maps the
+// names of moved Kustomizations to their flattened names; an empty value
+// marks a Kustomization that was dropped with its layout, and ambiguous
+// marks names that were used by more than one moved Kustomization.
+type depthLimiter struct {
+	root      *ManifestLayout
+	maxDepth  int
+	renames   map[string]string
+	ambiguous map[string]bool
+}
+
+// limitAt visits the children of l, found depth levels below root under
+// the node path nodePath, and flattens the descendants of the children at
+// maxDepth into l.
+func (d *depthLimiter) limitAt(l *ManifestLayout, nodePath string, depth int) error {
+	if depth >= d.maxDepth {
+		return nil
+	}
+	// Flattening adds siblings to l.Children, so iterate over a snapshot.
+	children := append([]*ManifestLayout(nil), l.Children...)
+	for _, child := range children {
+		if depth+1 < d.maxDepth {
+			if err := d.limitAt(child, joinNodePath(nodePath, child.Name), depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.hoistDescendants(l, child, child.Name, nodePath, child.Name); err != nil {
+			return err
+		}
+		if child.isEmpty() {
+			l.Children = removeLayout(l.Children, child)
+			d.moveKustomization(l, nil, child.Name, "")
+		}
+	}
+	return nil
+}
+
+// hoistDescendants moves the directory descendants of l into parent. prefix
+// is the flattened name of l and relPath its node path relative to
+// parentPath, the node path of parent.
+func (d *depthLimiter) hoistDescendants(parent, l *ManifestLayout, prefix, parentPath, relPath string) error {
+	parentDir := filepath.Join(parent.Namespace, parent.Name)
+	var kept []*ManifestLayout
+	for _, child := range l.Children {
+		if child.UmbrellaChild || child.writtenAsFile() {
+			kept = append(kept, child)
+			continue
+		}
+		flatName := prefix + "-" + child.Name
+		for _, sibling := range parent.Children {
+			if sibling.Name == flatName {
+				return errors.ResourceValidationError("ManifestLayout", parent.FullRepoPath(), "maxDepth",
+					fmt.Sprintf("flattened layout %q conflicts with an existing layout of the same name", flatName), nil)
+			}
+		}
+
+		childRel := joinNodePath(relPath, child.Name)
+		oldName := child.Name
+		oldPath := child.FullRepoPath()
+		oldDir := filepath.Join(child.Namespace, child.Name)
+		child.Namespace = parentDir
+		child.Name = flatName
+		parent.Children = append(parent.Children, child)
+		d.moveKustomization(l, parent, oldName, flatName)
+
+		if err := d.hoistDescendants(parent, child, flatName, parentPath, childRel); err != nil {
+			return err
+		}
+		for _, gc := range child.Children {
+			rebaseLayout(gc, oldDir, filepath.Join(child.Namespace, child.Name))
+		}
+		if child.isEmpty() {
+			parent.Children = removeLayout(parent.Children, child)
+			d.moveKustomization(parent, nil, flatName, "")
+			continue
+		}
+		recordFlatten(d.root, joinNodePath(parentPath, childRel), child, oldPath, child.FullRepoPath())
+	}
+	l.Children = kept
+	return nil
+}
+
+// moveKustomization moves the Flux Kustomization named name from from.Resources
+// to to.Resources and renames it to newName. A nil to drops it. The change
+// is recorded for renameDependsOn.
+func (d *depthLimiter) moveKustomization(from, to *ManifestLayout, name, newName string) {
+	for i, obj := range from.Resources {
+		k, ok := obj.(*kustomizev1.Kustomization)
+		if !ok || k.Name != name {
+			continue
+		}
+		from.Resources = append(from.Resources[:i:i], from.Resources[i+1:]...)
+		if to != nil {
+			k.Name = newName
+			to.Resources = append(to.Resources, k)
+		}
+		d.recordRename(name, newName)
+		return
+	}
+}
+
+// recordRename records that the Kustomization name became newName. A name
+// used by Kustomizations that moved to different places is ambiguous and
+// left alone by renameDependsOn.
+func (d *depthLimiter) recordRename(name, newName string) {
+	if d.ambiguous[name] {
+		return
+	}
+	if prev, ok := d.renames[name]; ok && prev != newName {
+		if d.ambiguous == nil {
+			d.ambiguous = map[string]bool{}
+		}
+		d.ambiguous[name] = true
+		delete(d.renames, name)
+		return
+	}
+	d.renames[name] = newName
+}
+
+// renameDependsOn rewrites the dependsOn entries of every Kustomization
+// below root that name a Kustomization moved by limitDepth, following
+// repeated moves, and removes those naming a dropped one. Entries whose
+// name still belongs to a Kustomization in the tree are kept.
+func renameDependsOn(root *ManifestLayout, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	var kustomizations []*kustomizev1.Kustomization
+	collectKustomizations(root, &kustomizations)
+	present := make(map[string]bool, len(kustomizations))
+	for _, k := range kustomizations {
+		present[k.Name] = true
+	}
+	resolve := func(name string) string {
+		for range len(renames) {
+			next, moved := renames[name]
+			if present[name] || !moved {
+				return name
+			}
+			if name = next; name == "" {
+				return ""
+			}
+		}
+		return name
+	}
+	for _, k := range kustomizations {
+		deps := k.Spec.DependsOn[:0]
+		for _, dep := range k.Spec.DependsOn {
+			if _, moved := renames[dep.Name]; moved && !present[dep.Name] {
+				if dep.Name = resolve(dep.Name); dep.Name == "" {
+					continue
+				}
+			}
+			deps = append(deps, dep)
+		}
+		k.Spec.DependsOn = deps
+	}
+}
+
+// collectKustomizations appends the Flux Kustomizations of ml and its
+// descendants to out.
+func collectKustomizations(ml *ManifestLayout, out *[]*kustomizev1.Kustomization) {
+	for _, obj := range ml.Resources {
+		if k, ok := obj.(*kustomizev1.Kustomization); ok {
+			*out = append(*out, k)
+		}
+	}
+	for _, child := range ml.Children {
+		collectKustomizations(child, out)
+	}
+}
+
+// isEmpty reports whether ml would produce no files.
+func (ml *ManifestLayout) isEmpty() bool {
+	return len(ml.Resources) == 0 && len(ml.Children) == 0 && len(ml.References) == 0 &&
+		len(ml.ExtraFiles) == 0 && len(ml.ConfigMapGenerators) == 0
+}
+
+// removeLayout returns layouts without target.
+func removeLayout(layouts []*ManifestLayout, target *ManifestLayout) []*ManifestLayout {
+	out := layouts[:0]
+	for _, l := range layouts {
+		if l != target {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// recordFlatten records on root that the layout for nodePath moved from
+// oldPath to target at newPath.
+func recordFlatten(root *ManifestLayout, nodePath string, target *ManifestLayout, oldPath, newPath string) {
+	if root.flattenInfo == nil {
+		root.flattenInfo = &flattenInfo{
+			nodeAliases:  map[string]*ManifestLayout{},
+			pathRewrites: map[string]string{},
+		}
+	}
+	if nodePath != "" {
+		root.flattenInfo.nodeAliases[nodePath] = target
+	}
+	if oldPath != newPath {
+		root.flattenInfo.pathRewrites[oldPath] = newPath
+	}
+}
+
+// joinNodePath appends name to the slash-separated node path p, the way the
+// Flux integrator builds layout paths from layout names.
+func joinNodePath(p, name string) string {
+	switch {
+	case p == "":
+		return name
+	case name == "":
+		return p
+	default:
+		return p + "/" + name
+	}
+}
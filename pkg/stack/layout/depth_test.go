@@ -0,0 +1,252 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// depthTestTree returns root/tier/app/worker with one ConfigMap per level
+// below root.
+func depthTestTree() *ManifestLayout {
+	worker := &ManifestLayout{
+		Name:      "worker",
+		Namespace: filepath.Join("root", "tier", "app"),
+		Resources: []client.Object{testObject("v1", "ConfigMap", "worker", "default")},
+	}
+	app := &ManifestLayout{
+		Name:      "app",
+		Namespace: filepath.Join("root", "tier"),
+		Resources: []client.Object{testObject("v1", "ConfigMap", "app", "default")},
+		Children:  []*ManifestLayout{worker},
+	}
+	tier := &ManifestLayout{
+		Name:      "tier",
+		Namespace: "root",
+		Resources: []client.Object{testObject("v1", "ConfigMap", "tier", "default")},
+		Children:  []*ManifestLayout{app},
+	}
+	return &ManifestLayout{Name: "root", Namespace: ".", Children: []*ManifestLayout{tier}}
+}
+
+func TestLimitDepth(t *testing.T) {
+	root := depthTestTree()
+	if err := limitDepth(root, 1); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+
+	var names []string
+	for _, c := range root.Children {
+		names = append(names, c.Name)
+		if len(c.Children) != 0 {
+			t.Errorf("expected %s to have no children, got %d", c.Name, len(c.Children))
+		}
+		if c.Namespace != "root" {
+			t.Errorf("expected %s in root, got namespace %q", c.Name, c.Namespace)
+		}
+	}
+	if got := strings.Join(names, ","); got != "tier,tier-app,tier-app-worker" {
+		t.Fatalf("unexpected children %s", got)
+	}
+
+	if alias := FindByNodeAlias(root, "root/tier/app/worker"); alias == nil || alias.Name != "tier-app-worker" {
+		t.Errorf("expected node alias for root/tier/app/worker, got %v", alias)
+	}
+	rewrites := root.FlattenInfoPathRewrites()
+	if rewrites["root/tier/app"] != "root/tier-app" || rewrites["root/tier/app/worker"] != "root/tier-app-worker" {
+		t.Errorf("unexpected path rewrites %v", rewrites)
+	}
+
+	// The most specific rewrite wins regardless of map order.
+	ks := &kustomizev1.Kustomization{}
+	ks.Spec.Path = "root/tier/app/worker"
+	root.Resources = append(root.Resources, ks)
+	ApplyFlattenPathRewrites(root)
+	if ks.Spec.Path != "root/tier-app-worker" {
+		t.Errorf("expected rewritten path root/tier-app-worker, got %q", ks.Spec.Path)
+	}
+}
+
+func TestLimitDepth_Write(t *testing.T) {
+	root := depthTestTree()
+	if err := limitDepth(root, 1); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+	dir := t.TempDir()
+	if err := WriteManifest(dir, DefaultLayoutConfig(), root); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	for _, p := range []string{
+		"root/tier/default-configmap-tier.yaml",
+		"root/tier-app/default-configmap-app.yaml",
+		"root/tier-app-worker/default-configmap-worker.yaml",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, "clusters", filepath.FromSlash(p))); err != nil {
+			t.Errorf("expected %s: %v", p, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "clusters", "root", "tier", "app")); !os.IsNotExist(err) {
+		t.Error("expected no nested app directory")
+	}
+	kust, err := os.ReadFile(filepath.Join(dir, "clusters", filepath.FromSlash(root.FullRepoPath()), "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization.yaml: %v", err)
+	}
+	for _, want := range []string{"  - tier\n", "  - tier-app\n", "  - tier-app-worker\n"} {
+		if !strings.Contains(string(kust), want) {
+			t.Errorf("expected root kustomization.yaml to contain %q, got:\n%s", want, kust)
+		}
+	}
+}
+
+func TestLimitDepth_DropsEmptyLayouts(t *testing.T) {
+	root := depthTestTree()
+	root.Children[0].Resources = nil // tier only groups app
+	if err := limitDepth(root, 1); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+	for _, c := range root.Children {
+		if c.Name == "tier" {
+			t.Error("expected empty tier layout to be dropped")
+		}
+	}
+	if len(root.Children) != 2 {
+		t.Errorf("expected tier-app and tier-app-worker, got %d children", len(root.Children))
+	}
+}
+
+func TestLimitDepth_Conflict(t *testing.T) {
+	root := depthTestTree()
+	root.Children = append(root.Children, &ManifestLayout{
+		Name:      "tier-app",
+		Namespace: "root",
+		Resources: []client.Object{testObject("v1", "ConfigMap", "other", "default")},
+	})
+	if err := limitDepth(root, 1); err == nil {
+		t.Fatal("expected conflict error")
+	}
+}
+
+func TestLimitDepth_Unlimited(t *testing.T) {
+	root := depthTestTree()
+	if err := limitDepth(root, 0); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+	if err := limitDepth(root, 3); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+	if len(root.Children) != 1 || root.Children[0].Children[0].Children[0].Name != "worker" {
+		t.Error("expected tree within the limit to be unchanged")
+	}
+}
+
+// depthTestKustomization returns a Flux Kustomization as the integrator
+// places it next to the layout at path.
+func depthTestKustomization(name, path string, dependsOn ...string) *kustomizev1.Kustomization {
+	ks := &kustomizev1.Kustomization{}
+	ks.APIVersion = kustomizev1.GroupVersion.String()
+	ks.Kind = kustomizev1.KustomizationKind
+	ks.Name = name
+	ks.Namespace = "flux-system"
+	ks.Spec.Path = path
+	for _, dep := range dependsOn {
+		ks.Spec.DependsOn = append(ks.Spec.DependsOn, kustomizev1.DependencyReference{Name: dep})
+	}
+	return ks
+}
+
+func TestLimitDepth_MovesKustomizations(t *testing.T) {
+	root := depthTestTree()
+	tier := root.Children[0]
+	app := tier.Children[0]
+	other := depthTestKustomization("other", "root/other", "app", "tier")
+	root.Resources = append(root.Resources, depthTestKustomization("tier", "root/tier"), other)
+	tier.Resources = append(tier.Resources, depthTestKustomization("app", "root/tier/app"))
+	app.Resources = append(app.Resources, depthTestKustomization("worker", "root/tier/app/worker", "app"))
+
+	if err := limitDepth(root, 1); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+	ApplyFlattenPathRewrites(root)
+
+	var all []*kustomizev1.Kustomization
+	collectKustomizations(root, &all)
+	got := map[string]*kustomizev1.Kustomization{}
+	for _, k := range all {
+		got[k.Name] = k
+	}
+	for name, want := range map[string]string{
+		"tier":            "root/tier",
+		"tier-app":        "root/tier-app",
+		"tier-app-worker": "root/tier-app-worker",
+	} {
+		k, ok := got[name]
+		if !ok {
+			t.Errorf("missing Kustomization %s", name)
+			continue
+		}
+		if k.Spec.Path != want {
+			t.Errorf("Kustomization %s spec.path = %q, want %q", name, k.Spec.Path, want)
+		}
+		if !slices.Contains(root.Resources, client.Object(k)) {
+			t.Errorf("expected Kustomization %s in the root layout", name)
+		}
+	}
+	if len(all) != 4 {
+		t.Errorf("expected 4 Kustomizations, got %d", len(all))
+	}
+	if deps := got["tier-app-worker"].Spec.DependsOn; len(deps) != 1 || deps[0].Name != "tier-app" {
+		t.Errorf("unexpected dependsOn of tier-app-worker: %v", deps)
+	}
+	if deps := other.Spec.DependsOn; len(deps) != 2 || deps[0].Name != "tier-app" || deps[1].Name != "tier" {
+		t.Errorf("unexpected dependsOn of other: %v", deps)
+	}
+
+	dir := t.TempDir()
+	if err := WriteManifest(dir, DefaultLayoutConfig(), root); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	kust, err := os.ReadFile(filepath.Join(dir, "clusters", "root", "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization.yaml: %v", err)
+	}
+	for _, name := range []string{"flux-system-kustomization-tier-app.yaml", "flux-system-kustomization-tier-app-worker.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, "clusters", "root", name)); err != nil {
+			t.Errorf("expected %s next to the flattened layouts: %v", name, err)
+		}
+		if !strings.Contains(string(kust), "  - "+name+"\n") {
+			t.Errorf("expected root kustomization.yaml to list %s, got:\n%s", name, kust)
+		}
+	}
+	for _, stale := range []string{"tier/flux-system-kustomization-app.yaml", "tier-app/flux-system-kustomization-worker.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, "clusters", "root", filepath.FromSlash(stale))); !os.IsNotExist(err) {
+			t.Errorf("expected no %s", stale)
+		}
+	}
+}
+
+func TestLimitDepth_DropsKustomizationsOfEmptyLayouts(t *testing.T) {
+	root := depthTestTree()
+	tier := root.Children[0]
+	tier.Resources = []client.Object{depthTestKustomization("app", "root/tier/app")}
+	other := depthTestKustomization("other", "root/other", "tier")
+	root.Resources = append(root.Resources, depthTestKustomization("tier", "root/tier"), other)
+
+	if err := limitDepth(root, 1); err != nil {
+		t.Fatalf("limitDepth: %v", err)
+	}
+	for _, obj := range root.Resources {
+		if k, ok := obj.(*kustomizev1.Kustomization); ok && k.Name == "tier" {
+			t.Error("expected the Kustomization of the dropped tier layout to be removed")
+		}
+	}
+	if len(other.Spec.DependsOn) != 0 {
+		t.Errorf("expected the dependency on the dropped layout to be removed, got %v", other.Spec.DependsOn)
+	}
+}
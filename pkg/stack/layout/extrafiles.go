@@ -0,0 +1,146 @@
+package layout
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// resolveExtraFileConflicts walks the layout tree and resolves ExtraFiles
+// that share a name within one layout according to policy. Duplicates with
+// identical content are dropped under every policy. When a file is renamed,
+// the configMapGenerator entries of its owner are updated to the new name.
+func resolveExtraFileConflicts(ml *ManifestLayout, policy ExtraFileConflictPolicy) error {
+	if ml == nil {
+		return nil
+	}
+	if len(ml.ExtraFiles) > 1 {
+		files, err := resolveExtraFiles(ml, policy)
+		if err != nil {
+			return err
+		}
+		ml.ExtraFiles = files
+	}
+	for _, child := range ml.Children {
+		if err := resolveExtraFileConflicts(child, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveExtraFiles(ml *ManifestLayout, policy ExtraFileConflictPolicy) ([]ExtraFile, error) {
+	out := make([]ExtraFile, 0, len(ml.ExtraFiles))
+	byName := make(map[string]int, len(ml.ExtraFiles))
+	for _, ef := range ml.ExtraFiles {
+		i, dup := byName[ef.Name]
+		if !dup {
+			byName[ef.Name] = len(out)
+			out = append(out, ef)
+			continue
+		}
+		if bytes.Equal(out[i].Content, ef.Content) {
+			continue
+		}
+		conflict := func(reason string) error {
+			return errors.ResourceValidationError("ManifestLayout", ml.FullRepoPath(), "extraFiles",
+				fmt.Sprintf("extra file %q from %s conflicts with %s: %s", ef.Name, ownerOf(ef), ownerOf(out[i]), reason), nil)
+		}
+		switch policy {
+		case ExtraFileConflictMerge:
+			merged, err := mergeYAMLFiles(out[i].Content, ef.Content)
+			if err != nil {
+				return nil, conflict(err.Error())
+			}
+			out[i].Content = merged
+		case ExtraFileConflictRename:
+			if ef.Owner == "" {
+				return nil, conflict("file has no owner to rename it after")
+			}
+			ext := path.Ext(ef.Name)
+			oldName := ef.Name
+			ef.Name = strings.TrimSuffix(ef.Name, ext) + "-" + ef.Owner + ext
+			if _, taken := byName[ef.Name]; taken {
+				return nil, conflict(fmt.Sprintf("renamed file %q already exists", ef.Name))
+			}
+			if err := renameGeneratorFiles(ml, ef.Owner, oldName, ef.Name); err != nil {
+				return nil, conflict(err.Error())
+			}
+			byName[ef.Name] = len(out)
+			out = append(out, ef)
+		default:
+			return nil, conflict("content differs")
+		}
+	}
+	return out, nil
+}
+
+// renameGeneratorFiles points the configMapGenerator entries of owner that
+// include oldName at newName. The entries keep oldName as the ConfigMap key
+// ("oldName=newName"), so consumers such as HelmRelease valuesFrom keep
+// working. A generator without an owner that includes oldName is an error
+// because it cannot be told which of the conflicting files it means.
+func renameGeneratorFiles(ml *ManifestLayout, owner, oldName, newName string) error {
+	for gi := range ml.ConfigMapGenerators {
+		g := &ml.ConfigMapGenerators[gi]
+		for fi, entry := range g.Files {
+			key, file, hasKey := strings.Cut(entry, "=")
+			if !hasKey {
+				key, file = oldName, entry
+			}
+			if file != oldName {
+				continue
+			}
+			if g.Owner == "" {
+				return errors.Errorf("configMapGenerator %q without an owner includes %q", g.Name, oldName)
+			}
+			if g.Owner != owner {
+				continue
+			}
+			g.Files[fi] = key + "=" + newName
+		}
+	}
+	return nil
+}
+
+// ownerOf describes the producer of ef for error messages.
+func ownerOf(ef ExtraFile) string {
+	if ef.Owner == "" {
+		return "an unknown producer"
+	}
+	return fmt.Sprintf("application %q", ef.Owner)
+}
+
+// mergeYAMLFiles deep-merges two YAML mappings; values from overlay win.
+func mergeYAMLFiles(base, overlay []byte) ([]byte, error) {
+	var b, o map[string]any
+	if err := yaml.Unmarshal(base, &b); err != nil {
+		return nil, errors.Wrap(err, "existing file is not a YAML mapping")
+	}
+	if err := yaml.Unmarshal(overlay, &o); err != nil {
+		return nil, errors.Wrap(err, "new file is not a YAML mapping")
+	}
+	return yaml.Marshal(mergeMaps(b, o))
+}
+
+// mergeMaps returns base with overlay merged in recursively.
+func mergeMaps(base, overlay map[string]any) map[string]any {
+	if base == nil {
+		base = map[string]any{}
+	}
+	for k, v := range overlay {
+		if om, ok := v.(map[string]any); ok {
+			if bm, ok := base[k].(map[string]any); ok {
+				base[k] = mergeMaps(bm, om)
+				continue
+			}
+		}
+		base[k] = v
+	}
+	return base
+}
@@ -0,0 +1,94 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+)
+
+func conflictLayout() *ManifestLayout {
+	return &ManifestLayout{
+		Name:      "apps",
+		Namespace: "cluster",
+		ExtraFiles: []ExtraFile{
+			{Name: "values.yaml", Content: []byte("image:\n  tag: v1\nreplicas: 1\n"), Owner: "web"},
+			{Name: "values.yaml", Content: []byte("image:\n  repo: api\n"), Owner: "api"},
+			{Name: "NOTICE", Content: []byte("generated\n")},
+			{Name: "NOTICE", Content: []byte("generated\n")},
+		},
+	}
+}
+
+func TestResolveExtraFileConflicts_Error(t *testing.T) {
+	for _, policy := range []ExtraFileConflictPolicy{ExtraFileConflictUnset, ExtraFileConflictError} {
+		err := resolveExtraFileConflicts(conflictLayout(), policy)
+		if err == nil || !strings.Contains(err.Error(), `application "api"`) {
+			t.Errorf("policy %q: expected conflict error naming the application, got %v", policy, err)
+		}
+	}
+}
+
+func TestResolveExtraFileConflicts_Merge(t *testing.T) {
+	ml := conflictLayout()
+	if err := resolveExtraFileConflicts(ml, ExtraFileConflictMerge); err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if len(ml.ExtraFiles) != 2 {
+		t.Fatalf("expected 2 files after merge and dedup, got %d", len(ml.ExtraFiles))
+	}
+	got := string(ml.ExtraFiles[0].Content)
+	for _, want := range []string{"tag: v1", "repo: api", "replicas: 1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged values.yaml missing %q:\n%s", want, got)
+		}
+	}
+
+	notYAML := &ManifestLayout{ExtraFiles: []ExtraFile{
+		{Name: "script.sh", Content: []byte("echo a\n")},
+		{Name: "script.sh", Content: []byte("echo b\n")},
+	}}
+	if err := resolveExtraFileConflicts(notYAML, ExtraFileConflictMerge); err == nil {
+		t.Error("expected merge of non-mapping content to fail")
+	}
+}
+
+func TestResolveExtraFileConflicts_Rename(t *testing.T) {
+	ml := conflictLayout()
+	if err := resolveExtraFileConflicts(ml, ExtraFileConflictRename); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	var names []string
+	for _, ef := range ml.ExtraFiles {
+		names = append(names, ef.Name)
+	}
+	if strings.Join(names, ",") != "values.yaml,values-api.yaml,NOTICE" {
+		t.Errorf("unexpected files after rename: %v", names)
+	}
+}
+
+func TestResolveExtraFileConflicts_RenameUpdatesGenerators(t *testing.T) {
+	ml := conflictLayout()
+	ml.ConfigMapGenerators = []ConfigMapGeneratorSpec{
+		{Name: "web-values", Files: []string{"values.yaml"}, Owner: "web"},
+		{Name: "api-values", Files: []string{"values.yaml", "NOTICE"}, Owner: "api"},
+		{Name: "api-keyed", Files: []string{"config=values.yaml"}, Owner: "api"},
+	}
+	if err := resolveExtraFileConflicts(ml, ExtraFileConflictRename); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	want := [][]string{
+		{"values.yaml"},
+		{"values.yaml=values-api.yaml", "NOTICE"},
+		{"config=values-api.yaml"},
+	}
+	for i, g := range ml.ConfigMapGenerators {
+		if strings.Join(g.Files, ",") != strings.Join(want[i], ",") {
+			t.Errorf("generator %s: files = %v, want %v", g.Name, g.Files, want[i])
+		}
+	}
+
+	ownerless := conflictLayout()
+	ownerless.ConfigMapGenerators = []ConfigMapGeneratorSpec{{Name: "values", Files: []string{"values.yaml"}}}
+	if err := resolveExtraFileConflicts(ownerless, ExtraFileConflictRename); err == nil {
+		t.Error("expected an ownerless generator that includes the renamed file to fail")
+	}
+}
@@ -1,6 +1,7 @@
 package layout
 
 import (
+	"sort"
 	"strings"
 
 	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
@@ -23,6 +24,7 @@ import (
 // On collapse:
 //   - parent.Resources = child.Resources, parent.Children = nil.
 //   - parent.ExtraFiles += child.ExtraFiles, parent.ConfigMapGenerators += child.ConfigMapGenerators.
+//   - child.Index is merged into parent.Index, and frozen markers are copied.
 //   - Inherit child's Mode / FilePer / ApplicationFileMode / FileNaming if
 //     the parent has them as their unset sentinel value.
 //   - Populate parent.flattenInfo (nodeAliases + pathRewrites).
@@ -53,6 +55,8 @@ func flattenSingleTier(root *ManifestLayout, c *stack.Cluster, rules LayoutRules
 	root.Resources = child.Resources
 	root.ExtraFiles = append(root.ExtraFiles, child.ExtraFiles...)
 	root.ConfigMapGenerators = append(root.ConfigMapGenerators, child.ConfigMapGenerators...)
+	mergeIndex(root, child.Index)
+	mergeFrozen(root, child)
 	root.Children = nil
 	if root.Mode == KustomizationUnset && child.Mode != KustomizationUnset {
 		root.Mode = child.Mode
@@ -199,6 +203,23 @@ func collectPathRewrites(ml *ManifestLayout) map[string]string {
 }
 
 func rewriteFluxPaths(ml *ManifestLayout, rewrites map[string]string) {
+	// Test the longest (most specific) paths first: MaxDepth records a
+	// rewrite for every moved layout, so a nested path can match both its
+	// own entry exactly and an ancestor's entry as a prefix.
+	keys := make([]string, 0, len(rewrites))
+	for old := range rewrites {
+		keys = append(keys, old)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	rewriteFluxPathsOrdered(ml, rewrites, keys)
+}
+
+func rewriteFluxPathsOrdered(ml *ManifestLayout, rewrites map[string]string, keys []string) {
 	if ml == nil {
 		return
 	}
@@ -207,11 +228,11 @@ func rewriteFluxPaths(ml *ManifestLayout, rewrites map[string]string) {
 		if !ok {
 			continue
 		}
-		for old, neu := range rewrites {
+		for _, old := range keys {
+			neu := rewrites[old]
 			// Once a rewrite fires for this resource, stop testing the
-			// remaining entries against the modified path. With Go's
-			// non-deterministic map iteration, continuing could double-
-			// rewrite if multiple rewrites' keys/values overlap.
+			// remaining entries against the modified path, so that
+			// overlapping keys and values never double-rewrite.
 			if k.Spec.Path == old {
 				k.Spec.Path = neu
 				break
@@ -223,6 +244,6 @@ func rewriteFluxPaths(ml *ManifestLayout, rewrites map[string]string) {
 		}
 	}
 	for _, child := range ml.Children {
-		rewriteFluxPaths(child, rewrites)
+		rewriteFluxPathsOrdered(child, rewrites, keys)
 	}
 }
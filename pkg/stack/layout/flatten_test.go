@@ -427,7 +427,7 @@ func TestWalkNodeForPackageInternal_Nil(t *testing.T) {
 
 func TestWalkNode_Nil(t *testing.T) {
 	// walkNode(nil, ...) should return nil without error
-	got, err := walkNode(nil, nil, false, false, FilePerResource, nil, FluxSeparate, "")
+	got, err := walkNode(nil, nil, false, false, FilePerResource, nil, FluxSeparate, "", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -454,6 +454,7 @@ func TestWalkUmbrellaChildLayouts_NilChild(t *testing.T) {
 		FilePerResource,
 		FluxSeparate,
 		"",
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("unexpected error with nil child: %v", err)
@@ -0,0 +1,81 @@
+package layout
+
+import (
+	stderrors "errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// ValidateFluxPaths checks that the spec.path of every Flux Kustomization in
+// the layout tree resolves to a directory of the tree that holds at least
+// one manifest, directly or in a sub-layout. Paths are resolved like the
+// integrator writes them: relative to the directory the layout tree is
+// written into, with an optional "./" prefix.
+//
+// LayoutIntegrator.IntegrateWithLayout runs it after integrating the Flux
+// resources; run it directly after changing the layout afterwards, for
+// example with MergeClusters, to catch paths that would otherwise only fail
+// when the cluster reconciles. Every broken path is reported; the errors
+// name the Kustomization and the layout it was placed in.
+func ValidateFluxPaths(root *ManifestLayout) error {
+	if root == nil {
+		return nil
+	}
+	dirs := map[string]bool{}
+	indexLayoutDirs(root, dirs)
+
+	var errs []error
+	checkFluxPaths(root, dirs, &errs)
+	return stderrors.Join(errs...)
+}
+
+// indexLayoutDirs records every directory written for ml and its children,
+// mapped to whether it holds a manifest. Resources, configMapGenerator
+// entries and references to other directories all count.
+func indexLayoutDirs(ml *ManifestLayout, dirs map[string]bool) bool {
+	hasManifests := len(ml.Resources) > 0 || len(ml.ConfigMapGenerators) > 0 || len(ml.References) > 0
+	for _, child := range ml.Children {
+		if child != nil && indexLayoutDirs(child, dirs) {
+			hasManifests = true
+		}
+	}
+	if !ml.writtenAsFile() {
+		dir := path.Clean(filepath.ToSlash(ml.FullRepoPath()))
+		dirs[dir] = dirs[dir] || hasManifests
+	}
+	return hasManifests
+}
+
+func checkFluxPaths(ml *ManifestLayout, dirs map[string]bool, errs *[]error) {
+	for _, obj := range ml.Resources {
+		k, ok := obj.(*kustomizev1.Kustomization)
+		if !ok {
+			continue
+		}
+		target := path.Clean(strings.TrimPrefix(k.Spec.Path, "./"))
+		if target == "." {
+			// The root of the source always exists.
+			continue
+		}
+		hasManifests, exists := dirs[target]
+		switch {
+		case !exists:
+			*errs = append(*errs, errors.ResourceValidationError("Kustomization", k.Name, "spec.path",
+				fmt.Sprintf("path %q referenced from layout %q does not exist in the layout", k.Spec.Path, ml.FullRepoPath()), nil))
+		case !hasManifests:
+			*errs = append(*errs, errors.ResourceValidationError("Kustomization", k.Name, "spec.path",
+				fmt.Sprintf("path %q referenced from layout %q contains no manifests", k.Spec.Path, ml.FullRepoPath()), nil))
+		}
+	}
+	for _, child := range ml.Children {
+		if child != nil {
+			checkFluxPaths(child, dirs, errs)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/errors"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+func fluxPathKustomization(name, path string) *kustomizev1.Kustomization {
+	k := &kustomizev1.Kustomization{}
+	k.SetName(name)
+	k.Spec.Path = path
+	return k
+}
+
+// fluxPathLayout returns demo -> {apps -> web (one ConfigMap), empty} with
+// the given Kustomizations placed at the cluster layout.
+func fluxPathLayout(ks ...client.Object) *layout.ManifestLayout {
+	web := &layout.ManifestLayout{Name: "web", Namespace: "demo/apps", Resources: []client.Object{*makeCM("web")}}
+	apps := &layout.ManifestLayout{Name: "apps", Namespace: "demo", Children: []*layout.ManifestLayout{web}}
+	empty := &layout.ManifestLayout{Name: "empty", Namespace: "demo"}
+	return &layout.ManifestLayout{
+		Name:      "",
+		Namespace: "demo",
+		Resources: ks,
+		Children:  []*layout.ManifestLayout{apps, empty},
+	}
+}
+
+func TestValidateFluxPaths_Valid(t *testing.T) {
+	ml := fluxPathLayout(
+		fluxPathKustomization("apps", "demo/apps"),
+		fluxPathKustomization("web", "./demo/apps/web"),
+		fluxPathKustomization("all", "./"),
+	)
+	if err := layout.ValidateFluxPaths(ml); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateFluxPaths_Broken(t *testing.T) {
+	ml := fluxPathLayout(
+		fluxPathKustomization("missing", "./demo/db"),
+		fluxPathKustomization("empty", "demo/empty"),
+	)
+	err := layout.ValidateFluxPaths(ml)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{`"./demo/db" referenced from layout "demo" does not exist`, `"demo/empty" referenced from layout "demo" contains no manifests`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+	if !errors.IsKureError(err) {
+		t.Errorf("expected structured kure errors, got %T", err)
+	}
+}
+
+func TestValidateFluxPaths_Nil(t *testing.T) {
+	if err := layout.ValidateFluxPaths(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateFluxPaths_GeneratorsAndReferences(t *testing.T) {
+	generated := &layout.ManifestLayout{
+		Name:                "generated",
+		Namespace:           "demo",
+		ConfigMapGenerators: []layout.ConfigMapGeneratorSpec{{Name: "values", Files: []string{"values.yaml"}}},
+	}
+	referencing := &layout.ManifestLayout{Name: "shared", Namespace: "demo", References: []string{"../infrastructure/infra"}}
+	ml := &layout.ManifestLayout{
+		Name:      "",
+		Namespace: "demo",
+		Resources: []client.Object{
+			fluxPathKustomization("generated", "demo/generated"),
+			fluxPathKustomization("shared", "demo/shared"),
+		},
+		Children: []*layout.ManifestLayout{generated, referencing},
+	}
+	if err := layout.ValidateFluxPaths(ml); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package layout
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+// frozenSet records the resources and extra files of a layout whose files
+// on disk must be kept rather than rewritten.
+type frozenSet struct {
+	objects map[client.Object]struct{}
+	files   map[string]struct{}
+}
+
+// FreezeResources marks objs as frozen. WriteManifest and WriteToDisk still
+// list the files holding frozen resources in kustomization.yaml but do not
+// write them, so a previously generated (and possibly hand-edited) file is
+// kept as-is. A file is only skipped when every resource in it is frozen and
+// it already exists; missing files are generated. WriteToTar ignores
+// freezing because an archive has no previous contents.
+func (ml *ManifestLayout) FreezeResources(objs ...client.Object) {
+	if len(objs) == 0 {
+		return
+	}
+	fs := ml.frozenSet()
+	for _, obj := range objs {
+		if obj != nil {
+			fs.objects[obj] = struct{}{}
+		}
+	}
+}
+
+// FreezeExtraFiles marks the named ExtraFiles as frozen so that the disk
+// writers keep the existing files instead of overwriting them.
+func (ml *ManifestLayout) FreezeExtraFiles(names ...string) {
+	if len(names) == 0 {
+		return
+	}
+	fs := ml.frozenSet()
+	for _, name := range names {
+		fs.files[name] = struct{}{}
+	}
+}
+
+// IsFrozen reports whether obj was marked frozen on this layout.
+func (ml *ManifestLayout) IsFrozen(obj client.Object) bool {
+	if ml.frozen == nil {
+		return false
+	}
+	_, ok := ml.frozen.objects[obj]
+	return ok
+}
+
+func (ml *ManifestLayout) frozenSet() *frozenSet {
+	if ml.frozen == nil {
+		ml.frozen = &frozenSet{
+			objects: map[client.Object]struct{}{},
+			files:   map[string]struct{}{},
+		}
+	}
+	return ml.frozen
+}
+
+// allFrozen reports whether every object in objs is frozen.
+func (ml *ManifestLayout) allFrozen(objs []client.Object) bool {
+	if ml.frozen == nil || len(objs) == 0 {
+		return false
+	}
+	for _, obj := range objs {
+		if !ml.IsFrozen(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// writableExtraFiles returns the ExtraFiles that must be written: those that
+// are not frozen and the frozen ones for which keep reports that there is no
+// existing file to keep.
+func (ml *ManifestLayout) writableExtraFiles(keep func(name string) bool) []ExtraFile {
+	if ml.frozen == nil || len(ml.frozen.files) == 0 {
+		return ml.ExtraFiles
+	}
+	var out []ExtraFile
+	for _, ef := range ml.ExtraFiles {
+		if _, ok := ml.frozen.files[ef.Name]; ok && keep(ef.Name) {
+			continue
+		}
+		out = append(out, ef)
+	}
+	return out
+}
+
+// mergeFrozen copies the frozen markers of src into dst. It is used wherever
+// the walker moves resources or extra files from one layout to another.
+func mergeFrozen(dst, src *ManifestLayout) {
+	if src == nil || src.frozen == nil {
+		return
+	}
+	fs := dst.frozenSet()
+	for obj := range src.frozen.objects {
+		fs.objects[obj] = struct{}{}
+	}
+	for name := range src.frozen.files {
+		fs.files[name] = struct{}{}
+	}
+}
+
+// isAppFrozen reports whether app is frozen directly or through b.
+func isAppFrozen(b *stack.Bundle, app *stack.Application) bool {
+	return app.IsFrozen() || b.IsFrozen()
+}
+
+// freezeAppLayout freezes every resource and extra file of a per-application
+// layout and reports the application to wc.
+func freezeAppLayout(ml *ManifestLayout, app *stack.Application, wc *walkContext) {
+	ml.FreezeResources(ml.Resources...)
+	for _, ef := range ml.ExtraFiles {
+		ml.FreezeExtraFiles(ef.Name)
+	}
+	wc.warn(app.Name, "application is frozen; existing files are kept")
+}
@@ -0,0 +1,151 @@
+package layout_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kerrors "github.com/go-kure/kure/pkg/errors"
+	"github.com/go-kure/kure/pkg/stack"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+func freezeTestObject(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	return obj
+}
+
+func freezeTestApp(name string, frozen bool) *stack.Application {
+	var o client.Object = freezeTestObject(name)
+	app := stack.NewApplication(name, "default", &fakeConfig{objs: []*client.Object{&o}})
+	app.SetFrozen(frozen)
+	return app
+}
+
+func TestWalkCluster_FrozenApplicationKeepsFiles(t *testing.T) {
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{
+		freezeTestApp("hotfixed", true),
+		freezeTestApp("regular", false),
+	}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	warnings := &kerrors.Warnings{}
+	rules := layout.LayoutRules{
+		ClusterName:         "root",
+		BundleGrouping:      layout.GroupFlat,
+		ApplicationGrouping: layout.GroupFlat,
+		Warnings:            warnings,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if len(ml.Resources) != 2 {
+		t.Fatalf("expected frozen output to stay in the layout, got %d resources", len(ml.Resources))
+	}
+
+	frozenWarning := false
+	for _, w := range warnings.Items() {
+		if w.Path == "hotfixed" && strings.Contains(w.Message, "frozen") {
+			frozenWarning = true
+		}
+	}
+	if !frozenWarning {
+		t.Errorf("expected frozen application to be reported, got %v", warnings.Items())
+	}
+
+	dir := t.TempDir()
+	outDir := filepath.Join(dir, "clusters", "root")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hotfixFile := filepath.Join(outDir, "default-configmap-hotfixed.yaml")
+	if err := os.WriteFile(hotfixFile, []byte("# manual hotfix\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	data, err := os.ReadFile(hotfixFile)
+	if err != nil {
+		t.Fatalf("read frozen file: %v", err)
+	}
+	if string(data) != "# manual hotfix\n" {
+		t.Errorf("expected frozen file to be kept, got:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "default-configmap-regular.yaml")); err != nil {
+		t.Errorf("expected regular application to be written: %v", err)
+	}
+	kust, err := os.ReadFile(filepath.Join(outDir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization.yaml: %v", err)
+	}
+	if !strings.Contains(string(kust), "default-configmap-hotfixed.yaml") {
+		t.Errorf("expected frozen file to stay referenced, got:\n%s", kust)
+	}
+}
+
+func TestWalkCluster_FrozenBundleAnnotation(t *testing.T) {
+	bundle := &stack.Bundle{
+		Name:         "apps",
+		Annotations:  map[string]string{stack.AnnotationFreezeKey: "true"},
+		Applications: []*stack.Application{freezeTestApp("web", false)},
+	}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	rules := layout.LayoutRules{
+		BundleGrouping:      layout.GroupByName,
+		ApplicationGrouping: layout.GroupByName,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+
+	// root -> bundle -> app
+	appLayout := ml.Children[0].Children[0]
+	if len(appLayout.Resources) != 1 || !appLayout.IsFrozen(appLayout.Resources[0]) {
+		t.Fatalf("expected application resources to be frozen through the bundle annotation")
+	}
+}
+
+func TestWriteManifest_FrozenFileMissingIsWritten(t *testing.T) {
+	app := freezeTestApp("hotfixed", false)
+	app.SetAnnotations(map[string]string{stack.AnnotationSkipKey: "true"})
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{app}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	ml, err := layout.WalkCluster(cluster, layout.LayoutRules{
+		ClusterName:         "root",
+		BundleGrouping:      layout.GroupFlat,
+		ApplicationGrouping: layout.GroupFlat,
+	})
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if len(ml.Resources) != 1 || !ml.IsFrozen(ml.Resources[0]) {
+		t.Fatal("expected the skip annotation to freeze the application")
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "clusters", "root", "default-configmap-hotfixed.yaml"))
+	if err != nil {
+		t.Fatalf("expected missing frozen file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "name: hotfixed") {
+		t.Errorf("unexpected frozen file content:\n%s", data)
+	}
+}
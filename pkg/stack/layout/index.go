@@ -0,0 +1,61 @@
+package layout
+
+import (
+	"sigs.k8s.io/yaml"
+
+	"github.com/go-kure/kure/pkg/errors"
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+// ManifestIndexFileName is the name of the file written into each layout
+// directory that carries a ManifestIndex when LayoutRules.ManifestIndex is set.
+// The file is not referenced from kustomization.yaml.
+const ManifestIndexFileName = "index.yaml"
+
+// newBundleIndex returns an empty ManifestIndex for b.
+func newBundleIndex(b *stack.Bundle) *stack.ManifestIndex {
+	return &stack.ManifestIndex{Bundle: b.Name, Applications: []stack.ApplicationIndex{}}
+}
+
+// mergeIndex appends the application entries of src to ml.Index. The merged
+// index no longer describes a single bundle, so its Bundle name is cleared
+// when the sources differ.
+func mergeIndex(ml *ManifestLayout, src *stack.ManifestIndex) {
+	if src == nil || len(src.Applications) == 0 {
+		return
+	}
+	if ml.Index == nil {
+		ml.Index = &stack.ManifestIndex{Bundle: src.Bundle, Applications: []stack.ApplicationIndex{}}
+	} else if ml.Index.Bundle != src.Bundle {
+		ml.Index.Bundle = ""
+	}
+	ml.Index.Applications = append(ml.Index.Applications, src.Applications...)
+}
+
+// attachManifestIndexes walks the layout tree and adds an index.yaml
+// ExtraFile to every layout with a non-empty Index. It fails when a layout
+// already carries an ExtraFile with the same name.
+func attachManifestIndexes(ml *ManifestLayout) error {
+	if ml == nil {
+		return nil
+	}
+	if ml.Index != nil && len(ml.Index.Applications) > 0 {
+		for _, ef := range ml.ExtraFiles {
+			if ef.Name == ManifestIndexFileName {
+				return errors.ResourceValidationError("ManifestLayout", ml.FullRepoPath(), "extraFiles",
+					"extra file "+ManifestIndexFileName+" conflicts with the manifest index", nil)
+			}
+		}
+		data, err := yaml.Marshal(ml.Index)
+		if err != nil {
+			return errors.Wrapf(err, "marshal manifest index for %s", ml.FullRepoPath())
+		}
+		ml.ExtraFiles = append(ml.ExtraFiles, ExtraFile{Name: ManifestIndexFileName, Content: data})
+	}
+	for _, child := range ml.Children {
+		if err := attachManifestIndexes(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
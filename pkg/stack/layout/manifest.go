@@ -1,9 +1,11 @@
 package layout
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -12,6 +14,7 @@ import (
 
 	"github.com/go-kure/kure/pkg/errors"
 	kio "github.com/go-kure/kure/pkg/io"
+	"github.com/go-kure/kure/pkg/stack"
 )
 
 type ManifestLayout struct {
@@ -47,6 +50,19 @@ type ManifestLayout struct {
 	// translates these into spec.dependsOn on the emitted Kustomization CR.
 	// Augmenters (LayoutAugmenter) set this field; the integrator reads it.
 	DependsOn []string
+	// References lists extra entries for the resources list of this
+	// layout's kustomization.yaml, relative to its directory. MergeClusters
+	// uses them to point clusters at shared directories outside their own
+	// tree, e.g. "../../infrastructure/monitoring".
+	References []string
+	// Index records which application produced each resource placed in this
+	// layout. The walker populates it on layouts that receive application
+	// output; it is persisted as index.yaml only when
+	// LayoutRules.ManifestIndex is set.
+	Index *stack.ManifestIndex
+	// frozen records resources and extra files whose existing files are kept
+	// by the disk writers. See FreezeResources.
+	frozen *frozenSet
 	// flattenInfo carries the redirects produced by FlattenSingleTier when
 	// this layout absorbed a collapsed child. Set only on the absorbing
 	// layout; never serialised. Consulted by the Flux integrator's
@@ -55,6 +71,9 @@ type ManifestLayout struct {
 	// multiple times on the same flattened layout without losing the alias
 	// state needed by integrated placement.
 	flattenInfo *flattenInfo
+	// repoFiles holds the .sourceignore and .gitattributes rules of the
+	// root layout. The writers emit them at the repository root.
+	repoFiles *repoFileRules
 }
 
 // flattenInfo records the redirects produced by a FlattenSingleTier collapse.
@@ -95,14 +114,100 @@ func (ml *ManifestLayout) FlattenInfoPathRewrites() map[string]string {
 type ExtraFile struct {
 	Name    string
 	Content []byte
+	// Owner names the application that produced the file. The walker sets
+	// it for files added by a LayoutAugmenter; ExtraFileConflictRename uses
+	// it as the rename suffix.
+	Owner string
+	// OwnerNamespace is the namespace of the Owner application. Together
+	// with Owner it selects the application's provenance header entry.
+	OwnerNamespace string
 }
 
 // ConfigMapGeneratorSpec describes a single kustomize configMapGenerator entry.
 // Files are paths (relative to the layout directory) of files included in the
-// generated ConfigMap.
+// generated ConfigMap, optionally in kustomize's "key=path" form.
 type ConfigMapGeneratorSpec struct {
 	Name  string
 	Files []string
+	// Owner names the application that produced the generator. The walker
+	// sets it for generators added by a LayoutAugmenter;
+	// ExtraFileConflictRename uses it to update the Files of the renamed
+	// application.
+	Owner string
+}
+
+// clone returns a deep copy of ml and its descendants. Resources are deep
+// copied and the frozen markers follow the copies, so the copy can be
+// modified without affecting ml. flattenInfo is shared because it is only
+// read after the walk.
+func (ml *ManifestLayout) clone() *ManifestLayout {
+	if ml == nil {
+		return nil
+	}
+	c := *ml
+	copies := make(map[client.Object]client.Object, len(ml.Resources))
+	c.Resources = make([]client.Object, len(ml.Resources))
+	for i, obj := range ml.Resources {
+		if obj == nil {
+			continue
+		}
+		cp, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			cp = obj
+		}
+		copies[obj] = cp
+		c.Resources[i] = cp
+	}
+	if ml.frozen != nil {
+		c.frozen = nil
+		fs := c.frozenSet()
+		for obj := range ml.frozen.objects {
+			if cp, ok := copies[obj]; ok {
+				obj = cp
+			}
+			fs.objects[obj] = struct{}{}
+		}
+		for name := range ml.frozen.files {
+			fs.files[name] = struct{}{}
+		}
+	}
+	if ml.PackageRef != nil {
+		ref := *ml.PackageRef
+		c.PackageRef = &ref
+	}
+	c.ExtraFiles = make([]ExtraFile, len(ml.ExtraFiles))
+	for i, ef := range ml.ExtraFiles {
+		ef.Content = bytes.Clone(ef.Content)
+		c.ExtraFiles[i] = ef
+	}
+	c.ConfigMapGenerators = make([]ConfigMapGeneratorSpec, len(ml.ConfigMapGenerators))
+	for i, g := range ml.ConfigMapGenerators {
+		g.Files = slices.Clone(g.Files)
+		c.ConfigMapGenerators[i] = g
+	}
+	c.DependsOn = slices.Clone(ml.DependsOn)
+	c.References = slices.Clone(ml.References)
+	if ml.Index != nil {
+		idx := *ml.Index
+		idx.Applications = make([]stack.ApplicationIndex, len(ml.Index.Applications))
+		for i, entry := range ml.Index.Applications {
+			entry.Objects = slices.Clone(entry.Objects)
+			idx.Applications[i] = entry
+		}
+		c.Index = &idx
+	}
+	c.Children = make([]*ManifestLayout, len(ml.Children))
+	for i, child := range ml.Children {
+		c.Children[i] = child.clone()
+	}
+	return &c
+}
+
+// writtenAsFile reports whether ml is written as a single <Name>.yaml into
+// its parent's directory. The parent's kustomization.yaml references that
+// file, so ml must not write a kustomization.yaml of its own there.
+func (ml *ManifestLayout) writtenAsFile() bool {
+	return ml.ApplicationFileMode == AppFileSingle && len(ml.Children) == 0
 }
 
 // resolveManifestFileName returns the effective ManifestFileNameFunc for this
@@ -243,15 +348,17 @@ func (ml *ManifestLayout) WriteToDisk(basePath string) error {
 		appMode = AppFilePerResource
 	}
 
-	var fullPath string
+	dir := ml.FullRepoPath()
 	if appMode == AppFileSingle {
-		fullPath = filepath.Join(basePath, ml.Namespace)
-	} else {
-		fullPath = filepath.Join(basePath, ml.FullRepoPath())
+		dir = ml.Namespace
 	}
+	fullPath := filepath.Join(basePath, dir)
 	if err := os.MkdirAll(fullPath, 0755); err != nil {
 		return errors.NewFileError("create", fullPath, "directory creation failed", err)
 	}
+	if err := writeExtraFilesToDisk(basePath, ml.repoFiles.files(filepath.ToSlash(dir))); err != nil {
+		return err
+	}
 
 	fileGroups := map[string][]client.Object{}
 	for _, obj := range ml.Resources {
@@ -288,6 +395,10 @@ func (ml *ManifestLayout) WriteToDisk(basePath string) error {
 
 	for _, fileName := range sortedFileNames {
 		objs := fileGroups[fileName]
+		if ml.allFrozen(objs) && fileExists(filepath.Join(fullPath, fileName)) {
+			// Keep the existing file; it is still listed below.
+			continue
+		}
 		f, err := os.Create(filepath.Join(fullPath, fileName))
 		if err != nil {
 			return err
@@ -317,7 +428,8 @@ func (ml *ManifestLayout) WriteToDisk(basePath string) error {
 		}
 	}
 
-	if err := writeExtraFilesToDisk(fullPath, ml.ExtraFiles); err != nil {
+	keepExtra := func(name string) bool { return fileExists(filepath.Join(fullPath, name)) }
+	if err := writeExtraFilesToDisk(fullPath, ml.writableExtraFiles(keepExtra)); err != nil {
 		return err
 	}
 
@@ -328,7 +440,7 @@ func (ml *ManifestLayout) WriteToDisk(basePath string) error {
 
 	// Generate kustomization.yaml if there are resources or children
 	// Every directory with manifests should have a kustomization.yaml for proper GitOps workflow
-	if len(fileGroups) > 0 || len(ml.Children) > 0 {
+	if !ml.writtenAsFile() && (len(fileGroups) > 0 || len(ml.Children) > 0 || len(ml.References) > 0) {
 		kustomPath := filepath.Join(fullPath, "kustomization.yaml")
 		kf, err := os.Create(kustomPath)
 		if err != nil {
@@ -392,6 +504,9 @@ func (ml *ManifestLayout) WriteToDisk(basePath string) error {
 				writeStr(fmt.Sprintf("  - %s\n", child.Name))
 			}
 		}
+		for _, ref := range ml.References {
+			writeStr(fmt.Sprintf("  - %s\n", ref))
+		}
 
 		writeStr(renderConfigMapGeneratorBlock(ml.ConfigMapGenerators))
 
@@ -0,0 +1,237 @@
+package layout
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// DefaultSharedDirectory is the directory MergeClusters moves shared
+// layouts to when LayoutRules.SharedDirectory is empty.
+const DefaultSharedDirectory = "infrastructure"
+
+// MergeClusters combines the layouts of several clusters, each walked with
+// its own LayoutRules.ClusterName, into one repository tree with a
+// directory per cluster. Writing the result with WriteManifest produces
+// clusters/<name>/... for every cluster.
+//
+// Layouts found at the same path in every cluster with identical content
+// (compared through Render) are deduplicated: one copy moves to
+// rules.SharedDirectory (DefaultSharedDirectory when empty), keeping its
+// path below the cluster directory, and each cluster's kustomization.yaml
+// references the shared copy through ManifestLayout.References instead.
+// Only the outermost identical layout of a subtree is moved. Clusters must
+// have distinct directories that do not clash with the shared directory.
+//
+// Flux Kustomizations whose spec.path points at a moved layout, or below
+// it, are rewritten to the shared copy, and the rewrites are recorded like
+// FlattenSingleTier's so that ApplyFlattenPathRewrites repeats them. A
+// parent that holds the Kustomization of a moved layout, as with integrated
+// Flux placement, keeps listing that Kustomization and gets no reference.
+//
+// The input layouts are modified in place. Flux resources that embed
+// cluster-specific paths differ between clusters and are never shared.
+func MergeClusters(clusters []*ManifestLayout, rules LayoutRules) (*ManifestLayout, error) {
+	shared := rules.SharedDirectory
+	if shared == "" {
+		shared = DefaultSharedDirectory
+	}
+
+	root := &ManifestLayout{Name: "", Namespace: "."}
+	seen := map[string]struct{}{}
+	var members []*ManifestLayout
+	for _, c := range clusters {
+		if c == nil {
+			continue
+		}
+		dir := c.FullRepoPath()
+		if c.Namespace == "" || dir == "." || strings.Contains(dir, "/") {
+			return nil, errors.ResourceValidationError("ManifestLayout", dir, "clusterName",
+				"MergeClusters needs layouts walked with LayoutRules.ClusterName", nil)
+		}
+		if dir == shared {
+			return nil, errors.ResourceValidationError("ManifestLayout", dir, "clusterName",
+				fmt.Sprintf("cluster directory conflicts with the shared directory %q", shared), nil)
+		}
+		if _, dup := seen[dir]; dup {
+			return nil, errors.ResourceValidationError("ManifestLayout", dir, "clusterName",
+				"duplicate cluster directory", nil)
+		}
+		seen[dir] = struct{}{}
+		members = append(members, c)
+		root.Children = append(root.Children, c)
+	}
+
+	if len(members) > 1 {
+		sharedLayouts, rewrites, err := dedupeClusters(members, shared)
+		if err != nil {
+			return nil, err
+		}
+		root.Children = append(root.Children, sharedLayouts...)
+		if len(rewrites) > 0 {
+			root.flattenInfo = &flattenInfo{nodeAliases: map[string]*ManifestLayout{}, pathRewrites: rewrites}
+			rewriteFluxPaths(root, rewrites)
+		}
+	}
+	mergeRepoFiles(root, members)
+	return root, nil
+}
+
+// clusterEntry is a layout of one cluster together with its parent.
+type clusterEntry struct {
+	layout *ManifestLayout
+	parent *ManifestLayout
+}
+
+// dedupeClusters moves the layouts shared by every cluster below shared and
+// returns the moved layouts together with the path rewrites, from each
+// cluster's copy to the shared one, for the Flux Kustomizations.
+func dedupeClusters(clusters []*ManifestLayout, shared string) ([]*ManifestLayout, map[string]string, error) {
+	// Index every directory layout of every cluster by its path relative
+	// to the cluster directory.
+	indexes := make([]map[string]clusterEntry, len(clusters))
+	for i, c := range clusters {
+		indexes[i] = map[string]clusterEntry{}
+		indexClusterLayouts(c, nil, c.FullRepoPath(), indexes[i])
+	}
+
+	var rels []string
+	for rel := range indexes[0] {
+		rels = append(rels, rel)
+	}
+	// Parents sort before their children, so the outermost shared layout
+	// is found first.
+	sort.Strings(rels)
+
+	var moved []*ManifestLayout
+	var movedRels []string
+	rewrites := map[string]string{}
+	for _, rel := range rels {
+		if under(rel, movedRels) {
+			continue
+		}
+		entries := make([]clusterEntry, len(clusters))
+		same := true
+		var want map[string][]byte
+		for i := range clusters {
+			e, ok := indexes[i][rel]
+			if !ok {
+				same = false
+				break
+			}
+			files, err := renderRelative(e.layout)
+			if err != nil {
+				return nil, nil, err
+			}
+			if i == 0 {
+				want = files
+			} else if !sameFiles(want, files) {
+				same = false
+				break
+			}
+			entries[i] = e
+		}
+		if !same {
+			continue
+		}
+
+		keep := entries[0].layout
+		target := path.Join(shared, rel)
+		for i, e := range entries {
+			oldPath := e.layout.FullRepoPath()
+			rewrites[oldPath] = target
+			e.parent.Children = removeLayout(e.parent.Children, e.layout)
+			// A parent that applies the layout through its own Flux
+			// Kustomization (integrated placement) keeps listing it; the
+			// rewritten spec.path points it at the shared copy.
+			if !appliesLayout(e.parent, oldPath) {
+				ref, err := filepath.Rel(filepath.FromSlash(e.parent.FullRepoPath()), filepath.FromSlash(target))
+				if err != nil {
+					return nil, nil, errors.Wrapf(err, "reference shared layout %s", target)
+				}
+				e.parent.References = append(e.parent.References, filepath.ToSlash(ref))
+			}
+			if i == 0 {
+				rebaseLayout(keep, clusters[0].FullRepoPath(), shared)
+			}
+		}
+		moved = append(moved, keep)
+		movedRels = append(movedRels, rel)
+	}
+	return moved, rewrites, nil
+}
+
+// appliesLayout reports whether ml holds a Flux Kustomization whose
+// spec.path is the directory repoPath.
+func appliesLayout(ml *ManifestLayout, repoPath string) bool {
+	for _, obj := range ml.Resources {
+		if k, ok := obj.(*kustomizev1.Kustomization); ok && path.Clean(strings.TrimPrefix(k.Spec.Path, "./")) == repoPath {
+			return true
+		}
+	}
+	return false
+}
+
+// indexClusterLayouts records the directory layouts below ml, keyed by their
+// path relative to base. Umbrella children and single-file applications
+// are part of their parent's directory and are not indexed on their own.
+func indexClusterLayouts(ml, parent *ManifestLayout, base string, index map[string]clusterEntry) {
+	if parent != nil {
+		if ml.UmbrellaChild || ml.writtenAsFile() {
+			return
+		}
+		rel := strings.TrimPrefix(ml.FullRepoPath(), base+"/")
+		index[rel] = clusterEntry{layout: ml, parent: parent}
+	}
+	for _, child := range ml.Children {
+		indexClusterLayouts(child, ml, base, index)
+	}
+}
+
+// under reports whether rel lies below one of dirs.
+func under(rel string, dirs []string) bool {
+	for _, d := range dirs {
+		if strings.HasPrefix(rel, d+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// renderRelative renders ml with the default configuration and paths
+// relative to its own directory.
+func renderRelative(ml *ManifestLayout) (map[string][]byte, error) {
+	cfg := DefaultLayoutConfig()
+	cfg.ManifestsDir = "."
+	files, err := ml.RenderWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ml.FullRepoPath() + "/"
+	out := make(map[string][]byte, len(files))
+	for p, data := range files {
+		out[strings.TrimPrefix(p, prefix)] = data
+	}
+	return out, nil
+}
+
+// sameFiles reports whether a and b hold the same files and contents.
+func sameFiles(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for p, data := range a {
+		other, ok := b[p]
+		if !ok || !bytes.Equal(data, other) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package layout_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/stack"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+// mergeTestCluster returns root -> {infra, apps}. infra is identical for
+// every cluster; apps carries a cluster-specific ConfigMap.
+func mergeTestCluster(t *testing.T, name string) *layout.ManifestLayout {
+	t.Helper()
+	node := func(nodeName, cmName string) *stack.Node {
+		app := stack.NewApplication(nodeName, "default", &fakeConfig{objs: []*client.Object{makeCM(cmName)}})
+		return &stack.Node{Name: nodeName, Bundle: &stack.Bundle{Name: nodeName + "-bundle", Applications: []*stack.Application{app}}}
+	}
+	root, infra, apps := node("root", "root"), node("infra", "infra"), node("apps", "apps-"+name)
+	root.Children = []*stack.Node{infra, apps}
+	infra.SetParent(root)
+	apps.SetParent(root)
+
+	ml, err := layout.WalkCluster(&stack.Cluster{Name: name, Node: root}, layout.LayoutRules{ClusterName: name})
+	if err != nil {
+		t.Fatalf("WalkCluster %s: %v", name, err)
+	}
+	return ml
+}
+
+func findLayout(ml *layout.ManifestLayout, repoPath string) *layout.ManifestLayout {
+	if ml.FullRepoPath() == repoPath {
+		return ml
+	}
+	for _, child := range ml.Children {
+		if found := findLayout(child, repoPath); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestMergeClusters_SharesIdenticalLayouts(t *testing.T) {
+	merged, err := layout.MergeClusters([]*layout.ManifestLayout{
+		mergeTestCluster(t, "prod"),
+		mergeTestCluster(t, "staging"),
+	}, layout.LayoutRules{})
+	if err != nil {
+		t.Fatalf("MergeClusters: %v", err)
+	}
+
+	if findLayout(merged, "infrastructure/root/infra") == nil {
+		t.Fatal("expected infra to move to the shared directory")
+	}
+	for _, cluster := range []string{"prod", "staging"} {
+		if findLayout(merged, cluster+"/root/infra") != nil {
+			t.Errorf("expected %s/root/infra to be removed", cluster)
+		}
+		if findLayout(merged, cluster+"/root/apps") == nil {
+			t.Errorf("expected %s/root/apps to stay in the cluster", cluster)
+		}
+		parent := findLayout(merged, cluster+"/root")
+		if parent == nil {
+			t.Fatalf("missing %s/root", cluster)
+		}
+		if len(parent.References) != 1 || parent.References[0] != "../../infrastructure/root/infra" {
+			t.Errorf("%s/root: unexpected references %v", cluster, parent.References)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), merged); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+	kust, err := os.ReadFile(filepath.Join(dir, "clusters", "prod", "root", "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization.yaml: %v", err)
+	}
+	if !strings.Contains(string(kust), "  - ../../infrastructure/root/infra\n") {
+		t.Errorf("expected reference to the shared layout, got:\n%s", kust)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "clusters", "infrastructure", "root", "infra", "kustomization.yaml")); err != nil {
+		t.Errorf("expected shared layout to be written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "clusters", "prod", "root", "infra")); !os.IsNotExist(err) {
+		t.Errorf("expected no per-cluster copy of the shared layout, got %v", err)
+	}
+}
+
+// TestMergeClusters_RewritesFluxPaths verifies that Flux Kustomizations
+// placed in a separate flux-system directory follow a layout moved to the
+// shared directory.
+func TestMergeClusters_RewritesFluxPaths(t *testing.T) {
+	kustomization := func(name, repoPath string) client.Object {
+		return &kustomizev1.Kustomization{
+			TypeMeta:   metav1.TypeMeta{APIVersion: kustomizev1.GroupVersion.String(), Kind: kustomizev1.KustomizationKind},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			Spec:       kustomizev1.KustomizationSpec{Path: repoPath},
+		}
+	}
+	var clusters []*layout.ManifestLayout
+	for _, name := range []string{"prod", "staging"} {
+		ml := mergeTestCluster(t, name)
+		ml.Children = append(ml.Children, &layout.ManifestLayout{
+			Name:      "flux-system",
+			Namespace: filepath.Join(ml.FullRepoPath(), "flux-system"),
+			Resources: []client.Object{
+				kustomization("infra", name+"/root/infra"),
+				kustomization("apps", name+"/root/apps"),
+			},
+		})
+		clusters = append(clusters, ml)
+	}
+
+	merged, err := layout.MergeClusters(clusters, layout.LayoutRules{})
+	if err != nil {
+		t.Fatalf("MergeClusters: %v", err)
+	}
+	check := func() {
+		t.Helper()
+		for _, cluster := range []string{"prod", "staging"} {
+			flux := findLayout(merged, cluster+"/flux-system")
+			if flux == nil {
+				t.Fatalf("missing %s/flux-system", cluster)
+			}
+			paths := map[string]string{}
+			for _, obj := range flux.Resources {
+				k := obj.(*kustomizev1.Kustomization)
+				paths[k.Name] = k.Spec.Path
+			}
+			if paths["infra"] != "infrastructure/root/infra" {
+				t.Errorf("%s: Kustomization infra spec.path = %q, want %q", cluster, paths["infra"], "infrastructure/root/infra")
+			}
+			if want := cluster + "/root/apps"; paths["apps"] != want {
+				t.Errorf("%s: Kustomization apps spec.path = %q, want %q", cluster, paths["apps"], want)
+			}
+			parent := findLayout(merged, cluster+"/root")
+			if len(parent.References) != 1 || parent.References[0] != "../../infrastructure/root/infra" {
+				t.Errorf("%s/root: unexpected references %v", cluster, parent.References)
+			}
+		}
+	}
+	check()
+
+	// The rewrites are recorded and can be applied again without effect.
+	layout.ApplyFlattenPathRewrites(merged)
+	check()
+	if err := layout.ValidateFluxPaths(merged); err != nil {
+		t.Errorf("ValidateFluxPaths: %v", err)
+	}
+}
+
+func TestMergeClusters_SingleClusterUnchanged(t *testing.T) {
+	prod := mergeTestCluster(t, "prod")
+	merged, err := layout.MergeClusters([]*layout.ManifestLayout{prod}, layout.LayoutRules{})
+	if err != nil {
+		t.Fatalf("MergeClusters: %v", err)
+	}
+	if len(merged.Children) != 1 || merged.Children[0] != prod {
+		t.Errorf("expected the cluster layout as the only child, got %d children", len(merged.Children))
+	}
+	if findLayout(merged, "prod/root/infra") == nil {
+		t.Error("expected nothing to be shared with a single cluster")
+	}
+}
+
+func TestMergeClusters_InvalidClusters(t *testing.T) {
+	tests := map[string]struct {
+		clusters []*layout.ManifestLayout
+		rules    layout.LayoutRules
+	}{
+		"duplicate cluster": {
+			clusters: []*layout.ManifestLayout{mergeTestCluster(t, "prod"), mergeTestCluster(t, "prod")},
+		},
+		"shared directory conflict": {
+			clusters: []*layout.ManifestLayout{mergeTestCluster(t, "prod"), mergeTestCluster(t, "shared")},
+			rules:    layout.LayoutRules{SharedDirectory: "shared"},
+		},
+		"missing cluster name": {
+			clusters: []*layout.ManifestLayout{{Name: "root"}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := layout.MergeClusters(tt.clusters, tt.rules); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package layout
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// PackageArtifactIndexFileName is the file WritePackageArtifacts writes into
+// the base path to describe the package directories.
+const PackageArtifactIndexFileName = "packages.yaml"
+
+// PackageArtifact describes one package directory written by
+// WritePackageArtifacts. The directory is self-contained: it has a root
+// kustomization.yaml and can be pushed as a Flux OCI artifact and applied
+// with a Kustomization whose spec.path is "./".
+type PackageArtifact struct {
+	// Package is the package key returned by WalkClusterByPackage.
+	Package string `json:"package"`
+	// APIVersion and Kind identify the source type of the package's
+	// PackageRef. Both are empty for the default package.
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	// Path is the package directory relative to the base path.
+	Path string `json:"path"`
+	// Root is the directory of the package layout relative to Path, as
+	// referenced from the root kustomization.yaml.
+	Root string `json:"root"`
+}
+
+// PackageArtifactIndex maps package directories to packages. It is written
+// as PackageArtifactIndexFileName by WritePackageArtifacts.
+type PackageArtifactIndex struct {
+	Packages []PackageArtifact `json:"packages"`
+}
+
+// WritePackageArtifacts writes packages like WritePackagesToDisk and turns
+// every package directory into a self-contained artifact: it adds a root
+// kustomization.yaml referencing the package layout and records the
+// path-to-package mapping in a PackageArtifactIndex written to
+// basePath/packages.yaml. Packages are listed in path order. It fails when
+// two packages map to the same directory.
+func WritePackageArtifacts(packages map[string]*ManifestLayout, basePath string) (*PackageArtifactIndex, error) {
+	index := &PackageArtifactIndex{Packages: []PackageArtifact{}}
+	owners := map[string]string{}
+	for packageKey, ml := range packages {
+		if ml == nil {
+			continue
+		}
+		dir := sanitizePackageKey(packageKey)
+		if other, dup := owners[dir]; dup {
+			return nil, errors.ResourceValidationError("PackageArtifact", dir, "path",
+				fmt.Sprintf("packages %q and %q map to the same directory", other, packageKey), nil)
+		}
+		owners[dir] = packageKey
+
+		artifact := PackageArtifact{Package: packageKey, Path: dir, Root: ml.FullRepoPath()}
+		if ml.PackageRef != nil {
+			artifact.APIVersion = ml.PackageRef.GroupVersion().String()
+			artifact.Kind = ml.PackageRef.Kind
+		}
+		index.Packages = append(index.Packages, artifact)
+	}
+	sort.Slice(index.Packages, func(i, j int) bool { return index.Packages[i].Path < index.Packages[j].Path })
+
+	if err := WritePackagesToDisk(packages, basePath); err != nil {
+		return nil, err
+	}
+
+	for _, artifact := range index.Packages {
+		// A layout rooted at the package directory already wrote its own
+		// kustomization.yaml there.
+		if artifact.Root == "." || artifact.Root == "" {
+			continue
+		}
+		content := fmt.Sprintf("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n  - %s\n", artifact.Root)
+		kustomPath := filepath.Join(basePath, artifact.Path, "kustomization.yaml")
+		if err := os.WriteFile(kustomPath, []byte(content), 0644); err != nil {
+			return nil, errors.NewFileError("write", kustomPath, "package kustomization write failed", err)
+		}
+	}
+
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshal package artifact index")
+	}
+	indexPath := filepath.Join(basePath, PackageArtifactIndexFileName)
+	if err := os.WriteFile(indexPath, data, 0644); err != nil {
+		return nil, errors.NewFileError("write", indexPath, "package index write failed", err)
+	}
+	return index, nil
+}
@@ -0,0 +1,97 @@
+package layout_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+func packageTestLayout(name string, ref *schema.GroupVersionKind) *layout.ManifestLayout {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	return &layout.ManifestLayout{
+		Name:       name,
+		Namespace:  "cluster",
+		PackageRef: ref,
+		Resources:  []client.Object{obj},
+	}
+}
+
+func TestWritePackageArtifacts(t *testing.T) {
+	ociRef := &schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "OCIRepository"}
+	packages := map[string]*layout.ManifestLayout{
+		"default":        packageTestLayout("web", nil),
+		ociRef.String():  packageTestLayout("platform", ociRef),
+		"nil-is-skipped": nil,
+	}
+
+	dir := t.TempDir()
+	index, err := layout.WritePackageArtifacts(packages, dir)
+	if err != nil {
+		t.Fatalf("WritePackageArtifacts: %v", err)
+	}
+
+	want := []layout.PackageArtifact{
+		{Package: "default", Path: "default", Root: "cluster/web"},
+		{Package: ociRef.String(), APIVersion: "source.toolkit.fluxcd.io/v1", Kind: "OCIRepository", Path: "oci-packages", Root: "cluster/platform"},
+	}
+	if len(index.Packages) != len(want) {
+		t.Fatalf("expected %d packages, got %+v", len(want), index.Packages)
+	}
+	for i, w := range want {
+		if index.Packages[i] != w {
+			t.Errorf("package %d: expected %+v, got %+v", i, w, index.Packages[i])
+		}
+	}
+
+	kust, err := os.ReadFile(filepath.Join(dir, "oci-packages", "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read package root kustomization.yaml: %v", err)
+	}
+	if !strings.Contains(string(kust), "  - cluster/platform\n") {
+		t.Errorf("expected root kustomization to reference the package layout, got:\n%s", kust)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "oci-packages", "cluster", "platform", "default-configmap-platform.yaml")); err != nil {
+		t.Errorf("expected package layout to be written: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, layout.PackageArtifactIndexFileName))
+	if err != nil {
+		t.Fatalf("read %s: %v", layout.PackageArtifactIndexFileName, err)
+	}
+	var written layout.PackageArtifactIndex
+	if err := yaml.Unmarshal(data, &written); err != nil {
+		t.Fatalf("unmarshal index: %v", err)
+	}
+	if len(written.Packages) != 2 || written.Packages[1].Path != "oci-packages" {
+		t.Errorf("unexpected written index %+v", written)
+	}
+}
+
+func TestWritePackageArtifacts_DirectoryConflict(t *testing.T) {
+	v1 := &schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "OCIRepository"}
+	v1beta2 := &schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1beta2", Kind: "OCIRepository"}
+	packages := map[string]*layout.ManifestLayout{
+		v1.String():      packageTestLayout("a", v1),
+		v1beta2.String(): packageTestLayout("b", v1beta2),
+	}
+
+	dir := t.TempDir()
+	if _, err := layout.WritePackageArtifacts(packages, dir); err == nil {
+		t.Fatal("expected error for packages sharing a directory")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing to be written, got %d entries", len(entries))
+	}
+}
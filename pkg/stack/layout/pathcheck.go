@@ -0,0 +1,95 @@
+package layout
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// maxPathSegmentLength is the longest file or directory name most
+// filesystems accept.
+const maxPathSegmentLength = 255
+
+// windowsReservedNames are device names Windows refuses as file or
+// directory names, with or without an extension.
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// validateLayoutPaths checks the names of the layout tree before anything is
+// written. Every layout name must be a single path segment that is valid on
+// Linux, macOS and Windows checkouts and in a Flux spec.path: no separators,
+// "." or "..", characters or device names reserved on Windows, trailing
+// dots or spaces, or names longer than maxPathSegmentLength. Directories
+// whose paths differ only in case are rejected because they collide on
+// case-insensitive filesystems. The checks are lexical; symlinks are never
+// resolved.
+func validateLayoutPaths(root *ManifestLayout) error {
+	if root == nil {
+		return nil
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(root.Namespace), "/") {
+		if segment == "" || segment == "." {
+			continue
+		}
+		if err := validatePathSegment(segment); err != nil {
+			return errors.ResourceValidationError("ManifestLayout", root.FullRepoPath(), "namespace", err.Error(), nil)
+		}
+	}
+	return validateLayoutNames(root, map[string]string{})
+}
+
+func validateLayoutNames(ml *ManifestLayout, dirs map[string]string) error {
+	if ml.Name != "" {
+		if err := validatePathSegment(ml.Name); err != nil {
+			return errors.ResourceValidationError("ManifestLayout", ml.FullRepoPath(), "name", err.Error(), nil)
+		}
+	}
+	if !ml.writtenAsFile() {
+		p := ml.FullRepoPath()
+		folded := strings.ToLower(p)
+		if other, ok := dirs[folded]; ok && other != p {
+			return errors.ResourceValidationError("ManifestLayout", p, "name",
+				fmt.Sprintf("collides with %q on case-insensitive filesystems", other), nil)
+		}
+		dirs[folded] = p
+	}
+	for _, child := range ml.Children {
+		if child == nil {
+			continue
+		}
+		if err := validateLayoutNames(child, dirs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePathSegment reports why name cannot be used as a single file or
+// directory name.
+func validatePathSegment(name string) error {
+	switch {
+	case name == "." || name == "..":
+		return errors.Errorf("%q is not a valid directory name", name)
+	case len(name) > maxPathSegmentLength:
+		return errors.Errorf("%q is longer than %d bytes", name, maxPathSegmentLength)
+	case strings.ContainsAny(name, `/\<>:"|?*`):
+		return errors.Errorf("%q contains a path separator or a character reserved on Windows", name)
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, " "):
+		return errors.Errorf("%q ends with a dot or space, which Windows strips", name)
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return errors.Errorf("%q contains a control character", name)
+		}
+	}
+	base, _, _ := strings.Cut(name, ".")
+	if _, reserved := windowsReservedNames[strings.ToUpper(base)]; reserved {
+		return errors.Errorf("%q is a device name reserved on Windows", name)
+	}
+	return nil
+}
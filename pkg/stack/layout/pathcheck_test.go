@@ -0,0 +1,76 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+func TestValidatePathSegment(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"apps", false},
+		{"cert-manager.v1", false},
+		{"Frontend_2", false},
+		{".", true},
+		{"..", true},
+		{"a/b", true},
+		{`a\b`, true},
+		{"c:", true},
+		{"what?", true},
+		{"trailing.", true},
+		{"trailing ", true},
+		{"tab\tname", true},
+		{"con", true},
+		{"NUL.yaml", true},
+		{"com1", true},
+		{"console", false},
+		{strings.Repeat("a", maxPathSegmentLength), false},
+		{strings.Repeat("a", maxPathSegmentLength+1), true},
+	}
+	for _, tt := range tests {
+		err := validatePathSegment(tt.name)
+		if tt.wantErr && err == nil {
+			t.Errorf("%q: expected error", tt.name)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.name, err)
+		}
+	}
+}
+
+func TestValidateLayoutPaths_CaseCollision(t *testing.T) {
+	ml := &ManifestLayout{Name: "root", Namespace: "demo", Children: []*ManifestLayout{
+		{Name: "Apps", Namespace: "demo/root"},
+		{Name: "apps", Namespace: "demo/root"},
+	}}
+	err := validateLayoutPaths(ml)
+	if err == nil || !strings.Contains(err.Error(), "case-insensitive") {
+		t.Fatalf("expected case collision error, got %v", err)
+	}
+}
+
+func TestValidateLayoutPaths_InvalidNamespace(t *testing.T) {
+	ml := &ManifestLayout{Name: "root", Namespace: "prod:eu"}
+	if err := validateLayoutPaths(ml); err == nil {
+		t.Fatal("expected error for invalid cluster directory")
+	}
+}
+
+func TestWalkCluster_RejectsReservedNodeName(t *testing.T) {
+	obj := testObject("v1", "ConfigMap", "cm", "default")
+	app := stack.NewApplication("app", "default", &flattenFakeConfig{objs: []*client.Object{&obj}})
+	aux := &stack.Node{Name: "aux", Bundle: &stack.Bundle{Name: "aux", Applications: []*stack.Application{app}}}
+	root := &stack.Node{Name: "root", Children: []*stack.Node{aux}}
+	aux.SetParent(root)
+
+	_, err := WalkCluster(&stack.Cluster{Name: "demo", Node: root}, LayoutRules{})
+	if err == nil || !strings.Contains(err.Error(), "reserved on Windows") {
+		t.Fatalf("expected reserved name error, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package layout
+
+import (
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// PostProcessor is a custom step that runs on the complete ManifestLayout
+// tree before it is written. Implementations may modify the layout in place,
+// for example to stamp file headers onto ExtraFiles, add index files or
+// attach compliance manifests.
+type PostProcessor interface {
+	PostProcess(ml *ManifestLayout) error
+}
+
+// PostProcessorFunc adapts an ordinary function to the PostProcessor
+// interface.
+type PostProcessorFunc func(ml *ManifestLayout) error
+
+// PostProcess calls f(ml).
+func (f PostProcessorFunc) PostProcess(ml *ManifestLayout) error {
+	return f(ml)
+}
+
+// AddPostProcessor registers p to run before WriteManifest writes a layout.
+// Post-processors run in registration order.
+func (c *Config) AddPostProcessor(p PostProcessor) {
+	c.PostProcessors = append(c.PostProcessors, p)
+}
+
+// RunPostProcessors applies each processor to ml in order and stops at the
+// first error. Nil processors are skipped. WriteManifest calls it with
+// Config.PostProcessors; callers of WriteToDisk or WriteToTar can call it
+// directly before writing.
+func RunPostProcessors(ml *ManifestLayout, processors ...PostProcessor) error {
+	if ml == nil {
+		return nil
+	}
+	for i, p := range processors {
+		if p == nil {
+			continue
+		}
+		if err := p.PostProcess(ml); err != nil {
+			return errors.Wrapf(err, "layout post-processor %d", i)
+		}
+	}
+	return nil
+}
+
+// processedCopy returns ml with processors applied without modifying ml:
+// the processors run on a copy of the layout tree. Without processors ml
+// itself is returned.
+func processedCopy(ml *ManifestLayout, processors []PostProcessor) (*ManifestLayout, error) {
+	if ml == nil || len(processors) == 0 {
+		return ml, nil
+	}
+	c := ml.clone()
+	if err := RunPostProcessors(c, processors...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
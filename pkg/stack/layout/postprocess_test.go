@@ -0,0 +1,74 @@
+package layout
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWriteManifest_PostProcessors(t *testing.T) {
+	child := &ManifestLayout{
+		Name:      "app",
+		Namespace: "cluster/apps",
+		Resources: []client.Object{testObject("v1", "ConfigMap", "cfg", "default")},
+	}
+	ml := &ManifestLayout{
+		Name:      "apps",
+		Namespace: "cluster",
+		Children:  []*ManifestLayout{child},
+	}
+
+	var calls []string
+	cfg := DefaultLayoutConfig()
+	cfg.AddPostProcessor(PostProcessorFunc(func(l *ManifestLayout) error {
+		calls = append(calls, "first:"+l.Name)
+		for _, c := range l.Children {
+			c.ExtraFiles = append(c.ExtraFiles, ExtraFile{Name: "NOTICE", Content: []byte("generated\n")})
+		}
+		return nil
+	}))
+	cfg.AddPostProcessor(PostProcessorFunc(func(l *ManifestLayout) error {
+		calls = append(calls, "second:"+l.Name)
+		return nil
+	}))
+
+	dir := t.TempDir()
+	if err := WriteManifest(dir, cfg, ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "first:apps" || calls[1] != "second:apps" {
+		t.Errorf("expected each post-processor to run once on the root in order, got %v", calls)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "clusters", "cluster", "apps", "app", "NOTICE"))
+	if err != nil {
+		t.Fatalf("expected file added by post-processor: %v", err)
+	}
+	if string(data) != "generated\n" {
+		t.Errorf("unexpected NOTICE content %q", data)
+	}
+}
+
+func TestWriteManifest_PostProcessorError(t *testing.T) {
+	ml := &ManifestLayout{
+		Name:      "app",
+		Namespace: "cluster",
+		Resources: []client.Object{testObject("v1", "ConfigMap", "cfg", "default")},
+	}
+	boom := errors.New("boom")
+	cfg := DefaultLayoutConfig()
+	cfg.AddPostProcessor(nil)
+	cfg.AddPostProcessor(PostProcessorFunc(func(*ManifestLayout) error { return boom }))
+
+	dir := t.TempDir()
+	err := WriteManifest(dir, cfg, ml)
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected post-processor error, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "clusters")); !os.IsNotExist(statErr) {
+		t.Error("expected nothing to be written when a post-processor fails")
+	}
+}
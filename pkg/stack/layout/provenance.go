@@ -0,0 +1,149 @@
+package layout
+
+import (
+	"fmt"
+	"path"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+// ProvenanceMarker is the first line of the header WriteManifest adds to
+// generated files when Config.Provenance is set. It follows the Go
+// convention for generated code so that linters and review tools recognise
+// the file as generated.
+const ProvenanceMarker = "# Code generated by kure. DO NOT EDIT."
+
+const kureModulePath = "github.com/go-kure/kure"
+
+var (
+	kureVersionOnce sync.Once
+	kureVersionStr  string
+)
+
+// kureVersion returns the version of the kure module linked into the
+// running binary, or "(devel)" when it cannot be determined.
+func kureVersion() string {
+	kureVersionOnce.Do(func() {
+		kureVersionStr = "(devel)"
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		if info.Main.Path == kureModulePath && info.Main.Version != "" {
+			kureVersionStr = info.Main.Version
+			return
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == kureModulePath && dep.Version != "" {
+				kureVersionStr = dep.Version
+				return
+			}
+		}
+	})
+	return kureVersionStr
+}
+
+// provenance maps the objects of a layout tree to the index entries of the
+// applications that produced them.
+type provenance map[stack.ObjectRef]*stack.ApplicationIndex
+
+// collectProvenance gathers the Index of ml and all of its descendants.
+// Indexes usually live on bundle layouts while the resources are written
+// from application layouts below them, so the whole tree is collected once
+// before writing.
+func collectProvenance(ml *ManifestLayout) provenance {
+	p := provenance{}
+	var walk func(*ManifestLayout)
+	walk = func(l *ManifestLayout) {
+		if l == nil {
+			return
+		}
+		if l.Index != nil {
+			for i := range l.Index.Applications {
+				entry := &l.Index.Applications[i]
+				for _, ref := range entry.Objects {
+					p[ref] = entry
+				}
+			}
+		}
+		for _, child := range l.Children {
+			walk(child)
+		}
+	}
+	walk(ml)
+	return p
+}
+
+// header returns the provenance comment block for a file holding objs. Each
+// application that produced one of the objects is listed once, in order of
+// first appearance, with its generator and config hash. Objects without an
+// index entry (for example Flux resources added by the integrator) only get
+// the generated-file marker and the kure version.
+func (p provenance) header(objs []client.Object) []byte {
+	var b strings.Builder
+	b.WriteString(ProvenanceMarker + "\n")
+	fmt.Fprintf(&b, "# kure-version: %s\n", kureVersion())
+
+	seen := map[*stack.ApplicationIndex]struct{}{}
+	for _, obj := range objs {
+		entry, ok := p[stack.NewObjectRef(obj)]
+		if !ok {
+			continue
+		}
+		if _, dup := seen[entry]; dup {
+			continue
+		}
+		seen[entry] = struct{}{}
+		writeApplicationLines(&b, entry)
+	}
+	return []byte(b.String())
+}
+
+// extraFileHeader returns the provenance comment block for an ExtraFile
+// produced by the application with the given name and namespace. The
+// application lines are included when the application has an index entry.
+func (p provenance) extraFileHeader(owner, namespace string) []byte {
+	var b strings.Builder
+	b.WriteString(ProvenanceMarker + "\n")
+	fmt.Fprintf(&b, "# kure-version: %s\n", kureVersion())
+	if owner == "" {
+		return []byte(b.String())
+	}
+	for _, entry := range p {
+		if entry.Name == owner && entry.Namespace == namespace {
+			writeApplicationLines(&b, entry)
+			break
+		}
+	}
+	return []byte(b.String())
+}
+
+// writeApplicationLines writes the header lines describing entry.
+func writeApplicationLines(b *strings.Builder, entry *stack.ApplicationIndex) {
+	fmt.Fprintf(b, "# application: %s\n", entry.Name)
+	if entry.Generator != "" {
+		fmt.Fprintf(b, "#   generator: %s\n", entry.Generator)
+	}
+	if entry.ConfigHash != "" {
+		fmt.Fprintf(b, "#   config-hash: %s\n", entry.ConfigHash)
+	}
+}
+
+// withProvenanceHeaders returns files with the provenance header prepended
+// to every YAML file. Other files are returned unchanged because their
+// comment syntax is unknown.
+func (p provenance) withProvenanceHeaders(files []ExtraFile) []ExtraFile {
+	out := make([]ExtraFile, len(files))
+	for i, ef := range files {
+		if ext := path.Ext(ef.Name); ext == ".yaml" || ext == ".yml" {
+			ef.Content = append(p.extraFileHeader(ef.Owner, ef.OwnerNamespace), ef.Content...)
+		}
+		out[i] = ef
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+func TestExtraFileHeader_MatchesNamespace(t *testing.T) {
+	staging := &stack.ApplicationIndex{Name: "web", Namespace: "staging", ConfigHash: "staging-hash"}
+	prod := &stack.ApplicationIndex{Name: "web", Namespace: "prod", ConfigHash: "prod-hash"}
+	p := provenance{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "staging", Name: "web"}: staging,
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "prod", Name: "web"}:    prod,
+	}
+
+	for _, entry := range []*stack.ApplicationIndex{staging, prod} {
+		header := string(p.extraFileHeader("web", entry.Namespace))
+		if !strings.Contains(header, "#   config-hash: "+entry.ConfigHash+"\n") {
+			t.Errorf("expected the %s entry in the header, got:\n%s", entry.Namespace, header)
+		}
+	}
+
+	header := string(p.extraFileHeader("web", "dev"))
+	if strings.Contains(header, "# application:") {
+		t.Errorf("expected no application lines for an unknown namespace, got:\n%s", header)
+	}
+}
@@ -0,0 +1,115 @@
+package layout_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/stack"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+// versionedAugmentingConfig is a fakeAugmentingConfig that reports a
+// generator GVK.
+type versionedAugmentingConfig struct {
+	fakeAugmentingConfig
+}
+
+func (*versionedAugmentingConfig) GetAPIVersion() string { return "generators.gokure.dev/v1alpha1" }
+
+func (*versionedAugmentingConfig) GetKind() string { return "Fake" }
+
+func TestWriteManifest_Provenance(t *testing.T) {
+	var obj client.Object = freezeTestObject("web")
+	app := stack.NewApplication("web", "default", &versionedAugmentingConfig{
+		fakeAugmentingConfig: fakeAugmentingConfig{objs: []*client.Object{&obj}},
+	})
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{app}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	rules := layout.LayoutRules{
+		BundleGrouping:      layout.GroupByName,
+		ApplicationGrouping: layout.GroupByName,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+
+	cfg := layout.DefaultLayoutConfig()
+	cfg.Provenance = true
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, cfg, ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	appDir := filepath.Join(dir, "clusters", "root", "apps", "web")
+	data, err := os.ReadFile(filepath.Join(appDir, "default-configmap-web.yaml"))
+	if err != nil {
+		t.Fatalf("read resource file: %v", err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, layout.ProvenanceMarker+"\n") {
+		t.Errorf("expected resource file to start with the provenance marker, got:\n%s", content)
+	}
+	for _, want := range []string{
+		"# kure-version: ",
+		"# application: web\n",
+		"#   generator: generators.gokure.dev/v1alpha1, Kind=Fake\n",
+		"#   config-hash: " + app.ConfigHash() + "\n",
+		"kind: ConfigMap",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected resource file to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	values, err := os.ReadFile(filepath.Join(appDir, "values.yaml"))
+	if err != nil {
+		t.Fatalf("read extra file: %v", err)
+	}
+	if !strings.HasPrefix(string(values), layout.ProvenanceMarker+"\n") ||
+		!strings.Contains(string(values), "# application: web\n") ||
+		!strings.HasSuffix(string(values), "k: v\n") {
+		t.Errorf("expected the extra file to carry the provenance header, got:\n%s", values)
+	}
+
+	kust, err := os.ReadFile(filepath.Join(appDir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization.yaml: %v", err)
+	}
+	if !strings.HasPrefix(string(kust), layout.ProvenanceMarker+"\n") {
+		t.Errorf("expected kustomization.yaml to start with the provenance marker, got:\n%s", kust)
+	}
+	if strings.Contains(string(kust), "# application:") {
+		t.Errorf("expected no application lines in kustomization.yaml, got:\n%s", kust)
+	}
+}
+
+func TestWriteManifest_NoProvenanceByDefault(t *testing.T) {
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{freezeTestApp("web", false)}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	ml, err := layout.WalkCluster(cluster, layout.LayoutRules{
+		ClusterName:         "root",
+		BundleGrouping:      layout.GroupFlat,
+		ApplicationGrouping: layout.GroupFlat,
+	})
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "clusters", "root", "default-configmap-web.yaml"))
+	if err != nil {
+		t.Fatalf("read resource file: %v", err)
+	}
+	if strings.Contains(string(data), "#") {
+		t.Errorf("expected no comments without Config.Provenance, got:\n%s", data)
+	}
+}
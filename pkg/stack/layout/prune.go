@@ -0,0 +1,196 @@
+package layout
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-kure/kure/pkg/errors"
+)
+
+// PruneOptions configures ReconcileManifest.
+type PruneOptions struct {
+	// Protected lists path.Match patterns, relative to the base path and
+	// slash-separated (e.g. "clusters/*/secrets"), for files and
+	// directories that are never deleted. Protecting a directory protects
+	// everything below it.
+	Protected []string
+	// DryRun reports what would be deleted without writing or deleting
+	// anything.
+	DryRun bool
+}
+
+// PruneReport lists the stale paths found by ReconcileManifest, relative to
+// the base path and slash-separated. In dry-run mode nothing was deleted.
+type PruneReport struct {
+	// Files are the stale files.
+	Files []string
+	// Dirs are the directories left empty by removing stale files.
+	Dirs []string
+}
+
+// recordSink collects the paths WriteManifest would produce. layoutDirs
+// holds the directories of the layouts themselves, dirs also their parents.
+type recordSink struct {
+	files      map[string]struct{}
+	dirs       map[string]struct{}
+	layoutDirs []string
+}
+
+func (r *recordSink) writeDir(dirPath string) error {
+	r.layoutDirs = append(r.layoutDirs, dirPath)
+	for d := dirPath; d != "." && d != "/" && d != ""; d = path.Dir(d) {
+		r.dirs[d] = struct{}{}
+	}
+	return nil
+}
+
+func (r *recordSink) writeFile(filePath string, _ []byte) error {
+	r.files[filePath] = struct{}{}
+	return nil
+}
+
+func (r *recordSink) keepFile(filePath string) bool {
+	r.files[filePath] = struct{}{}
+	return true
+}
+
+// ReconcileManifest writes ml like WriteManifest and then deletes the files
+// that the layout no longer produces, together with directories left empty.
+// Only the directories the layout owns are pruned: the directory of ml
+// (below basePath/cfg.ManifestsDir) and those of descendants written
+// outside it, so the directories of other clusters or tools next to it are
+// left alone. Files kept because they are frozen count as produced. Paths
+// matching opts.Protected and .git directories are never touched. The
+// returned report lists the stale paths; with opts.DryRun the layout is
+// neither written nor pruned.
+//
+// cfg.PostProcessors run on a copy of ml, as with Render, so ml itself is
+// never modified.
+func ReconcileManifest(basePath string, cfg Config, ml *ManifestLayout, opts PruneOptions) (*PruneReport, error) {
+	for _, pattern := range opts.Protected {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid protected pattern %q", pattern)
+		}
+	}
+	ml, err := processedCopy(ml, cfg.PostProcessors)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ManifestsDir == "" {
+		cfg.ManifestsDir = "clusters"
+	}
+	cfg = cfg.withProvenance(ml)
+
+	expected := &recordSink{files: map[string]struct{}{}, dirs: map[string]struct{}{}}
+	if err := writeManifestTo(expected, cfg, ml); err != nil {
+		return nil, err
+	}
+	if !opts.DryRun {
+		if err := writeManifest(basePath, cfg, ml); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &PruneReport{}
+	for _, root := range ownedDirs(expected.layoutDirs) {
+		if _, err := os.Stat(filepath.Join(basePath, filepath.FromSlash(root))); os.IsNotExist(err) {
+			continue
+		}
+		if _, err := pruneDir(basePath, root, expected, opts, report); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(report.Files)
+	sort.Strings(report.Dirs)
+	return report, nil
+}
+
+// ownedDirs returns the directories of dirs that are not below another one,
+// sorted. They are the roots below which ReconcileManifest prunes.
+func ownedDirs(dirs []string) []string {
+	cleaned := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		cleaned = append(cleaned, path.Clean(d))
+	}
+	sort.Strings(cleaned)
+	var roots []string
+	for _, d := range cleaned {
+		if !below(d, roots) {
+			roots = append(roots, d)
+		}
+	}
+	return roots
+}
+
+// below reports whether dir equals or lies below one of roots.
+func below(dir string, roots []string) bool {
+	for _, r := range roots {
+		if dir == r || r == "." || strings.HasPrefix(dir, r+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneDir prunes the stale entries of dir, relative to basePath, and
+// reports whether dir is empty afterwards (or would be, in dry-run mode).
+func pruneDir(basePath, dir string, expected *recordSink, opts PruneOptions, report *PruneReport) (bool, error) {
+	full := filepath.Join(basePath, filepath.FromSlash(dir))
+	entries, err := os.ReadDir(full)
+	if err != nil {
+		return false, errors.NewFileError("read", full, "directory read failed", err)
+	}
+
+	empty := true
+	for _, entry := range entries {
+		rel := path.Join(dir, entry.Name())
+		if entry.Name() == ".git" || isProtected(rel, opts.Protected) {
+			empty = false
+			continue
+		}
+		if entry.IsDir() {
+			childEmpty, err := pruneDir(basePath, rel, expected, opts, report)
+			if err != nil {
+				return false, err
+			}
+			if _, ok := expected.dirs[rel]; ok || !childEmpty {
+				empty = false
+				continue
+			}
+			report.Dirs = append(report.Dirs, rel)
+			if !opts.DryRun {
+				if err := os.Remove(filepath.Join(basePath, filepath.FromSlash(rel))); err != nil {
+					return false, errors.NewFileError("remove", rel, "stale directory removal failed", err)
+				}
+			}
+			continue
+		}
+		if _, ok := expected.files[rel]; ok {
+			empty = false
+			continue
+		}
+		report.Files = append(report.Files, rel)
+		if !opts.DryRun {
+			if err := os.Remove(filepath.Join(basePath, filepath.FromSlash(rel))); err != nil {
+				return false, errors.NewFileError("remove", rel, "stale file removal failed", err)
+			}
+		}
+	}
+	return empty, nil
+}
+
+// isProtected reports whether rel or one of its parent directories matches
+// one of patterns.
+func isProtected(rel string, patterns []string) bool {
+	for p := rel; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileManifest(t *testing.T) {
+	cfg := DefaultLayoutConfig()
+	dir := t.TempDir()
+
+	old := &ManifestLayout{
+		Name:      "apps",
+		Namespace: "cluster",
+		Resources: []client.Object{testObject("v1", "ConfigMap", "keep", "default")},
+		Children: []*ManifestLayout{{
+			Name:      "legacy",
+			Namespace: "cluster/apps",
+			Resources: []client.Object{testObject("v1", "ConfigMap", "gone", "default")},
+		}},
+	}
+	if err := WriteManifest(dir, cfg, old); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	appsDir := filepath.Join(dir, "clusters", "cluster", "apps")
+	if err := os.WriteFile(filepath.Join(appsDir, "README.md"), []byte("notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(appsDir, "default-configmap-stale.yaml"), []byte("stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	current := &ManifestLayout{
+		Name:      "apps",
+		Namespace: "cluster",
+		Resources: []client.Object{testObject("v1", "ConfigMap", "keep", "default")},
+	}
+	opts := PruneOptions{Protected: []string{"clusters/*/apps/README.md"}, DryRun: true}
+
+	report, err := ReconcileManifest(dir, cfg, current, opts)
+	if err != nil {
+		t.Fatalf("ReconcileManifest (dry run) failed: %v", err)
+	}
+	wantFiles := []string{
+		"clusters/cluster/apps/default-configmap-stale.yaml",
+		"clusters/cluster/apps/legacy/default-configmap-gone.yaml",
+		"clusters/cluster/apps/legacy/kustomization.yaml",
+	}
+	wantDirs := []string{"clusters/cluster/apps/legacy"}
+	if !reflect.DeepEqual(report.Files, wantFiles) || !reflect.DeepEqual(report.Dirs, wantDirs) {
+		t.Fatalf("unexpected dry-run report: files=%v dirs=%v", report.Files, report.Dirs)
+	}
+	if _, err := os.Stat(filepath.Join(appsDir, "legacy")); err != nil {
+		t.Fatalf("dry run must not delete anything: %v", err)
+	}
+
+	opts.DryRun = false
+	report, err = ReconcileManifest(dir, cfg, current, opts)
+	if err != nil {
+		t.Fatalf("ReconcileManifest failed: %v", err)
+	}
+	if !reflect.DeepEqual(report.Files, wantFiles) {
+		t.Errorf("unexpected report: %v", report.Files)
+	}
+	if _, err := os.Stat(filepath.Join(appsDir, "legacy")); !os.IsNotExist(err) {
+		t.Errorf("expected stale directory to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(appsDir, "default-configmap-stale.yaml")); !os.IsNotExist(err) {
+		t.Errorf("expected stale file to be removed, got %v", err)
+	}
+	for _, name := range []string{"README.md", "default-configmap-keep.yaml", "kustomization.yaml"} {
+		if _, err := os.Stat(filepath.Join(appsDir, name)); err != nil {
+			t.Errorf("expected %s to be kept: %v", name, err)
+		}
+	}
+}
+
+func TestReconcileManifest_InvalidPattern(t *testing.T) {
+	ml := &ManifestLayout{Name: "apps", Namespace: "cluster"}
+	_, err := ReconcileManifest(t.TempDir(), DefaultLayoutConfig(), ml, PruneOptions{Protected: []string{"["}})
+	if err == nil {
+		t.Fatal("expected error for malformed protected pattern")
+	}
+}
+
+func TestReconcileManifest_ScopedToLayout(t *testing.T) {
+	cfg := DefaultLayoutConfig()
+	dir := t.TempDir()
+
+	// Another cluster and a foreign file next to the layout's directory.
+	otherCluster := filepath.Join(dir, "clusters", "staging")
+	if err := os.MkdirAll(otherCluster, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(otherCluster, "kustomization.yaml"), []byte("resources: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "clusters", "README.md"), []byte("notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ml := &ManifestLayout{
+		Name:      "",
+		Namespace: "prod",
+		Children: []*ManifestLayout{{
+			Name:      "apps",
+			Namespace: "prod",
+			Resources: []client.Object{testObject("v1", "ConfigMap", "keep", "default")},
+		}},
+	}
+	staleDir := filepath.Join(dir, "clusters", "prod", "old")
+	if err := os.MkdirAll(staleDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(staleDir, "stale.yaml"), []byte("stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.AddPostProcessor(PostProcessorFunc(func(l *ManifestLayout) error {
+		l.ExtraFiles = append(l.ExtraFiles, ExtraFile{Name: "NOTICE", Content: []byte("generated\n")})
+		return nil
+	}))
+	report, err := ReconcileManifest(dir, cfg, ml, PruneOptions{})
+	if err != nil {
+		t.Fatalf("ReconcileManifest failed: %v", err)
+	}
+	wantFiles := []string{"clusters/prod/old/stale.yaml"}
+	wantDirs := []string{"clusters/prod/old"}
+	if !reflect.DeepEqual(report.Files, wantFiles) || !reflect.DeepEqual(report.Dirs, wantDirs) {
+		t.Fatalf("unexpected report: files=%v dirs=%v", report.Files, report.Dirs)
+	}
+	for _, p := range []string{
+		filepath.Join(otherCluster, "kustomization.yaml"),
+		filepath.Join(dir, "clusters", "README.md"),
+		filepath.Join(dir, "clusters", "prod", "NOTICE"),
+	} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("expected %s to exist: %v", p, err)
+		}
+	}
+	if len(ml.ExtraFiles) != 0 {
+		t.Errorf("expected post-processors not to modify the caller's layout, got %v", ml.ExtraFiles)
+	}
+}
@@ -0,0 +1,39 @@
+package layout
+
+// Render returns the files that WriteManifest would write for ml with
+// DefaultLayoutConfig as a map from slash-separated path, relative to the
+// base path, to file contents, without touching the filesystem. Directories
+// are implied by the paths and have no entries of their own. Use
+// RenderWithConfig to render with other settings.
+func (ml *ManifestLayout) Render() (map[string][]byte, error) {
+	return ml.RenderWithConfig(DefaultLayoutConfig())
+}
+
+// RenderWithConfig is like Render but uses cfg. It honours the same Config
+// settings as WriteManifest, including EncodeOptions, FileNamer and
+// Provenance. cfg.PostProcessors run on a copy of ml, so the caller's layout
+// is left unchanged. Frozen files are rendered like any other because there
+// are no previous contents to keep.
+func (ml *ManifestLayout) RenderWithConfig(cfg Config) (map[string][]byte, error) {
+	processed, err := processedCopy(ml, cfg.PostProcessors)
+	if err != nil {
+		return nil, err
+	}
+	files := mapSink{}
+	if err := writeManifestTo(files, cfg.withProvenance(processed), processed); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// mapSink collects written files in memory.
+type mapSink map[string][]byte
+
+func (mapSink) writeDir(string) error { return nil }
+
+func (s mapSink) writeFile(filePath string, data []byte) error {
+	s[filePath] = data
+	return nil
+}
+
+func (mapSink) keepFile(string) bool { return false }
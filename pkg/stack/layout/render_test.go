@@ -0,0 +1,138 @@
+package layout
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kio "github.com/go-kure/kure/pkg/io"
+)
+
+func renderTestLayout() *ManifestLayout {
+	child := &ManifestLayout{
+		Name:       "app",
+		Namespace:  "cluster/apps",
+		Resources:  []client.Object{testObject("v1", "ConfigMap", "cfg", "default")},
+		ExtraFiles: []ExtraFile{{Name: "values.yaml", Content: []byte("replicas: 1\n")}},
+	}
+	return &ManifestLayout{
+		Name:      "apps",
+		Namespace: "cluster",
+		Children:  []*ManifestLayout{child},
+	}
+}
+
+func TestRender_MatchesWriteManifest(t *testing.T) {
+	cfg := DefaultLayoutConfig()
+	cfg.EncodeOptions = kio.EncodeOptions{Indent: 4}
+	cfg.Provenance = true
+	cfg.FileNamer = ManifestFileNameFunc(func(_, _, name string, _ FileExportMode) string {
+		return "custom-" + name + ".yaml"
+	})
+
+	ml := renderTestLayout()
+	files, err := ml.RenderWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WriteManifest(dir, cfg, renderTestLayout()); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	want := map[string][]byte{}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		want[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read written tree: %v", err)
+	}
+
+	if len(files) != len(want) {
+		t.Fatalf("expected %d files, got %d: %v", len(want), len(files), files)
+	}
+	for name, data := range want {
+		if !bytes.Equal(files[name], data) {
+			t.Errorf("%s: rendered content differs from written file", name)
+		}
+	}
+	if got := string(files["clusters/cluster/apps/app/values.yaml"]); got != "replicas: 1\n" {
+		t.Errorf("expected extra file to be rendered, got %q", got)
+	}
+	cm := string(files["clusters/cluster/apps/app/custom-cfg.yaml"])
+	if !strings.HasPrefix(cm, ProvenanceMarker) {
+		t.Errorf("expected provenance header, got:\n%s", cm)
+	}
+	if !strings.Contains(cm, "\n    name: cfg") {
+		t.Errorf("expected EncodeOptions indentation, got:\n%s", cm)
+	}
+	if !strings.Contains(string(files["clusters/cluster/apps/kustomization.yaml"]), "- app") {
+		t.Errorf("expected parent kustomization to reference app, got:\n%s", files["clusters/cluster/apps/kustomization.yaml"])
+	}
+}
+
+func TestRender_PostProcessorsDoNotModifyLayout(t *testing.T) {
+	cfg := DefaultLayoutConfig()
+	cfg.AddPostProcessor(PostProcessorFunc(func(ml *ManifestLayout) error {
+		ml.ExtraFiles = append(ml.ExtraFiles, ExtraFile{Name: "README.md", Content: []byte("generated\n")})
+		ml.Children[0].Resources[0].SetLabels(map[string]string{"processed": "true"})
+		return nil
+	}))
+
+	ml := renderTestLayout()
+	files, err := ml.RenderWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if _, ok := files["clusters/cluster/apps/README.md"]; !ok {
+		t.Error("expected the post-processor output to be rendered")
+	}
+	if !strings.Contains(string(files["clusters/cluster/apps/app/default-configmap-cfg.yaml"]), "processed") {
+		t.Error("expected the post-processed resource to be rendered")
+	}
+	if len(ml.ExtraFiles) != 0 {
+		t.Errorf("expected the caller's layout to be unchanged, got extra files %v", ml.ExtraFiles)
+	}
+	if ml.Children[0].Resources[0].GetLabels() != nil {
+		t.Error("expected the caller's resources to be unchanged")
+	}
+}
+
+func TestRender_UsesDefaultConfig(t *testing.T) {
+	files, err := renderTestLayout().Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	want, err := renderTestLayout().RenderWithConfig(DefaultLayoutConfig())
+	if err != nil {
+		t.Fatalf("RenderWithConfig failed: %v", err)
+	}
+	if len(files) != len(want) {
+		t.Fatalf("expected %d files, got %d", len(want), len(files))
+	}
+	for name, data := range want {
+		if !bytes.Equal(files[name], data) {
+			t.Errorf("%s: Render differs from RenderWithConfig(DefaultLayoutConfig())", name)
+		}
+	}
+	if _, ok := files["clusters/cluster/apps/app/values.yaml"]; !ok {
+		t.Errorf("expected files under the default manifests directory, got %v", files)
+	}
+}
@@ -0,0 +1,88 @@
+package layout
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	// SourceIgnoreFileName is the Flux source-controller ignore file written
+	// when LayoutRules.SourceIgnore is set.
+	SourceIgnoreFileName = ".sourceignore"
+	// GitAttributesFileName is the Git attributes file written when
+	// LayoutRules.GitAttributes is set.
+	GitAttributesFileName = ".gitattributes"
+)
+
+// DefaultSourceIgnorePatterns excludes the files kure writes next to
+// manifests that are not Kubernetes objects, so Flux never tries to apply
+// them when it generates a kustomization.yaml for a directory.
+var DefaultSourceIgnorePatterns = []string{"*.md", ManifestIndexFileName}
+
+// repoFileRules records the repository files selected by LayoutRules. They
+// are carried by the root layout and written at the repository root, where
+// source-controller and Git look for them.
+type repoFileRules struct {
+	sourceIgnore  []string
+	gitAttributes bool
+}
+
+// attachRepoFiles records the .sourceignore and .gitattributes selected by
+// rules on the root layout.
+func attachRepoFiles(ml *ManifestLayout, rules LayoutRules) {
+	if ml == nil || (len(rules.SourceIgnore) == 0 && !rules.GitAttributes) {
+		return
+	}
+	ml.repoFiles = &repoFileRules{
+		sourceIgnore:  slices.Clone(rules.SourceIgnore),
+		gitAttributes: rules.GitAttributes,
+	}
+}
+
+// mergeRepoFiles moves the repository files of layouts to root, so that a
+// merged tree writes them once.
+func mergeRepoFiles(root *ManifestLayout, layouts []*ManifestLayout) {
+	for _, ml := range layouts {
+		if ml.repoFiles == nil {
+			continue
+		}
+		if root.repoFiles == nil {
+			root.repoFiles = &repoFileRules{}
+		}
+		for _, p := range ml.repoFiles.sourceIgnore {
+			if !slices.Contains(root.repoFiles.sourceIgnore, p) {
+				root.repoFiles.sourceIgnore = append(root.repoFiles.sourceIgnore, p)
+			}
+		}
+		root.repoFiles.gitAttributes = root.repoFiles.gitAttributes || ml.repoFiles.gitAttributes
+		ml.repoFiles = nil
+	}
+}
+
+// files returns the repository files of r. dir is the directory of the root
+// layout relative to the repository root; .gitattributes marks only the
+// files below it as generated.
+func (r *repoFileRules) files(dir string) []ExtraFile {
+	if r == nil {
+		return nil
+	}
+	var files []ExtraFile
+	if len(r.sourceIgnore) > 0 {
+		files = append(files, ExtraFile{
+			Name:    SourceIgnoreFileName,
+			Content: []byte(strings.Join(r.sourceIgnore, "\n") + "\n"),
+		})
+	}
+	if r.gitAttributes {
+		pattern := "*"
+		if dir = path.Clean(dir); dir != "." {
+			pattern = dir + "/**"
+		}
+		files = append(files, ExtraFile{
+			Name:    GitAttributesFileName,
+			Content: []byte(pattern + " linguist-generated=true\n"),
+		})
+	}
+	return files
+}
@@ -0,0 +1,102 @@
+package layout_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kure/kure/pkg/stack"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+func TestWalkCluster_RepoFiles(t *testing.T) {
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{freezeTestApp("web", false)}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	rules := layout.LayoutRules{
+		ClusterName:         "root",
+		BundleGrouping:      layout.GroupByName,
+		ApplicationGrouping: layout.GroupByName,
+		SourceIgnore:        append([]string{"/docs/"}, layout.DefaultSourceIgnorePatterns...),
+		GitAttributes:       true,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	// Both files live at the repository root, where source-controller and
+	// Git read them.
+	ignore, err := os.ReadFile(filepath.Join(dir, layout.SourceIgnoreFileName))
+	if err != nil {
+		t.Fatalf("read %s: %v", layout.SourceIgnoreFileName, err)
+	}
+	if want := "/docs/\n*.md\nindex.yaml\n"; string(ignore) != want {
+		t.Errorf("unexpected %s content %q, want %q", layout.SourceIgnoreFileName, ignore, want)
+	}
+
+	attrs, err := os.ReadFile(filepath.Join(dir, layout.GitAttributesFileName))
+	if err != nil {
+		t.Fatalf("read %s: %v", layout.GitAttributesFileName, err)
+	}
+	if want := "clusters/root/** linguist-generated=true\n"; string(attrs) != want {
+		t.Errorf("unexpected %s content %q, want %q", layout.GitAttributesFileName, attrs, want)
+	}
+
+	for _, sub := range []string{filepath.Join("clusters", "root"), filepath.Join("clusters", "root", "apps")} {
+		for _, name := range []string{layout.SourceIgnoreFileName, layout.GitAttributesFileName} {
+			if _, err := os.Stat(filepath.Join(dir, sub, name)); !os.IsNotExist(err) {
+				t.Errorf("expected no %s in %s", name, sub)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := ml.WriteToTar(&buf); err != nil {
+		t.Fatalf("WriteToTar failed: %v", err)
+	}
+	names := map[string]bool{}
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read tar: %v", err)
+		}
+		names[hdr.Name] = true
+	}
+	for _, name := range []string{layout.SourceIgnoreFileName, layout.GitAttributesFileName} {
+		if !names[name] {
+			t.Errorf("expected %s at the archive root, got %v", name, names)
+		}
+	}
+}
+
+func TestWalkCluster_RepoFilesDisabledByDefault(t *testing.T) {
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{freezeTestApp("web", false)}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	ml, err := layout.WalkCluster(cluster, layout.LayoutRules{})
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	for _, name := range []string{layout.SourceIgnoreFileName, layout.GitAttributesFileName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("unexpected %s without the corresponding rule", name)
+		}
+	}
+}
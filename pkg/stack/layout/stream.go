@@ -0,0 +1,171 @@
+package layout
+
+import (
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/go-kure/kure/pkg/errors"
+	"github.com/go-kure/kure/pkg/stack"
+)
+
+// WalkClusterFunc walks c like WalkCluster but yields one ManifestLayout per
+// node to fn, in depth-first pre-order, instead of building the whole tree.
+// Only the node being yielded is held in memory, so clusters with thousands
+// of applications can be written incrementally:
+//
+//	err := layout.WalkClusterFunc(cluster, rules, func(ml *layout.ManifestLayout) error {
+//	    return layout.WriteManifest("out", cfg, ml)
+//	})
+//
+// Each yielded layout carries its own resources and bundle or application
+// sub-layouts. Child nodes appear in Children as empty stubs, so that the
+// layout's kustomization.yaml references them, and are yielded after it.
+// Writing every yielded layout produces the same files as writing the
+// result of WalkCluster. The first layout yielded is the root.
+//
+// Rules that rearrange the complete tree (NodeGrouping GroupFlat with flat
+// bundles and applications, FlattenSingleTier and MaxDepth) are rejected.
+// ExtraFile conflicts, manifest indexes and repository files are handled per
+// yielded layout. An error returned by fn stops the walk and is returned.
+func WalkClusterFunc(c *stack.Cluster, rules LayoutRules, fn func(ml *ManifestLayout) error) error {
+	if c == nil || c.Node == nil {
+		return nil
+	}
+	if err := stack.ValidateCluster(c); err != nil {
+		return err
+	}
+
+	rules = applyDefaultRules(rules)
+	nodeOnly := rules.BundleGrouping == GroupFlat && rules.ApplicationGrouping == GroupFlat
+	switch {
+	case nodeOnly && rules.NodeGrouping == GroupFlat:
+		return errors.ResourceValidationError("LayoutRules", "", "NodeGrouping",
+			"flat node grouping needs the complete tree and is not supported by WalkClusterFunc", nil)
+	case rules.FlattenSingleTier:
+		return errors.ResourceValidationError("LayoutRules", "", "FlattenSingleTier",
+			"single-tier flattening needs the complete tree and is not supported by WalkClusterFunc", nil)
+	case rules.MaxDepth > 0:
+		return errors.ResourceValidationError("LayoutRules", "", "MaxDepth",
+			"depth-limited flattening needs the complete tree and is not supported by WalkClusterFunc", nil)
+	}
+
+	s := &layoutStream{
+		rules:    rules,
+		nodeOnly: nodeOnly,
+		filePer:  nodeOnlyFilePer(rules, nodeOnly),
+		wc:       newWalkContext(rules),
+		fn:       fn,
+	}
+
+	// Walk the root without its child nodes; they are streamed below.
+	shallow := *c.Node
+	shallow.Children = nil
+	var root, nodeLayout *ManifestLayout
+	var childAncestors []string
+	if rules.ClusterName != "" {
+		var err error
+		root, err = walkClusterWithClusterName(&stack.Cluster{Name: c.Name, Node: &shallow}, rules, nodeOnly, s.filePer)
+		if err != nil {
+			return err
+		}
+		nodeLayout = root
+		if c.Node.Name != "" && root.Name != c.Node.Name {
+			// The synthetic cluster layout wraps the root node layout.
+			nodeLayout = root.Children[len(root.Children)-1]
+		}
+		if c.Node.Name == "" {
+			childAncestors = []string{rules.ClusterName}
+		} else {
+			childAncestors = layoutPathSegments(nodeLayout)
+		}
+	} else {
+		var err error
+		root, err = walkNode(&shallow, nil, nodeOnly, false, s.filePer, nil, rules.FluxPlacement, rules.FileNaming, s.wc)
+		if err != nil {
+			return err
+		}
+		nodeLayout = root
+		if c.Node.Name != "" {
+			childAncestors = []string{c.Node.Name}
+		}
+	}
+
+	s.addStubs(nodeLayout, c.Node.Children, childAncestors)
+	attachRepoFiles(root, rules)
+	if err := s.yield(root); err != nil {
+		return err
+	}
+	return s.walkChildren(c.Node, childAncestors, nil)
+}
+
+// layoutStream carries the state of a WalkClusterFunc walk.
+type layoutStream struct {
+	rules    LayoutRules
+	nodeOnly bool
+	filePer  FileExportMode
+	wc       *walkContext
+	fn       func(ml *ManifestLayout) error
+}
+
+// walkChildren yields the layouts of the child nodes of n, whose own
+// layout lives at ancestors, and of their descendants.
+func (s *layoutStream) walkChildren(n *stack.Node, ancestors []string, inheritedPackageRef *schema.GroupVersionKind) error {
+	packageRef := resolvePackageRef(n, inheritedPackageRef)
+	for _, child := range n.Children {
+		if child == nil {
+			continue
+		}
+		shallow := *child
+		shallow.Children = nil
+		ml, err := walkNode(&shallow, ancestors, s.nodeOnly, false, s.filePer, packageRef, s.rules.FluxPlacement, s.rules.FileNaming, s.wc)
+		if err != nil {
+			return err
+		}
+		childAncestors := append(append([]string{}, ancestors...), child.Name)
+		if child.Name == "" {
+			childAncestors = ancestors
+		}
+		s.addStubs(ml, child.Children, childAncestors)
+		if err := s.yield(ml); err != nil {
+			return err
+		}
+		if err := s.walkChildren(child, childAncestors, packageRef); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addStubs appends an empty layout for every child node to ml so that its
+// kustomization.yaml references the child directories.
+func (s *layoutStream) addStubs(ml *ManifestLayout, children []*stack.Node, ancestors []string) {
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		ml.Children = append(ml.Children, &ManifestLayout{
+			Name:          child.Name,
+			Namespace:     filepath.Join(ancestors...),
+			FilePer:       s.filePer,
+			FluxPlacement: s.rules.FluxPlacement,
+			FileNaming:    s.rules.FileNaming,
+		})
+	}
+}
+
+// yield applies the per-layout post-walk steps to ml and passes it to fn.
+func (s *layoutStream) yield(ml *ManifestLayout) error {
+	if err := validateLayoutPaths(ml); err != nil {
+		return err
+	}
+	if err := resolveExtraFileConflicts(ml, s.rules.ExtraFileConflicts); err != nil {
+		return err
+	}
+	if s.rules.ManifestIndex {
+		if err := attachManifestIndexes(ml); err != nil {
+			return err
+		}
+	}
+	return s.fn(ml)
+}
@@ -0,0 +1,135 @@
+package layout_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-kure/kure/pkg/stack"
+	"github.com/go-kure/kure/pkg/stack/layout"
+)
+
+// streamTestCluster returns root -> {infra, apps -> web}, each node with a
+// one-application bundle.
+func streamTestCluster() *stack.Cluster {
+	node := func(name string) *stack.Node {
+		app := stack.NewApplication(name, "default", &fakeConfig{objs: []*client.Object{makeCM(name)}})
+		return &stack.Node{Name: name, Bundle: &stack.Bundle{Name: name + "-bundle", Applications: []*stack.Application{app}}}
+	}
+	root, infra, apps, web := node("root"), node("infra"), node("apps"), node("web")
+	apps.Children = []*stack.Node{web}
+	web.SetParent(apps)
+	root.Children = []*stack.Node{infra, apps}
+	infra.SetParent(root)
+	apps.SetParent(root)
+	return &stack.Cluster{Name: "demo", Node: root}
+}
+
+// readTree returns the files below dir keyed by their slash-separated
+// relative path.
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read tree: %v", err)
+	}
+	return files
+}
+
+func TestWalkClusterFunc_MatchesWalkCluster(t *testing.T) {
+	for name, rules := range map[string]layout.LayoutRules{
+		"defaults":     layout.DefaultLayoutRules(),
+		"grouped":      {BundleGrouping: layout.GroupByName, ApplicationGrouping: layout.GroupByName},
+		"cluster name": {ClusterName: "demo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := layout.DefaultLayoutConfig()
+
+			ml, err := layout.WalkCluster(streamTestCluster(), rules)
+			if err != nil {
+				t.Fatalf("WalkCluster: %v", err)
+			}
+			treeDir := t.TempDir()
+			if err := layout.WriteManifest(treeDir, cfg, ml); err != nil {
+				t.Fatalf("WriteManifest: %v", err)
+			}
+
+			streamDir := t.TempDir()
+			var yielded []string
+			err = layout.WalkClusterFunc(streamTestCluster(), rules, func(l *layout.ManifestLayout) error {
+				yielded = append(yielded, l.FullRepoPath())
+				return layout.WriteManifest(streamDir, cfg, l)
+			})
+			if err != nil {
+				t.Fatalf("WalkClusterFunc: %v", err)
+			}
+			if len(yielded) != 4 {
+				t.Errorf("expected one layout per node, got %v", yielded)
+			}
+
+			want, got := readTree(t, treeDir), readTree(t, streamDir)
+			if len(want) == 0 {
+				t.Fatal("expected WalkCluster output")
+			}
+			for p, content := range want {
+				if got[p] != content {
+					t.Errorf("%s differs:\nwant:\n%s\ngot:\n%s", p, content, got[p])
+				}
+			}
+			for p := range got {
+				if _, ok := want[p]; !ok {
+					t.Errorf("unexpected streamed file %s", p)
+				}
+			}
+		})
+	}
+}
+
+func TestWalkClusterFunc_StopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	err := layout.WalkClusterFunc(streamTestCluster(), layout.DefaultLayoutRules(), func(*layout.ManifestLayout) error {
+		calls++
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the walk to stop after the first error, got %d calls", calls)
+	}
+}
+
+func TestWalkClusterFunc_RejectsTreeRules(t *testing.T) {
+	for name, rules := range map[string]layout.LayoutRules{
+		"flatten single tier": {FlattenSingleTier: true},
+		"max depth":           {MaxDepth: 1},
+		"flat nodes":          {NodeGrouping: layout.GroupFlat, BundleGrouping: layout.GroupFlat, ApplicationGrouping: layout.GroupFlat},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := layout.WalkClusterFunc(streamTestCluster(), rules, func(*layout.ManifestLayout) error {
+				t.Fatal("unexpected callback")
+				return nil
+			})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
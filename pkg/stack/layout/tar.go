@@ -20,10 +20,28 @@ import (
 func (ml *ManifestLayout) WriteToTar(w io.Writer) error {
 	tw := tar.NewWriter(w)
 	defer func() { _ = tw.Close() }()
-	return ml.writeToTarRecursive(tw, "")
+	return ml.writeToSink(tarSink{tw: tw}, "")
 }
 
-func (ml *ManifestLayout) writeToTarRecursive(tw *tar.Writer, basePath string) error {
+// fileSink receives the directories and files produced by the in-memory
+// writers (WriteToTar and Render). Paths use forward slashes.
+type fileSink interface {
+	writeDir(dirPath string) error
+	writeFile(filePath string, data []byte) error
+}
+
+// tarSink writes entries to a tar archive.
+type tarSink struct {
+	tw *tar.Writer
+}
+
+func (s tarSink) writeDir(dirPath string) error { return writeTarDir(s.tw, dirPath) }
+
+func (s tarSink) writeFile(filePath string, data []byte) error {
+	return writeTarFile(s.tw, filePath, data)
+}
+
+func (ml *ManifestLayout) writeToSink(s fileSink, basePath string) error {
 	fileMode := ml.FilePer
 	if fileMode == FilePerUnset {
 		fileMode = FilePerResource
@@ -33,15 +51,17 @@ func (ml *ManifestLayout) writeToTarRecursive(tw *tar.Writer, basePath string) e
 		appMode = AppFilePerResource
 	}
 
-	var fullPath string
+	dir := ml.FullRepoPath()
 	if appMode == AppFileSingle {
-		fullPath = path.Join(basePath, ml.Namespace)
-	} else {
-		fullPath = path.Join(basePath, ml.FullRepoPath())
+		dir = ml.Namespace
 	}
+	fullPath := path.Join(basePath, dir)
 
 	// Add directory entry
-	if err := writeTarDir(tw, fullPath); err != nil {
+	if err := s.writeDir(fullPath); err != nil {
+		return err
+	}
+	if err := writeExtraFilesToSink(s, basePath, ml.repoFiles.files(dir)); err != nil {
 		return err
 	}
 
@@ -94,12 +114,12 @@ func (ml *ManifestLayout) writeToTarRecursive(tw *tar.Writer, basePath string) e
 			return err
 		}
 
-		if err := writeTarFile(tw, path.Join(fullPath, fileName), data); err != nil {
+		if err := s.writeFile(path.Join(fullPath, fileName), data); err != nil {
 			return err
 		}
 	}
 
-	if err := writeExtraFilesToTar(tw, fullPath, ml.ExtraFiles); err != nil {
+	if err := writeExtraFilesToSink(s, fullPath, ml.ExtraFiles); err != nil {
 		return err
 	}
 
@@ -109,7 +129,7 @@ func (ml *ManifestLayout) writeToTarRecursive(tw *tar.Writer, basePath string) e
 		kMode = KustomizationExplicit
 	}
 
-	if len(fileGroups) > 0 || len(ml.Children) > 0 {
+	if !ml.writtenAsFile() && (len(fileGroups) > 0 || len(ml.Children) > 0 || len(ml.References) > 0) {
 		var kustomBuf strings.Builder
 		kustomBuf.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\n")
 		kustomBuf.WriteString("kind: Kustomization\n")
@@ -154,17 +174,20 @@ func (ml *ManifestLayout) writeToTarRecursive(tw *tar.Writer, basePath string) e
 				kustomBuf.WriteString(fmt.Sprintf("  - %s\n", child.Name))
 			}
 		}
+		for _, ref := range ml.References {
+			kustomBuf.WriteString(fmt.Sprintf("  - %s\n", ref))
+		}
 
 		kustomBuf.WriteString(renderConfigMapGeneratorBlock(ml.ConfigMapGenerators))
 
-		if err := writeTarFile(tw, path.Join(fullPath, "kustomization.yaml"), []byte(kustomBuf.String())); err != nil {
+		if err := s.writeFile(path.Join(fullPath, "kustomization.yaml"), []byte(kustomBuf.String())); err != nil {
 			return err
 		}
 	}
 
 	// Recurse into children
 	for _, child := range ml.Children {
-		if err := child.writeToTarRecursive(tw, basePath); err != nil {
+		if err := child.writeToSink(s, basePath); err != nil {
 			return err
 		}
 	}
@@ -3,6 +3,10 @@
 package layout
 
 import (
+	"fmt"
+	"path"
+	"strings"
+
 	"github.com/go-kure/kure/pkg/errors"
 )
 
@@ -96,6 +100,44 @@ const (
 	FluxUnset FluxPlacement = ""
 )
 
+// ArgoPlacement determines whether and where ArgoCD Applications are placed
+// in the layout. Each Application targets the directory of one bundle with a
+// repository-relative spec.source.path (no `./` prefix).
+type ArgoPlacement string
+
+const (
+	// ArgoSeparate places all ArgoCD Applications in a separate argocd
+	// directory, to be applied by a single app-of-apps Application.
+	ArgoSeparate ArgoPlacement = "separate"
+	// ArgoIntegrated places each ArgoCD Application in the parent directory
+	// of the directory it targets, so the Application is applied together
+	// with the parent's manifests.
+	ArgoIntegrated ArgoPlacement = "integrated"
+	// ArgoUnset indicates that no ArgoCD Applications are emitted.
+	ArgoUnset ArgoPlacement = ""
+)
+
+// ExtraFileConflictPolicy determines how ExtraFiles that share a name within
+// one layout directory are resolved. Files with identical content are always
+// collapsed into one.
+type ExtraFileConflictPolicy string
+
+const (
+	// ExtraFileConflictError fails the walk when two ExtraFiles with the
+	// same name have different content.
+	ExtraFileConflictError ExtraFileConflictPolicy = "error"
+	// ExtraFileConflictMerge deep-merges conflicting YAML mappings, with the
+	// later file winning on conflicting keys. Content that is not a YAML
+	// mapping fails like ExtraFileConflictError.
+	ExtraFileConflictMerge ExtraFileConflictPolicy = "merge"
+	// ExtraFileConflictRename keeps the first file and renames later ones by
+	// appending the owning application's name, e.g. values-web.yaml. The
+	// owner's configMapGenerator entries follow the rename.
+	ExtraFileConflictRename ExtraFileConflictPolicy = "rename"
+	// ExtraFileConflictUnset is treated as ExtraFileConflictError.
+	ExtraFileConflictUnset ExtraFileConflictPolicy = ""
+)
+
 // LayoutRules control how layouts are generated.
 //
 // Zero values are interpreted as the defaults described in the field
@@ -126,6 +168,11 @@ type LayoutRules struct {
 	// FileNaming controls the file naming pattern for manifest files.
 	// Defaults to FileNamingDefault ({namespace}-{kind}-{name}.yaml).
 	FileNaming FileNamingMode
+	// ArgoPlacement determines whether and how ArgoCD Applications are
+	// placed. It is independent of FluxPlacement, so Applications can be
+	// emitted alongside or instead of Flux Kustomizations. Defaults to
+	// ArgoUnset (no Applications).
+	ArgoPlacement ArgoPlacement
 
 	// FlattenSingleTier collapses a vestigial intermediate directory layer
 	// produced by the walker when it adds no semantic value: a parent layout
@@ -147,6 +194,62 @@ type LayoutRules struct {
 	// ApplyFlattenPathRewrites before returning, so generated Flux
 	// Kustomization CRs resolve to the post-collapse directory.
 	FlattenSingleTier bool
+
+	// MaxDepth limits how many directory levels the layout nests below its
+	// root. Deeper layouts are moved up to the last allowed level and named
+	// after the levels they replace, joined with "-" (root/tier/app becomes
+	// root/tier-app with MaxDepth 1), keeping Flux paths short while
+	// preserving uniqueness. Zero means unlimited. With the integrated
+	// Flux placements WalkCluster leaves the tree as is and
+	// LayoutIntegrator.IntegrateWithLayout applies MaxDepth after placing
+	// the Kustomizations, which move and are renamed with their layouts.
+	MaxDepth int
+
+	// KustomizationPerApplication gives every application its own directory
+	// with an explicit kustomization.yaml listing its files, even when
+	// bundles and applications are GroupFlat. The parent directory references
+	// each application directory, so the output can be consumed by ArgoCD or
+	// `kubectl apply -k` without relying on Flux to generate a
+	// kustomization.yaml. GroupByName applications already get their own
+	// directory and are unaffected.
+	KustomizationPerApplication bool
+
+	// ManifestIndex writes an index.yaml into every layout directory that
+	// receives application output. The file maps each application to the
+	// object identities it produced (see stack.ManifestIndex) and is not
+	// referenced from kustomization.yaml.
+	ManifestIndex bool
+
+	// ExtraFileConflicts selects how ExtraFiles with the same name in one
+	// layout directory are resolved. Defaults to ExtraFileConflictError.
+	ExtraFileConflicts ExtraFileConflictPolicy
+
+	// SharedDirectory is the directory MergeClusters moves layouts shared
+	// by every cluster to. Defaults to DefaultSharedDirectory.
+	SharedDirectory string
+
+	// SourceIgnore, when non-empty, writes a Flux .sourceignore with these
+	// gitignore-style patterns at the repository root, the base path given
+	// to the writers. Use DefaultSourceIgnorePatterns for the files kure
+	// itself writes next to manifests.
+	SourceIgnore []string
+
+	// GitAttributes writes a .gitattributes at the repository root that
+	// marks every file below the root layout directory linguist-generated,
+	// so code review tools collapse generated manifests by default.
+	GitAttributes bool
+
+	// StrictFluxPaths makes LayoutIntegrator fail when ValidateFluxPaths
+	// finds a Flux Kustomization whose spec.path does not resolve to a
+	// directory with manifests. Otherwise the broken paths are reported to
+	// Warnings.
+	StrictFluxPaths bool
+
+	// Warnings, when non-nil, collects non-fatal findings from the walker,
+	// such as an overridden FilePer or nil objects dropped from application
+	// output, and Flux paths that do not resolve. A nil collector discards
+	// them.
+	Warnings *errors.Warnings
 }
 
 // DefaultLayoutRules returns a LayoutRules instance populated with the
@@ -204,6 +307,20 @@ func (lr LayoutRules) Validate() error {
 		return errors.NewValidationError("FluxPlacement", string(lr.FluxPlacement), "LayoutRules", []string{string(FluxSeparate), string(FluxIntegratedPerLayout), string(FluxIntegratedPerBundle)})
 	}
 
+	switch lr.ArgoPlacement {
+	case ArgoSeparate, ArgoIntegrated, ArgoUnset:
+		// valid
+	default:
+		return errors.NewValidationError("ArgoPlacement", string(lr.ArgoPlacement), "LayoutRules", []string{string(ArgoSeparate), string(ArgoIntegrated)})
+	}
+
+	switch lr.ExtraFileConflicts {
+	case ExtraFileConflictError, ExtraFileConflictMerge, ExtraFileConflictRename, ExtraFileConflictUnset:
+		// valid
+	default:
+		return errors.NewValidationError("ExtraFileConflicts", string(lr.ExtraFileConflicts), "LayoutRules", []string{string(ExtraFileConflictError), string(ExtraFileConflictMerge), string(ExtraFileConflictRename)})
+	}
+
 	switch lr.FileNaming {
 	case FileNamingDefault, FileNamingKindName, FileNamingUnset:
 		// valid
@@ -211,5 +328,22 @@ func (lr LayoutRules) Validate() error {
 		return errors.NewValidationError("FileNaming", string(lr.FileNaming), "LayoutRules", []string{string(FileNamingDefault), string(FileNamingKindName)})
 	}
 
+	if lr.MaxDepth < 0 {
+		return errors.ResourceValidationError("LayoutRules", "", "MaxDepth",
+			fmt.Sprintf("must not be negative, got %d", lr.MaxDepth), nil)
+	}
+
+	if d := lr.SharedDirectory; d != "" && (path.IsAbs(d) || path.Clean(d) != d || d == "." || d == ".." || strings.HasPrefix(d, "../")) {
+		return errors.ResourceValidationError("LayoutRules", "", "SharedDirectory",
+			fmt.Sprintf("%q must be a clean relative path", d), nil)
+	}
+
+	for _, pattern := range lr.SourceIgnore {
+		if strings.TrimSpace(pattern) == "" || strings.ContainsAny(pattern, "\r\n") {
+			return errors.ResourceValidationError("LayoutRules", "", "SourceIgnore",
+				fmt.Sprintf("pattern %q must be a single non-empty line", pattern), nil)
+		}
+	}
+
 	return nil
 }
@@ -160,6 +160,52 @@ func TestLayoutRules_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid argo placement integrated",
+			rules: layout.LayoutRules{
+				FluxPlacement: layout.FluxSeparate,
+				ArgoPlacement: layout.ArgoIntegrated,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid argo placement",
+			rules: layout.LayoutRules{
+				FluxPlacement: layout.FluxSeparate,
+				ArgoPlacement: layout.ArgoPlacement("invalid"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "negative max depth",
+			rules:   layout.LayoutRules{MaxDepth: -1},
+			wantErr: true,
+		},
+		{
+			name:    "nested shared directory",
+			rules:   layout.LayoutRules{SharedDirectory: "shared/infra"},
+			wantErr: false,
+		},
+		{
+			name:    "shared directory outside the repository",
+			rules:   layout.LayoutRules{SharedDirectory: "../infra"},
+			wantErr: true,
+		},
+		{
+			name:    "valid source ignore patterns",
+			rules:   layout.LayoutRules{SourceIgnore: layout.DefaultSourceIgnorePatterns},
+			wantErr: false,
+		},
+		{
+			name:    "multi-line source ignore pattern",
+			rules:   layout.LayoutRules{SourceIgnore: []string{"*.md\n!README.md"}},
+			wantErr: true,
+		},
+		{
+			name:    "empty source ignore pattern",
+			rules:   layout.LayoutRules{SourceIgnore: []string{" "}},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
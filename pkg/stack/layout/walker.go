@@ -39,7 +39,33 @@ func WalkCluster(c *stack.Cluster, rules LayoutRules) (*ManifestLayout, error) {
 		return nil, err
 	}
 
-	// Apply documented defaults for unset options.
+	rules = applyDefaultRules(rules)
+	nodeOnly := rules.BundleGrouping == GroupFlat && rules.ApplicationGrouping == GroupFlat
+	nodeFlat := rules.NodeGrouping == GroupFlat
+	filePer := nodeOnlyFilePer(rules, nodeOnly)
+	wc := newWalkContext(rules)
+
+	// For cluster-aware layout, we need to restructure the hierarchy
+	if rules.ClusterName != "" {
+		ml, err := walkClusterWithClusterName(c, rules, nodeOnly, filePer)
+		if err != nil {
+			return nil, err
+		}
+		return finishLayout(flattenSingleTier(ml, c, rules), rules)
+	}
+
+	// Traditional layout without cluster name
+	ml, err := walkNode(c.Node, nil, nodeOnly, nodeFlat, filePer, nil, rules.FluxPlacement, rules.FileNaming, wc)
+	if err != nil {
+		return nil, err
+	}
+
+	return finishLayout(flattenSingleTier(ml, c, rules), rules)
+}
+
+// applyDefaultRules fills the unset options of rules with the documented
+// defaults.
+func applyDefaultRules(rules LayoutRules) LayoutRules {
 	def := DefaultLayoutRules()
 	if rules.NodeGrouping == GroupUnset {
 		rules.NodeGrouping = def.NodeGrouping
@@ -56,30 +82,85 @@ func WalkCluster(c *stack.Cluster, rules LayoutRules) (*ManifestLayout, error) {
 	if rules.FluxPlacement == FluxUnset {
 		rules.FluxPlacement = def.FluxPlacement
 	}
+	return rules
+}
 
-	nodeOnly := rules.BundleGrouping == GroupFlat && rules.ApplicationGrouping == GroupFlat
-	nodeFlat := rules.NodeGrouping == GroupFlat
-	filePer := rules.FilePer
-	if nodeOnly {
-		filePer = FilePerResource
+// walkContext carries the per-walk options that every walker function
+// needs. A nil *walkContext is valid and behaves like zero LayoutRules.
+type walkContext struct {
+	warnings       *errors.Warnings
+	perApplication bool
+	appFileMode    ApplicationFileMode
+}
+
+// newWalkContext returns the walkContext for rules.
+func newWalkContext(rules LayoutRules) *walkContext {
+	return &walkContext{
+		warnings:       rules.Warnings,
+		perApplication: rules.KustomizationPerApplication,
+		appFileMode:    rules.ApplicationFileMode,
 	}
+}
 
-	// For cluster-aware layout, we need to restructure the hierarchy
-	if rules.ClusterName != "" {
-		ml, err := walkClusterWithClusterName(c, rules, nodeOnly, filePer)
-		if err != nil {
+// warn reports a walker finding for path to the configured collector.
+func (wc *walkContext) warn(path, format string, args ...any) {
+	if wc == nil {
+		return
+	}
+	wc.warnings.Addf("layout", path, format, args...)
+}
+
+// kustomizationPerApplication reports whether every application gets its
+// own directory and kustomization.yaml on flat walker paths.
+func (wc *walkContext) kustomizationPerApplication() bool {
+	return wc != nil && wc.perApplication
+}
+
+// applicationFileMode returns the ApplicationFileMode for the layout of app.
+// Augmenter output (extra files, configMapGenerators) needs its own
+// directory, so augmenters are never written as a single file.
+func (wc *walkContext) applicationFileMode(app *stack.Application) ApplicationFileMode {
+	if wc == nil || wc.appFileMode != AppFileSingle || isAugmenter(app) {
+		return AppFileUnset
+	}
+	return AppFileSingle
+}
+
+// finishLayout applies the optional post-walk steps selected by rules.
+func finishLayout(ml *ManifestLayout, rules LayoutRules) (*ManifestLayout, error) {
+	// With integrated placement the LayoutIntegrator applies MaxDepth once
+	// it has placed the Kustomizations that move with the layouts.
+	if rules.FluxPlacement != FluxIntegratedPerLayout && rules.FluxPlacement != FluxIntegratedPerBundle {
+		if err := limitDepth(ml, rules.MaxDepth); err != nil {
 			return nil, err
 		}
-		return flattenSingleTier(ml, c, rules), nil
 	}
-
-	// Traditional layout without cluster name
-	ml, err := walkNode(c.Node, nil, nodeOnly, nodeFlat, filePer, nil, rules.FluxPlacement, rules.FileNaming)
-	if err != nil {
+	if err := validateLayoutPaths(ml); err != nil {
 		return nil, err
 	}
+	if err := resolveExtraFileConflicts(ml, rules.ExtraFileConflicts); err != nil {
+		return nil, err
+	}
+	if rules.ManifestIndex {
+		if err := attachManifestIndexes(ml); err != nil {
+			return nil, err
+		}
+	}
+	attachRepoFiles(ml, rules)
+	return ml, nil
+}
 
-	return flattenSingleTier(ml, c, rules), nil
+// nodeOnlyFilePer returns the effective file export mode. Node-only layouts
+// (flat bundles and applications) always write one file per resource; an
+// explicit FilePer that is overridden this way is reported to rules.Warnings.
+func nodeOnlyFilePer(rules LayoutRules, nodeOnly bool) FileExportMode {
+	if !nodeOnly {
+		return rules.FilePer
+	}
+	if rules.FilePer != FilePerResource {
+		rules.Warnings.Addf("layout", "", "FilePer %q overridden to %q because bundles and applications are flat", rules.FilePer, FilePerResource)
+	}
+	return FilePerResource
 }
 
 // walkClusterWithClusterName creates a cluster-aware layout where the cluster
@@ -99,13 +180,14 @@ func walkClusterWithClusterName(c *stack.Cluster, rules LayoutRules, nodeOnly bo
 	}
 
 	nodeFlat := rules.NodeGrouping == GroupFlat
+	wc := newWalkContext(rules)
 
 	// Unnamed root node: resources go directly at the cluster root with no
 	// intermediate subdirectory. The clusterLayout itself holds the bundle's
 	// resources so WriteToDisk writes a single directory (no path collision).
 	if c.Node.Name == "" {
 		if c.Node.Bundle != nil {
-			if err := processFlatBundleApps(c.Node.Bundle.Applications, clusterLayout, []string{rules.ClusterName}, rules.FluxPlacement, rules.FileNaming); err != nil {
+			if err := processFlatBundleApps(c.Node.Bundle, clusterLayout, []string{rules.ClusterName}, rules.FluxPlacement, rules.FileNaming, wc); err != nil {
 				return nil, err
 			}
 			if len(c.Node.Bundle.Children) > 0 {
@@ -116,6 +198,7 @@ func walkClusterWithClusterName(c *stack.Cluster, rules LayoutRules, nodeOnly bo
 					filePer,
 					rules.FluxPlacement,
 					rules.FileNaming,
+					wc,
 				)
 				if err != nil {
 					return nil, err
@@ -124,7 +207,7 @@ func walkClusterWithClusterName(c *stack.Cluster, rules LayoutRules, nodeOnly bo
 			}
 		}
 		for _, child := range c.Node.Children {
-			childLayout, err := walkNode(child, []string{rules.ClusterName}, nodeOnly, nodeFlat, filePer, nil, rules.FluxPlacement, rules.FileNaming)
+			childLayout, err := walkNode(child, []string{rules.ClusterName}, nodeOnly, nodeFlat, filePer, nil, rules.FluxPlacement, rules.FileNaming, wc)
 			if err != nil {
 				return nil, err
 			}
@@ -157,7 +240,7 @@ func walkClusterWithClusterName(c *stack.Cluster, rules LayoutRules, nodeOnly bo
 
 	if c.Node.Bundle != nil {
 		// Add only the root node's bundle resources (not child resources)
-		if err := processFlatBundleApps(c.Node.Bundle.Applications, rootLayout, rootSegments, rules.FluxPlacement, rules.FileNaming); err != nil {
+		if err := processFlatBundleApps(c.Node.Bundle, rootLayout, rootSegments, rules.FluxPlacement, rules.FileNaming, wc); err != nil {
 			return nil, err
 		}
 
@@ -171,6 +254,7 @@ func walkClusterWithClusterName(c *stack.Cluster, rules LayoutRules, nodeOnly bo
 				filePer,
 				rules.FluxPlacement,
 				rules.FileNaming,
+				wc,
 			)
 			if err != nil {
 				return nil, err
@@ -184,7 +268,7 @@ func walkClusterWithClusterName(c *stack.Cluster, rules LayoutRules, nodeOnly bo
 	// stack.Node.GetPath() (rootName/childName/...) when the Flux integrator
 	// searches for the corresponding layout node.
 	for _, child := range c.Node.Children {
-		childLayout, err := walkNode(child, rootSegments, nodeOnly, nodeFlat, filePer, nil, rules.FluxPlacement, rules.FileNaming)
+		childLayout, err := walkNode(child, rootSegments, nodeOnly, nodeFlat, filePer, nil, rules.FluxPlacement, rules.FileNaming, wc)
 		if err != nil {
 			return nil, err
 		}
@@ -238,10 +322,8 @@ func WalkClusterByPackage(c *stack.Cluster, rules LayoutRules) (map[string]*Mani
 	}
 
 	nodeOnly := rules.BundleGrouping == GroupFlat && rules.ApplicationGrouping == GroupFlat
-	filePer := rules.FilePer
-	if nodeOnly {
-		filePer = FilePerResource
-	}
+	filePer := nodeOnlyFilePer(rules, nodeOnly)
+	wc := newWalkContext(rules)
 
 	// First pass: collect all unique package references
 	packages := make(map[string]*schema.GroupVersionKind)
@@ -250,11 +332,14 @@ func WalkClusterByPackage(c *stack.Cluster, rules LayoutRules) (map[string]*Mani
 	// Second pass: build layouts for each package
 	layouts := make(map[string]*ManifestLayout)
 	for pkgKey, pkgRef := range packages {
-		layout, err := walkNodeForPackage(c.Node, nil, nodeOnly, filePer, pkgRef, pkgKey, rules.FileNaming)
+		layout, err := walkNodeForPackage(c.Node, nil, nodeOnly, filePer, pkgRef, pkgKey, rules.FileNaming, wc)
 		if err != nil {
 			return nil, err
 		}
 		if layout != nil {
+			if layout, err = finishLayout(layout, rules); err != nil {
+				return nil, err
+			}
 			layouts[pkgKey] = layout
 		}
 	}
@@ -265,7 +350,7 @@ func WalkClusterByPackage(c *stack.Cluster, rules LayoutRules) (map[string]*Mani
 // walkNode recursively processes a stack.Node and its children.
 // When nodeFlat is true, child nodes do not create subdirectories; their
 // resources are merged into the parent ManifestLayout.
-func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, filePer FileExportMode, inheritedPackageRef *schema.GroupVersionKind, fluxPlacement FluxPlacement, fileNaming FileNamingMode) (*ManifestLayout, error) {
+func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, filePer FileExportMode, inheritedPackageRef *schema.GroupVersionKind, fluxPlacement FluxPlacement, fileNaming FileNamingMode, wc *walkContext) (*ManifestLayout, error) {
 	if n == nil {
 		return nil, nil
 	}
@@ -285,14 +370,14 @@ func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, f
 
 	if nodeOnly {
 		if b := n.Bundle; b != nil {
-			if err := processFlatBundleApps(b.Applications, ml, currentPath, fluxPlacement, fileNaming); err != nil {
+			if err := processFlatBundleApps(b, ml, currentPath, fluxPlacement, fileNaming, wc); err != nil {
 				return nil, err
 			}
 			// Umbrella: umbrella child sub-layouts live directly under the
 			// node layout in nodeOnly mode (no intermediate bundle layer).
 			if len(b.Children) > 0 {
 				b.InitializeUmbrella()
-				umbrellaChildren, err := walkUmbrellaChildLayouts(b.Children, currentPath, filePer, fluxPlacement, fileNaming)
+				umbrellaChildren, err := walkUmbrellaChildLayouts(b.Children, currentPath, filePer, fluxPlacement, fileNaming, wc)
 				if err != nil {
 					return nil, err
 				}
@@ -303,39 +388,38 @@ func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, f
 		var children []*ManifestLayout
 		if b := n.Bundle; b != nil {
 			var bundleChildren []*ManifestLayout
+			index := newBundleIndex(b)
 			for _, app := range b.Applications {
 				if app == nil {
 					continue
 				}
-				objsPtr, err := app.Generate()
+				objs, err := generateAppObjects(app, wc)
 				if err != nil {
 					return nil, err
 				}
-				var objs []client.Object
-				for _, o := range objsPtr {
-					if o == nil {
-						continue
-					}
-					objs = append(objs, *o)
-				}
+				index.Add(app, objs...)
 				appLayout := &ManifestLayout{
-					Name:          app.Name,
-					Namespace:     filepath.Join(append(currentPath, b.Name)...),
-					Resources:     objs,
-					Mode:          KustomizationExplicit,
-					FluxPlacement: fluxPlacement,
-					FileNaming:    fileNaming,
+					Name:                app.Name,
+					Namespace:           filepath.Join(append(currentPath, b.Name)...),
+					Resources:           objs,
+					Mode:                KustomizationExplicit,
+					FluxPlacement:       fluxPlacement,
+					FileNaming:          fileNaming,
+					ApplicationFileMode: wc.applicationFileMode(app),
 				}
 				if err := augmentAppLayout(app, appLayout); err != nil {
 					return nil, err
 				}
+				if isAppFrozen(b, app) {
+					freezeAppLayout(appLayout, app, wc)
+				}
 				bundleChildren = append(bundleChildren, appLayout)
 			}
 			// Umbrella: umbrella child sub-layouts are siblings of application
 			// sub-layouts within the bundle's layout directory.
 			if len(b.Children) > 0 {
 				b.InitializeUmbrella()
-				umbrellaChildren, err := walkUmbrellaChildLayouts(b.Children, append(currentPath, b.Name), filePer, fluxPlacement, fileNaming)
+				umbrellaChildren, err := walkUmbrellaChildLayouts(b.Children, append(currentPath, b.Name), filePer, fluxPlacement, fileNaming, wc)
 				if err != nil {
 					return nil, err
 				}
@@ -348,12 +432,13 @@ func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, f
 				Mode:          KustomizationRecursive,
 				FluxPlacement: fluxPlacement,
 				FileNaming:    fileNaming,
+				Index:         index,
 			}
 			children = append(children, bundleLayout)
 		}
 
 		for _, child := range n.Children {
-			cl, err := walkNode(child, currentPath, nodeOnly, nodeFlat, filePer, resolvePackageRef(n, inheritedPackageRef), fluxPlacement, fileNaming)
+			cl, err := walkNode(child, currentPath, nodeOnly, nodeFlat, filePer, resolvePackageRef(n, inheritedPackageRef), fluxPlacement, fileNaming, wc)
 			if err != nil {
 				return nil, err
 			}
@@ -369,19 +454,31 @@ func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, f
 		for _, child := range n.Children {
 			if nodeFlat {
 				// Merge child node resources directly into this node
-				cl, err := walkNode(child, ancestors, nodeOnly, nodeFlat, filePer, resolvePackageRef(n, inheritedPackageRef), fluxPlacement, fileNaming)
+				cl, err := walkNode(child, ancestors, nodeOnly, nodeFlat, filePer, resolvePackageRef(n, inheritedPackageRef), fluxPlacement, fileNaming, wc)
 				if err != nil {
 					return nil, err
 				}
 				if cl != nil {
 					ml.Resources = append(ml.Resources, cl.Resources...)
-					// Recursively collect from grandchildren too
+					mergeIndex(ml, cl.Index)
+					mergeFrozen(ml, cl)
+					// Recursively collect from grandchildren too. Per-app
+					// sub-layouts created for augmenters keep their own
+					// directory (and ExtraFiles / ConfigMapGenerators); they
+					// move under this node instead of being flattened.
 					for _, gc := range cl.Children {
+						if !gc.UmbrellaChild {
+							rebaseLayout(gc, filepath.Join(cl.Namespace, cl.Name), filepath.Join(currentPath...))
+							ml.Children = append(ml.Children, gc)
+							continue
+						}
 						ml.Resources = append(ml.Resources, gc.Resources...)
+						mergeIndex(ml, gc.Index)
+						mergeFrozen(ml, gc)
 					}
 				}
 			} else {
-				cl, err := walkNode(child, currentPath, nodeOnly, nodeFlat, filePer, resolvePackageRef(n, inheritedPackageRef), fluxPlacement, fileNaming)
+				cl, err := walkNode(child, currentPath, nodeOnly, nodeFlat, filePer, resolvePackageRef(n, inheritedPackageRef), fluxPlacement, fileNaming, wc)
 				if err != nil {
 					return nil, err
 				}
@@ -395,6 +492,24 @@ func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, f
 	return ml, nil
 }
 
+// rebaseLayout moves l and its descendants from the oldPath directory to
+// newPath by rewriting the matching Namespace prefix.
+func rebaseLayout(l *ManifestLayout, oldPath, newPath string) {
+	if oldPath == newPath {
+		return
+	}
+	prefix := oldPath + string(filepath.Separator)
+	switch {
+	case l.Namespace == oldPath:
+		l.Namespace = newPath
+	case strings.HasPrefix(l.Namespace, prefix):
+		l.Namespace = filepath.Join(newPath, strings.TrimPrefix(l.Namespace, prefix))
+	}
+	for _, c := range l.Children {
+		rebaseLayout(c, oldPath, newPath)
+	}
+}
+
 // walkUmbrellaChildLayouts renders a slice of umbrella Bundle.Children into a
 // flat ManifestLayout list. Each returned layout carries UmbrellaChild=true so
 // downstream writers emit a flux-system-kustomization-{Name}.yaml reference
@@ -402,7 +517,7 @@ func walkNode(n *stack.Node, ancestors []string, nodeOnly bool, nodeFlat bool, f
 // Flux CR. Child application resources are flattened into the child layout's
 // Resources (single-directory-per-child on disk). Nested umbrellas recurse so
 // grandchildren become sub-layouts of their immediate parent umbrella child.
-func walkUmbrellaChildLayouts(children []*stack.Bundle, currentPath []string, filePer FileExportMode, fluxPlacement FluxPlacement, fileNaming FileNamingMode) ([]*ManifestLayout, error) {
+func walkUmbrellaChildLayouts(children []*stack.Bundle, currentPath []string, filePer FileExportMode, fluxPlacement FluxPlacement, fileNaming FileNamingMode, wc *walkContext) ([]*ManifestLayout, error) {
 	var out []*ManifestLayout
 	for _, cb := range children {
 		if cb == nil {
@@ -417,12 +532,12 @@ func walkUmbrellaChildLayouts(children []*stack.Bundle, currentPath []string, fi
 			Mode:          KustomizationExplicit,
 			UmbrellaChild: true,
 		}
-		if err := processFlatBundleApps(cb.Applications, ml, append(append([]string(nil), currentPath...), cb.Name), fluxPlacement, fileNaming); err != nil {
+		if err := processFlatBundleApps(cb, ml, append(append([]string(nil), currentPath...), cb.Name), fluxPlacement, fileNaming, wc); err != nil {
 			return nil, err
 		}
 		if len(cb.Children) > 0 {
 			cb.InitializeUmbrella()
-			nested, err := walkUmbrellaChildLayouts(cb.Children, append(currentPath, cb.Name), filePer, fluxPlacement, fileNaming)
+			nested, err := walkUmbrellaChildLayouts(cb.Children, append(currentPath, cb.Name), filePer, fluxPlacement, fileNaming, wc)
 			if err != nil {
 				return nil, err
 			}
@@ -448,9 +563,38 @@ func augmentAppLayout(app *stack.Application, ml *ManifestLayout) error {
 	if err := augmenter.AugmentLayout(ml); err != nil {
 		return errors.Wrapf(err, "augment layout for application %q", app.Name)
 	}
+	for i := range ml.ExtraFiles {
+		if ml.ExtraFiles[i].Owner == "" {
+			ml.ExtraFiles[i].Owner = app.Name
+			ml.ExtraFiles[i].OwnerNamespace = app.Namespace
+		}
+	}
+	for i := range ml.ConfigMapGenerators {
+		if ml.ConfigMapGenerators[i].Owner == "" {
+			ml.ConfigMapGenerators[i].Owner = app.Name
+		}
+	}
 	return nil
 }
 
+// generateAppObjects runs app.Generate and dereferences the returned object
+// pointers. Nil entries are dropped and reported to wc.
+func generateAppObjects(app *stack.Application, wc *walkContext) ([]client.Object, error) {
+	objsPtr, err := app.Generate()
+	if err != nil {
+		return nil, err
+	}
+	var objs []client.Object
+	for i, o := range objsPtr {
+		if o == nil || *o == nil {
+			wc.warn(app.Name, "skipped nil object at index %d returned by application", i)
+			continue
+		}
+		objs = append(objs, *o)
+	}
+	return objs, nil
+}
+
 // isAugmenter reports whether app.Config implements LayoutAugmenter.
 func isAugmenter(app *stack.Application) bool {
 	if app == nil || app.Config == nil {
@@ -461,32 +605,33 @@ func isAugmenter(app *stack.Application) bool {
 }
 
 // processFlatBundleApps places each application from a flat bundle into either
-// a per-app sub-layout (when its Config implements LayoutAugmenter) or into
-// the parent layout's flat Resources (otherwise). The per-app sub-layout path
+// a per-app sub-layout (when its Config implements LayoutAugmenter or
+// LayoutRules.KustomizationPerApplication is set) or into the parent layout's
+// flat Resources (otherwise). With LayoutRules.ApplicationFileMode set to
+// AppFileSingle, other applications get a single-file sub-layout written as
+// <app>.yaml into the parent directory. The per-app sub-layout path
 // lets augmenters (e.g. values.yaml + configMapGenerator emitters) mutate
 // their own ManifestLayout without colliding with sibling apps that share the
-// same bundle.
+// same bundle. Output of frozen applications is marked with FreezeResources
+// (or freezeAppLayout for per-app sub-layouts).
 //
 // parentPath is the slice of path segments leading to and including the
 // parent layout's on-disk directory; per-app sub-layouts get
 // Namespace = filepath.Join(parentPath..., app.Name).
-func processFlatBundleApps(apps []*stack.Application, parent *ManifestLayout, parentPath []string, fluxPlacement FluxPlacement, fileNaming FileNamingMode) error {
-	for _, app := range apps {
+func processFlatBundleApps(b *stack.Bundle, parent *ManifestLayout, parentPath []string, fluxPlacement FluxPlacement, fileNaming FileNamingMode, wc *walkContext) error {
+	for _, app := range b.Applications {
 		if app == nil {
 			continue
 		}
-		objsPtr, err := app.Generate()
+		objs, err := generateAppObjects(app, wc)
 		if err != nil {
 			return err
 		}
-		var objs []client.Object
-		for _, o := range objsPtr {
-			if o == nil {
-				continue
-			}
-			objs = append(objs, *o)
+		if parent.Index == nil {
+			parent.Index = &stack.ManifestIndex{Applications: []stack.ApplicationIndex{}}
 		}
-		if isAugmenter(app) {
+		parent.Index.Add(app, objs...)
+		if isAugmenter(app) || wc.kustomizationPerApplication() {
 			appLayout := &ManifestLayout{
 				Name:          app.Name,
 				Namespace:     filepath.Join(append(append([]string(nil), parentPath...), app.Name)...),
@@ -498,9 +643,31 @@ func processFlatBundleApps(apps []*stack.Application, parent *ManifestLayout, pa
 			if err := augmentAppLayout(app, appLayout); err != nil {
 				return err
 			}
+			if isAppFrozen(b, app) {
+				freezeAppLayout(appLayout, app, wc)
+			}
 			parent.Children = append(parent.Children, appLayout)
 			continue
 		}
+		if wc.applicationFileMode(app) == AppFileSingle {
+			appLayout := &ManifestLayout{
+				Name:                app.Name,
+				Namespace:           filepath.Join(parentPath...),
+				Resources:           objs,
+				FluxPlacement:       fluxPlacement,
+				FileNaming:          fileNaming,
+				ApplicationFileMode: AppFileSingle,
+			}
+			if isAppFrozen(b, app) {
+				freezeAppLayout(appLayout, app, wc)
+			}
+			parent.Children = append(parent.Children, appLayout)
+			continue
+		}
+		if isAppFrozen(b, app) {
+			parent.FreezeResources(objs...)
+			wc.warn(app.Name, "application is frozen; existing files are kept")
+		}
 		parent.Resources = append(parent.Resources, objs...)
 	}
 	return nil
@@ -538,12 +705,12 @@ func collectPackageRefs(n *stack.Node, inheritedPackageRef *schema.GroupVersionK
 }
 
 // walkNodeForPackage walks the tree but only includes nodes that belong to the specified package
-func walkNodeForPackage(n *stack.Node, ancestors []string, nodeOnly bool, filePer FileExportMode, targetPackageRef *schema.GroupVersionKind, targetKey string, fileNaming FileNamingMode) (*ManifestLayout, error) {
-	return walkNodeForPackageInternal(n, ancestors, nodeOnly, filePer, nil, targetPackageRef, targetKey, fileNaming)
+func walkNodeForPackage(n *stack.Node, ancestors []string, nodeOnly bool, filePer FileExportMode, targetPackageRef *schema.GroupVersionKind, targetKey string, fileNaming FileNamingMode, wc *walkContext) (*ManifestLayout, error) {
+	return walkNodeForPackageInternal(n, ancestors, nodeOnly, filePer, nil, targetPackageRef, targetKey, fileNaming, wc)
 }
 
 // walkNodeForPackageInternal is the internal implementation with inheritance tracking
-func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool, filePer FileExportMode, inheritedPackageRef *schema.GroupVersionKind, targetPackageRef *schema.GroupVersionKind, targetKey string, fileNaming FileNamingMode) (*ManifestLayout, error) {
+func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool, filePer FileExportMode, inheritedPackageRef *schema.GroupVersionKind, targetPackageRef *schema.GroupVersionKind, targetKey string, fileNaming FileNamingMode, wc *walkContext) (*ManifestLayout, error) {
 	if n == nil {
 		return nil, nil
 	}
@@ -573,7 +740,7 @@ func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool
 				// package-aware walker also leaves FluxPlacement unset on
 				// per-app layouts. The per-app sublayout created for
 				// augmenter apps matches that convention.
-				if err := processFlatBundleApps(b.Applications, ml, currentPath, FluxUnset, fileNaming); err != nil {
+				if err := processFlatBundleApps(b, ml, currentPath, FluxUnset, fileNaming, wc); err != nil {
 					return nil, err
 				}
 			}
@@ -581,30 +748,29 @@ func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool
 			var children []*ManifestLayout
 			if b := n.Bundle; b != nil {
 				var bundleChildren []*ManifestLayout
+				index := newBundleIndex(b)
 				for _, app := range b.Applications {
 					if app == nil {
 						continue
 					}
-					objsPtr, err := app.Generate()
+					objs, err := generateAppObjects(app, wc)
 					if err != nil {
 						return nil, err
 					}
-					var objs []client.Object
-					for _, o := range objsPtr {
-						if o == nil {
-							continue
-						}
-						objs = append(objs, *o)
-					}
+					index.Add(app, objs...)
 					appLayout := &ManifestLayout{
-						Name:       app.Name,
-						Namespace:  filepath.Join(append(currentPath, b.Name)...),
-						Resources:  objs,
-						FileNaming: fileNaming,
+						Name:                app.Name,
+						Namespace:           filepath.Join(append(currentPath, b.Name)...),
+						Resources:           objs,
+						FileNaming:          fileNaming,
+						ApplicationFileMode: wc.applicationFileMode(app),
 					}
 					if err := augmentAppLayout(app, appLayout); err != nil {
 						return nil, err
 					}
+					if isAppFrozen(b, app) {
+						freezeAppLayout(appLayout, app, wc)
+					}
 					bundleChildren = append(bundleChildren, appLayout)
 				}
 				if len(bundleChildren) > 0 {
@@ -613,13 +779,14 @@ func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool
 						Namespace:  filepath.Join(currentPath...),
 						Children:   bundleChildren,
 						FileNaming: fileNaming,
+						Index:      index,
 					}
 					children = append(children, bundleLayout)
 				}
 			}
 
 			for _, child := range n.Children {
-				cl, err := walkNodeForPackageInternal(child, currentPath, nodeOnly, filePer, currentPackageRef, targetPackageRef, targetKey, fileNaming)
+				cl, err := walkNodeForPackageInternal(child, currentPath, nodeOnly, filePer, currentPackageRef, targetPackageRef, targetKey, fileNaming, wc)
 				if err != nil {
 					return nil, err
 				}
@@ -633,7 +800,7 @@ func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool
 
 		if nodeOnly {
 			for _, child := range n.Children {
-				cl, err := walkNodeForPackageInternal(child, currentPath, nodeOnly, filePer, currentPackageRef, targetPackageRef, targetKey, fileNaming)
+				cl, err := walkNodeForPackageInternal(child, currentPath, nodeOnly, filePer, currentPackageRef, targetPackageRef, targetKey, fileNaming, wc)
 				if err != nil {
 					return nil, err
 				}
@@ -646,7 +813,7 @@ func walkNodeForPackageInternal(n *stack.Node, ancestors []string, nodeOnly bool
 		// Node doesn't belong to target package, but continue traversing children
 		// in case they have different PackageRef values
 		for _, child := range n.Children {
-			cl, err := walkNodeForPackageInternal(child, ancestors, nodeOnly, filePer, currentPackageRef, targetPackageRef, targetKey, fileNaming)
+			cl, err := walkNodeForPackageInternal(child, ancestors, nodeOnly, filePer, currentPackageRef, targetPackageRef, targetKey, fileNaming, wc)
 			if err != nil {
 				return nil, err
 			}
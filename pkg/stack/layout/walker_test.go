@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kerrors "github.com/go-kure/kure/pkg/errors"
 	"github.com/go-kure/kure/pkg/stack"
 	"github.com/go-kure/kure/pkg/stack/layout"
 )
@@ -223,6 +224,120 @@ func TestWalkClusterNodeOnly(t *testing.T) {
 	}
 }
 
+func TestWalkCluster_Warnings(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("cm")
+	obj.SetNamespace("default")
+	var o client.Object = obj
+
+	app := stack.NewApplication("app", "ns", &fakeConfig{objs: []*client.Object{&o, nil}})
+	bundle := &stack.Bundle{Name: "bundle", Applications: []*stack.Application{app}}
+	root := &stack.Node{Name: "root", Bundle: bundle}
+	cluster := &stack.Cluster{Name: "demo", Node: root}
+
+	warnings := &kerrors.Warnings{}
+	rules := layout.LayoutRules{
+		BundleGrouping:      layout.GroupFlat,
+		ApplicationGrouping: layout.GroupFlat,
+		FilePer:             layout.FilePerKind,
+		Warnings:            warnings,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if len(ml.Resources) != 1 {
+		t.Fatalf("expected nil object to be dropped, got %d resources", len(ml.Resources))
+	}
+
+	items := warnings.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", len(items), items)
+	}
+	if !strings.Contains(items[0].Message, "FilePer") {
+		t.Errorf("expected FilePer override warning, got %q", items[0].Message)
+	}
+	if items[1].Path != "app" || !strings.Contains(items[1].Message, "nil object") {
+		t.Errorf("expected nil object warning for app, got %v", items[1])
+	}
+
+	// A nil collector is accepted and simply discards findings.
+	rules.Warnings = nil
+	if _, err := layout.WalkCluster(cluster, rules); err != nil {
+		t.Fatalf("walk cluster without collector: %v", err)
+	}
+}
+
+func TestWalkCluster_ManifestIndex(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("cm")
+	obj.SetNamespace("default")
+	var o client.Object = obj
+
+	newCluster := func() *stack.Cluster {
+		app := stack.NewApplication("app", "ns", &fakeConfig{objs: []*client.Object{&o}})
+		bundle := &stack.Bundle{Name: "bundle", Applications: []*stack.Application{app}}
+		return &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+	}
+
+	indexFile := func(ml *layout.ManifestLayout) string {
+		for _, ef := range ml.ExtraFiles {
+			if ef.Name == layout.ManifestIndexFileName {
+				return string(ef.Content)
+			}
+		}
+		return ""
+	}
+
+	t.Run("bundle directories", func(t *testing.T) {
+		ml, err := layout.WalkCluster(newCluster(), layout.LayoutRules{
+			BundleGrouping:      layout.GroupByName,
+			ApplicationGrouping: layout.GroupByName,
+			ManifestIndex:       true,
+		})
+		if err != nil {
+			t.Fatalf("walk cluster: %v", err)
+		}
+		bundleLayout := ml.Children[0]
+		got := indexFile(bundleLayout)
+		for _, want := range []string{"bundle: bundle", "name: app", "kind: ConfigMap", "name: cm"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("index.yaml missing %q:\n%s", want, got)
+			}
+		}
+		if bundleLayout.Index == nil {
+			t.Fatal("expected Index on bundle layout")
+		}
+		if owner, ok := bundleLayout.Index.Owner(stack.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm"}); !ok || owner != "app" {
+			t.Errorf("expected ConfigMap owned by app, got %q", owner)
+		}
+	})
+
+	t.Run("node-only layout", func(t *testing.T) {
+		ml, err := layout.WalkCluster(newCluster(), layout.LayoutRules{ManifestIndex: true})
+		if err != nil {
+			t.Fatalf("walk cluster: %v", err)
+		}
+		if got := indexFile(ml); !strings.Contains(got, "name: app") {
+			t.Errorf("expected index.yaml on node layout, got %q", got)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		ml, err := layout.WalkCluster(newCluster(), layout.LayoutRules{})
+		if err != nil {
+			t.Fatalf("walk cluster: %v", err)
+		}
+		if got := indexFile(ml); got != "" {
+			t.Errorf("expected no index.yaml, got %q", got)
+		}
+	})
+}
+
 func TestWalkClusterFlatRoot(t *testing.T) {
 	obj1 := &unstructured.Unstructured{}
 	obj1.SetAPIVersion("v1")
@@ -311,6 +426,142 @@ func TestWalkClusterFlatRoot_DeepHierarchy(t *testing.T) {
 	}
 }
 
+// TestWalkClusterFlatRoot_Augmenter verifies that NodeGrouping=GroupFlat
+// keeps an augmenter app's per-app sub-layout (with its ExtraFiles) and moves
+// it under the flattened root instead of merging its resources.
+func TestWalkClusterFlatRoot_Augmenter(t *testing.T) {
+	cfg := &fakeAugmentingConfig{objs: []*client.Object{makeCM("a")}}
+	app := stack.NewApplication("a", "ns", cfg)
+	bundle := &stack.Bundle{Name: "bundle", Applications: []*stack.Application{app}}
+	grandchild := &stack.Node{Name: "grandchild", Bundle: bundle}
+	child := &stack.Node{Name: "child", Children: []*stack.Node{grandchild}}
+	grandchild.SetParent(child)
+	root := &stack.Node{Name: "root", Children: []*stack.Node{child}}
+	child.SetParent(root)
+	cluster := &stack.Cluster{Name: "demo", Node: root}
+
+	rules := layout.LayoutRules{
+		NodeGrouping:        layout.GroupFlat,
+		BundleGrouping:      layout.GroupFlat,
+		ApplicationGrouping: layout.GroupFlat,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+
+	if len(ml.Resources) != 0 {
+		t.Errorf("expected augmenter resources to stay in the sub-layout, got %d flat resources", len(ml.Resources))
+	}
+	if len(ml.Children) != 1 {
+		t.Fatalf("expected 1 per-app sub-layout, got %d", len(ml.Children))
+	}
+	appLayout := ml.Children[0]
+	if cfg.called != appLayout {
+		t.Errorf("AugmentLayout was not called with the per-app layout")
+	}
+	if got := appLayout.FullRepoPath(); got != "root/a" {
+		t.Errorf("sub-layout path = %q, want %q", got, "root/a")
+	}
+	if len(appLayout.ExtraFiles) != 1 || len(appLayout.ConfigMapGenerators) != 1 {
+		t.Errorf("expected ExtraFiles and ConfigMapGenerators to survive, got %+v / %+v", appLayout.ExtraFiles, appLayout.ConfigMapGenerators)
+	}
+}
+
+func TestWalkCluster_KustomizationPerApplication(t *testing.T) {
+	web := stack.NewApplication("web", "ns", &fakeConfig{objs: []*client.Object{makeCM("web")}})
+	api := stack.NewApplication("api", "ns", &fakeConfig{objs: []*client.Object{makeCM("api")}})
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{web, api}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	rules := layout.LayoutRules{
+		BundleGrouping:              layout.GroupFlat,
+		ApplicationGrouping:         layout.GroupFlat,
+		KustomizationPerApplication: true,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if len(ml.Resources) != 0 {
+		t.Errorf("expected no flat resources, got %d", len(ml.Resources))
+	}
+	if len(ml.Children) != 2 {
+		t.Fatalf("expected 2 per-app sub-layouts, got %d", len(ml.Children))
+	}
+	for _, child := range ml.Children {
+		if child.Mode != layout.KustomizationExplicit {
+			t.Errorf("%s: expected explicit kustomization mode, got %v", child.Name, child.Mode)
+		}
+		if len(child.Resources) != 1 {
+			t.Errorf("%s: expected 1 resource, got %d", child.Name, len(child.Resources))
+		}
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	kust, err := os.ReadFile(filepath.Join(dir, "clusters", "root", "web", "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("expected kustomization.yaml in application directory: %v", err)
+	}
+	if !strings.Contains(string(kust), "default-configmap-web.yaml") {
+		t.Errorf("expected application kustomization to list its file, got:\n%s", kust)
+	}
+	parent, err := os.ReadFile(filepath.Join(dir, "clusters", "root", "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read parent kustomization.yaml: %v", err)
+	}
+	for _, ref := range []string{"web", "api"} {
+		if !strings.Contains(string(parent), "- "+ref) {
+			t.Errorf("expected parent kustomization to reference %s, got:\n%s", ref, parent)
+		}
+	}
+}
+
+func TestWalkCluster_ApplicationFileSingle(t *testing.T) {
+	web := stack.NewApplication("web", "ns", &fakeConfig{objs: []*client.Object{makeCM("web"), makeCM("web-env")}})
+	api := stack.NewApplication("api", "ns", &fakeConfig{objs: []*client.Object{makeCM("api")}})
+	bundle := &stack.Bundle{Name: "apps", Applications: []*stack.Application{web, api}}
+	cluster := &stack.Cluster{Name: "demo", Node: &stack.Node{Name: "root", Bundle: bundle}}
+
+	rules := layout.LayoutRules{
+		BundleGrouping:      layout.GroupFlat,
+		ApplicationGrouping: layout.GroupFlat,
+		ApplicationFileMode: layout.AppFileSingle,
+	}
+	ml, err := layout.WalkCluster(cluster, rules)
+	if err != nil {
+		t.Fatalf("walk cluster: %v", err)
+	}
+	if len(ml.Resources) != 0 || len(ml.Children) != 2 {
+		t.Fatalf("expected 2 single-file application layouts, got %d resources and %d children", len(ml.Resources), len(ml.Children))
+	}
+
+	dir := t.TempDir()
+	if err := layout.WriteManifest(dir, layout.DefaultLayoutConfig(), ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	rootDir := filepath.Join(dir, "clusters", "root")
+	data, err := os.ReadFile(filepath.Join(rootDir, "web.yaml"))
+	if err != nil {
+		t.Fatalf("expected web.yaml in the node directory: %v", err)
+	}
+	if !strings.Contains(string(data), "web-env") {
+		t.Errorf("expected all application resources in web.yaml, got:\n%s", data)
+	}
+	kust, err := os.ReadFile(filepath.Join(rootDir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization.yaml: %v", err)
+	}
+	for _, file := range []string{"web.yaml", "api.yaml"} {
+		if !strings.Contains(string(kust), "- "+file) {
+			t.Errorf("expected kustomization.yaml to reference %s, got:\n%s", file, kust)
+		}
+	}
+}
+
 // TestWalkCluster_ClusterNameWithChildNodes verifies that when rules.ClusterName
 // is set, child-node sub-layouts are nested under the root node layout (not as
 // siblings of it under the cluster-level layout). The Flux integrator's
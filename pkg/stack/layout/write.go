@@ -3,6 +3,7 @@ package layout
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -14,7 +15,41 @@ import (
 )
 
 // WriteManifest writes a ManifestLayout to disk using the provided configuration.
+// Any cfg.PostProcessors are applied to ml before the first file is written.
 func WriteManifest(basePath string, cfg Config, ml *ManifestLayout) error {
+	if err := RunPostProcessors(ml, cfg.PostProcessors...); err != nil {
+		return err
+	}
+	return writeManifest(basePath, cfg.withProvenance(ml), ml)
+}
+
+// writeManifest writes ml and its children without running post-processors.
+func writeManifest(basePath string, cfg Config, ml *ManifestLayout) error {
+	return writeManifestTo(diskSink{base: basePath}, cfg, ml)
+}
+
+// ManifestDir returns the directory WriteManifest writes ml to with cfg,
+// relative to its base path: cfg.ManifestsDir ("clusters" when empty)
+// joined with ml.FullRepoPath(), or with ml.Namespace for a single-file
+// application, which is written into its parent's directory.
+func ManifestDir(cfg Config, ml *ManifestLayout) string {
+	dir := cfg.ManifestsDir
+	if dir == "" {
+		dir = "clusters"
+	}
+	appMode := ml.ApplicationFileMode
+	if appMode == AppFileUnset {
+		appMode = cfg.ApplicationFileMode
+	}
+	if appMode == AppFileSingle {
+		return filepath.ToSlash(filepath.Join(dir, ml.Namespace))
+	}
+	return filepath.ToSlash(filepath.Join(dir, ml.FullRepoPath()))
+}
+
+// writeManifestTo renders ml and its children with cfg into s. Paths passed
+// to s are relative to the base path given to WriteManifest.
+func writeManifestTo(s manifestSink, cfg Config, ml *ManifestLayout) error {
 	manifestFileName := cfg.ResolveManifestFileName()
 	if cfg.ManifestsDir == "" {
 		cfg.ManifestsDir = "clusters"
@@ -32,14 +67,12 @@ func WriteManifest(basePath string, cfg Config, ml *ManifestLayout) error {
 		kMode = cfg.ResolveKustomizationMode(ml.FluxPlacement)
 	}
 
-	var fullPath string
-	if appMode == AppFileSingle {
-		fullPath = filepath.Join(basePath, cfg.ManifestsDir, ml.Namespace)
-	} else {
-		fullPath = filepath.Join(basePath, cfg.ManifestsDir, ml.FullRepoPath())
+	fullPath := ManifestDir(cfg, ml)
+	if err := s.writeDir(fullPath); err != nil {
+		return err
 	}
-	if err := os.MkdirAll(fullPath, 0755); err != nil {
-		return errors.NewFileError("create", fullPath, "directory creation failed", err)
+	if err := writeExtraFilesToSink(s, "", ml.repoFiles.files(fullPath)); err != nil {
+		return err
 	}
 
 	fileGroups := map[string][]client.Object{}
@@ -75,9 +108,9 @@ func WriteManifest(basePath string, cfg Config, ml *ManifestLayout) error {
 
 	for _, fileName := range sortedFileNames {
 		objs := fileGroups[fileName]
-		f, err := os.Create(filepath.Join(fullPath, fileName))
-		if err != nil {
-			return err
+		if ml.allFrozen(objs) && s.keepFile(path.Join(fullPath, fileName)) {
+			// Keep the existing file; it is still listed below.
+			continue
 		}
 
 		// Convert to []*client.Object for the kio encoder
@@ -88,23 +121,26 @@ func WriteManifest(basePath string, cfg Config, ml *ManifestLayout) error {
 		}
 
 		// Use proper Kubernetes YAML encoder
-		data, err := kio.EncodeObjectsToYAML(objPtrs)
+		data, err := kio.EncodeObjectsToYAMLWithOptions(objPtrs, cfg.EncodeOptions)
 		if err != nil {
-			_ = f.Close()
 			return err
 		}
 
-		if _, err = f.Write(data); err != nil {
-			_ = f.Close()
-			return err
+		if cfg.Provenance {
+			data = append(cfg.provenance.header(objs), data...)
 		}
 
-		if err := f.Close(); err != nil {
+		if err := s.writeFile(path.Join(fullPath, fileName), data); err != nil {
 			return err
 		}
 	}
 
-	if err := writeExtraFilesToDisk(fullPath, ml.ExtraFiles); err != nil {
+	keepExtra := func(name string) bool { return s.keepFile(path.Join(fullPath, name)) }
+	extraFiles := ml.writableExtraFiles(keepExtra)
+	if cfg.Provenance {
+		extraFiles = cfg.provenance.withProvenanceHeaders(extraFiles)
+	}
+	if err := writeExtraFilesToSink(s, fullPath, extraFiles); err != nil {
 		return err
 	}
 
@@ -117,34 +153,26 @@ func WriteManifest(basePath string, cfg Config, ml *ManifestLayout) error {
 	skipClusterRoot := ml.Namespace != "" &&
 		strings.Count(ml.Namespace, string(filepath.Separator)) == 0 &&
 		ml.Name == "" &&
-		len(fileGroups) == 0
+		len(fileGroups) == 0 &&
+		len(ml.References) == 0
 
 	// Generate kustomization.yaml if there are resources or children, except at the empty cluster root.
 	// Every directory with manifests should have a kustomization.yaml for proper GitOps workflow.
-	if !skipClusterRoot && (len(fileGroups) > 0 || len(ml.Children) > 0) {
-		kustomPath := filepath.Join(fullPath, "kustomization.yaml")
-		kf, err := os.Create(kustomPath)
-		if err != nil {
-			return err
-		}
-
-		var writeErr error
-		writeStr := func(s string) {
-			if writeErr != nil {
-				return
-			}
-			_, writeErr = kf.WriteString(s)
+	if !skipClusterRoot && !ml.writtenAsFile() && (len(fileGroups) > 0 || len(ml.Children) > 0 || len(ml.References) > 0) {
+		var kb strings.Builder
+		if cfg.Provenance {
+			kb.Write(cfg.provenance.header(nil))
 		}
 
 		// Write proper YAML header
-		writeStr("apiVersion: kustomize.config.k8s.io/v1beta1\n")
-		writeStr("kind: Kustomization\n")
-		writeStr("resources:\n")
+		kb.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\n")
+		kb.WriteString("kind: Kustomization\n")
+		kb.WriteString("resources:\n")
 
 		// Add resource files if in explicit mode OR if it's a leaf directory with no children
 		if kMode == KustomizationExplicit || len(ml.Children) == 0 {
 			for _, file := range sortedFileNames {
-				writeStr(fmt.Sprintf("  - %s\n", file))
+				kb.WriteString(fmt.Sprintf("  - %s\n", file))
 			}
 		}
 		// In recursive mode, only reference child directories, not files
@@ -167,38 +195,86 @@ func WriteManifest(basePath string, cfg Config, ml *ManifestLayout) error {
 				continue
 			}
 			if child.ApplicationFileMode == AppFileSingle {
-				writeStr(fmt.Sprintf("  - %s.yaml\n", child.Name))
+				kb.WriteString(fmt.Sprintf("  - %s.yaml\n", child.Name))
 			} else {
 				// For FluxIntegratedPerLayout mode, reference Flux Kustomization YAML files instead of directories.
 				// Always use FilePerResource — each child must have a unique filename.
 				if ml.FluxPlacement == FluxIntegratedPerLayout {
 					fluxKustName := manifestFileName("flux-system", "kustomization", child.Name, FilePerResource)
 					if _, dup := listedInResources[fluxKustName]; !dup {
-						writeStr(fmt.Sprintf("  - %s\n", fluxKustName))
+						kb.WriteString(fmt.Sprintf("  - %s\n", fluxKustName))
 					}
 				} else {
-					writeStr(fmt.Sprintf("  - %s\n", child.Name))
+					kb.WriteString(fmt.Sprintf("  - %s\n", child.Name))
 				}
 			}
 		}
-
-		writeStr(renderConfigMapGeneratorBlock(ml.ConfigMapGenerators))
-
-		if writeErr != nil {
-			_ = kf.Close()
-			return errors.Wrapf(writeErr, "writing kustomization.yaml at %s", kustomPath)
+		for _, ref := range ml.References {
+			kb.WriteString(fmt.Sprintf("  - %s\n", ref))
 		}
 
-		if err := kf.Close(); err != nil {
-			return err
+		kb.WriteString(renderConfigMapGeneratorBlock(ml.ConfigMapGenerators))
+
+		kustomPath := path.Join(fullPath, "kustomization.yaml")
+		if err := s.writeFile(kustomPath, []byte(kb.String())); err != nil {
+			return errors.Wrapf(err, "writing kustomization.yaml at %s", kustomPath)
 		}
 	}
 
 	for _, child := range ml.Children {
-		if err := WriteManifest(basePath, cfg, child); err != nil {
+		if err := writeManifestTo(s, cfg, child); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// withProvenance returns cfg with the provenance of ml collected when
+// cfg.Provenance is set.
+func (cfg Config) withProvenance(ml *ManifestLayout) Config {
+	if cfg.Provenance {
+		cfg.provenance = collectProvenance(ml)
+	}
+	return cfg
+}
+
+// manifestSink is a fileSink that is also told about frozen files, which
+// WriteManifest keeps instead of writing. keepFile reports whether the
+// existing file was kept; when it returns false the file is missing and is
+// written like any other.
+type manifestSink interface {
+	fileSink
+	keepFile(filePath string) bool
+}
+
+// diskSink writes files below base on the local filesystem.
+type diskSink struct {
+	base string
+}
+
+func (d diskSink) writeDir(dirPath string) error {
+	full := filepath.Join(d.base, filepath.FromSlash(dirPath))
+	if err := os.MkdirAll(full, 0755); err != nil {
+		return errors.NewFileError("create", full, "directory creation failed", err)
+	}
+	return nil
+}
+
+func (d diskSink) writeFile(filePath string, data []byte) error {
+	full := filepath.Join(d.base, filepath.FromSlash(filePath))
+	if err := os.WriteFile(full, data, 0644); err != nil {
+		return errors.NewFileError("write", full, "file write failed", err)
+	}
+	return nil
+}
+
+func (d diskSink) keepFile(filePath string) bool {
+	return fileExists(filepath.Join(d.base, filepath.FromSlash(filePath)))
+}
+
+// fileExists reports whether a regular file exists at p.
+func fileExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.Mode().IsRegular()
+}
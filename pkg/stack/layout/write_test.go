@@ -8,6 +8,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kio "github.com/go-kure/kure/pkg/io"
 )
 
 func testObject(apiVersion, kind, name, namespace string) client.Object {
@@ -714,6 +716,31 @@ func TestWriteManifest_FileNamingKindName(t *testing.T) {
 	}
 }
 
+func TestWriteManifest_EncodeOptions(t *testing.T) {
+	obj := testObject("v1", "ConfigMap", "styled", "default")
+	ml := &ManifestLayout{
+		Name:      "app",
+		Namespace: "cluster",
+		Resources: []client.Object{obj},
+	}
+
+	cfg := DefaultLayoutConfig()
+	cfg.EncodeOptions = kio.EncodeOptions{KubernetesFieldOrder: true, Indent: 4, QuoteStyle: kio.QuoteDouble}
+	dir := t.TempDir()
+	if err := WriteManifest(dir, cfg, ml); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "clusters", "cluster", "app", "default-configmap-styled.yaml"))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	want := "apiVersion: \"v1\"\nkind: \"ConfigMap\"\nmetadata:\n    name: \"styled\"\n    namespace: \"default\"\n"
+	if string(data) != want {
+		t.Errorf("unexpected manifest:\ngot:\n%s\nwant:\n%s", data, want)
+	}
+}
+
 func TestWriteManifest_FileNamingDefault_Unchanged(t *testing.T) {
 	obj := testObject("v1", "ConfigMap", "test", "ns")
 